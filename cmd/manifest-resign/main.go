@@ -0,0 +1,13 @@
+package main
+
+import (
+	"os"
+
+	"pluto-backend/internal/manifest/bootstrap"
+)
+
+func main() {
+	if err := bootstrap.RunResign(os.Args[1:]); err != nil {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,13 @@
+package main
+
+import (
+	"os"
+
+	"pluto-backend/internal/keys/bootstrap"
+)
+
+func main() {
+	if err := bootstrap.RunKeys(os.Args[1:]); err != nil {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,13 @@
+package main
+
+import (
+	"os"
+
+	"pluto-backend/internal/authoring/bootstrap"
+)
+
+func main() {
+	if err := bootstrap.RunManifestTool(os.Args[1:]); err != nil {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,11 @@
+package main
+
+import (
+	"pluto-backend/internal/signer/bootstrap"
+)
+
+func main() {
+	if err := bootstrap.RunSigner(); err != nil {
+		panic(err)
+	}
+}
@@ -18,24 +18,29 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9RWTW/bRhD9K4ttDy2gSP5IL7q5qNG6aRChadBDUxgMtbYYS+SGXAYxDAP6SOoANWLA",
-	"6KGXpgj6B2jHihnJpP/Cm39U7JK2JXlVX+oCvVHi7M6beW/mcYe7QUcGvvBVxOs7PHJbouOYxxUpvws2",
-	"Pf978SwWkdJ/yTCQIlSeMAFOs+kpL/Cd9vSvxlSU2paC13mkQs/f5LsV3hSRG3pSx/I6x2/IcY4cY2RI",
-	"qY8hxrSPDAkdMKTI6CVy6uIMCf2ClA7YZ0ioS6/K4BH1aUBdJBVGXQzxHilyZAwpo34V59XPeeUCQ/Dk",
-	"qXCVxuBIuf5chJHBsDML6RBD6lLPZMM5dZFijBwfMDQYNYQMSfnmTAdX2GO+WF2qLj/mE/kma37uuWLd",
-	"a1qy/aUvxQhJUTj9io+68BOdjfpI6WXxmvq6EYwG1KM+dZHjo37CMRIboEeP1r6yYgkiC4jfLY1GjiOG",
-	"t3hnr7fjuA8essW71UVr0bsVHopnsReKJq//NNEBg2Cagp8tFF3JL5KBHwmL/lxXRNG6CraEhcVvf/yB",
-	"4QRjXchpiT6nHnIcM4zYSmPN1hzxQnqhiNY9my7ema6PGD4gxammjWlSMDLCSBiOGfWMJgfIcIKEXvEK",
-	"3wjCjqN4nXu+Wl66Sur5SmyKUGeNRKT7YNWH5tFcSz0tSaQVhhHyQg00oDcMZ0acGXKGlHrFNNE+TnWt",
-	"Wje0X8qYtYPNIFaToOLYUPLP5E11eqpJU9htNK6GYRDO51Do15ZWv8W5qSUxwzFkyOk1UhxhhPRGuMWl",
-	"NjTfCKetWvPhRMpRcQHshdORbXN668aE5TFbxkb8pO2598T2/KTShKxvie3rjWis3r8jfDdoiiYr4piO",
-	"uwnQxJXXQelgz98IzHb2lCmy0Y5VwFZi1WIPRahHtZyQyy3JF6sL1QWzQKTwHenxOl+uLlSXeYVLR7VM",
-	"KTUnVq2aI+Wdtp5dU11QeIeu2NFVrTV5/XK6eYFbROrLoGnqdwNfCd8ccaRse645VHsaFau6MCj99Gko",
-	"Nnidf1K7crBaaV+1We/anW6QCmNh/igoMdCXFhZuIX3Juck/u/fNqA7p9YXZJTQwlja99c0u1m2/+y8C",
-	"nJ5KG7o/MMSxXvUXjjSxQzWaL/5TNIfIdHOMw2fI6MDY0+VKSIoF2S0RJ2YeorjTccJtffxPsxEHtKc/",
-	"GmifGW84R85MlzO8N8cKf8v0B4XV9s2thcKL+bpTjuymsCj8a6EuZ5/fotauLxhLA+eskf8bj4elIAcX",
-	"njbAEcZIae9CpSOM6Q3taSturN4vGGuZpT+XqMITbpOiGdexNObBvZlSiyPMbQl3Sx/Y/TsAAP//1ppM",
-	"h6kLAAA=",
+	"H4sIAAAAAAAC/9RX324Txxt9ldH8fhdBdbwbkhbwHS3QBgpYUNSLUkWT3Uk8yXpnmJ1FTVEkx4aC1IhI",
+	"qBe9KRXqCywBE+PEzit880bVzK7jtTMmrSoq1RfWend25nznnO+PH+GANwWPaawSXHuEk6BBm8ReXhbi",
+	"a77O4jv0QUoTZW4JyQWVilG7gIQhU4zHJJr8VZ9YpbYExTWcKMnidbxdwSFNAsmEWYtrGH6BIRzDEA5h",
+	"AD3dhi4c6l0YQKb3EPRgoB/DULfgCDL9E/T0HpqDTLf0k2JxX7d1R7cgqyDdgi68gR4MYYCgh3S7CsfV",
+	"c7gywsBXN2igDAYixMpDKhOL4dE0pBfQ1S29Y0+DY92CHhzCEN5BFwYFhAFkxZMjs7iC7uOF6vnq4n1c",
+	"Oq8c80MW0BUWOk77w2wKfcjywPXP8N4E/tacptvQ04/zx7ptiEC6o3d0W7dgCO/NFexD5gJ0797yFScW",
+	"njhA/OogGobwGsFLeOWOt0mC23fRwlJ1wRn0dgVL+iBlkoa49l2JAYtgUoLvHRKN7ZcIHifU4b8goEmy",
+	"ovgmdah4/dtvELyFQxPIQYF+qHdgCPsI+uhyfdlFDv1BMEmTFebyxSvLeh/BO+jBgZENGVGgb42RIdhH",
+	"esd6sgMDeAuZfoIreI3LJlG4hlmsFs+PD2WxoutUmlMTmhgenP4wOtpt9Y6xJPQqCPowzN2gO/o5giNr",
+	"zgEMEfT0Tp5NehcOTKzGN3q3sDGK+DpPVRlUmrJwjGmGeBNMT5A0gX22jDxVM8vIhmJu9ZavoLkNxc6Z",
+	"bDhAUxhOKfcPORzozohDex8ORtz9bbZKSCo2Ohcv1xiNwqtScnmakSZNErJOndVTEEmaVFHpTOIjvYce",
+	"pFRueYKohtegJKQSwbEpmZDZ3DWlI3MRKDiLnftev3v7FqrnT9HcnWtfoM8u+QvnUO75vHZ3UV66IZvM",
+	"tszahTRFZA7zIh6QiP1IzM4ejT3FVETPJHTEh4vHryiJVGN2kUgUUam9GsPgm2drmL/mOrEu+WpEmw7+",
+	"X+pn0IPX0C9qwbigtqGLDHEXLvoX0BwRImJBToLId/tkI+GxqaKT6EOqCIucRqDGO3YRU7RpL/4v6Rqu",
+	"4f95457uFQ3dK/lt+yQoIiXZMr9ZnCgSB3SSJ48I5jVJzNZoohLPX7jkr15cXZr3fd+fv2C+Lpovv/Rx",
+	"GUvmmb8cOuNwCLTkL7mqZG6WCYS3uELXeBqHrnPzG+XlqYxrIkoVrxW012Ku5tfcG0w5wj4dgah80CDp",
+	"asSCG3RrtiuFXbKySbdO26h+9eY8jQMe0hDl65BZdxa+0panQW1bjde4VSCnEdcNEehyqhroLpWmOxdN",
+	"8WQwwgtVv+rbmUHQmAiGa3ix6lcXjU+JathQPJKqhkeEmI9Mu7bR8bzOm4ity430Jw0dnzjicx7a+AMe",
+	"KxrbV8qpYVJiPJOeZfDpcXV7kiAlU2pv5JJY6Od9/yMcX2huz58e9Wx37upno/k20x3dPjXo2fHL0L70",
+	"QYDl0vHXgY7Klwvfb9CFfTPfjcbQUik3eD79l/G8MG3ZdiwzYA30np1KS1XW9vRWgTmzOZGkzSaRW+b1",
+	"3+0g1NFPzX8FvYvsSHgMQ2SZHsAb+1o+1g7M/wjntG93zV2e59h8kbbr1OHyL6k6yX/8Ef12usg4CJxR",
+	"Sv57Sr4oTNkZDbMdeA2H0NNPR07tw6F+rp+avlu/ejPXrGFng5lS5aPDxxRpajhxEHP7xlSo+SsoaNBg",
+	"07yw/ecAYygcJ6IPAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
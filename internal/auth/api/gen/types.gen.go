@@ -34,10 +34,24 @@ type AppLoginResponse struct {
 	SessionId openapi_types.UUID `json:"session_id"`
 }
 
-// ErrorResponse defines model for ErrorResponse.
-type ErrorResponse struct {
-	// Error Описание ошибки
-	Error string `json:"error"`
+// AppLogoutRequest defines model for AppLogoutRequest.
+type AppLogoutRequest struct {
+	// Jti JWT ID (jti) из access_token
+	Jti string `json:"jti"`
+
+	// SessionId UUID сессии, которую нужно отозвать
+	SessionId openapi_types.UUID `json:"session_id"`
+}
+
+// FieldError defines model for FieldError.
+type FieldError struct {
+	Message string `json:"message"`
+
+	// Parameter Имя query/path/header параметра
+	Parameter *string `json:"parameter,omitempty"`
+
+	// Pointer JSON Pointer (RFC 6901) на поле тела запроса
+	Pointer *string `json:"pointer,omitempty"`
 }
 
 // HealthResponse defines model for HealthResponse.
@@ -45,6 +59,17 @@ type HealthResponse struct {
 	Status string `json:"status"`
 }
 
+// Problem Ошибка в формате RFC 7807 (application/problem+json)
+type Problem struct {
+	Detail    *string       `json:"detail,omitempty"`
+	Errors    *[]FieldError `json:"errors,omitempty"`
+	Instance  *string       `json:"instance,omitempty"`
+	RequestId *string       `json:"requestId,omitempty"`
+	Status    int           `json:"status"`
+	Title     string        `json:"title"`
+	Type      string        `json:"type"`
+}
+
 // PublicKeyResponse defines model for PublicKeyResponse.
 type PublicKeyResponse struct {
 	// PublicKey PEM-encoded public key
@@ -9,6 +9,7 @@ import (
 	"github.com/rs/zerolog"
 	"pluto-backend/internal/auth/api/gen"
 	"pluto-backend/internal/auth/service"
+	"pluto-backend/internal/platform/problem"
 )
 
 // Handlers реализует интерфейс gen.ServerInterface.
@@ -32,14 +33,14 @@ func (h *Handlers) AppLogin(w http.ResponseWriter, r *http.Request) {
 	var req gen.AppLoginRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.Logger.Error().Err(err).Msg("AppLogin: failed to decode request body")
-		ErrorJSON(w, http.StatusBadRequest, "invalid_request", "failed to parse JSON body")
+		ErrorJSON(w, r, http.StatusBadRequest, problem.CodeInvalidRequest, "failed to parse JSON body")
 		return
 	}
 
 	resp, err := h.Svc.AppLogin(r.Context(), req)
 	if err != nil {
 		h.Logger.Error().Err(err).Msg("AppLogin: service error")
-		ErrorJSON(w, http.StatusInternalServerError, "internal_error", "failed to create or retrieve session")
+		ErrorJSON(w, r, http.StatusInternalServerError, problem.CodeInternal, "failed to create or retrieve session")
 		return
 	}
 
@@ -51,13 +52,13 @@ func (h *Handlers) AppLogin(w http.ResponseWriter, r *http.Request) {
 //	var req gen.AppLogoutRequest
 //	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 //		h.Logger.Error().Err(err).Msg("AppLogout: failed to decode request body")
-//		ErrorJSON(w, http.StatusBadRequest, "invalid_request", "failed to parse JSON body")
+//		ErrorJSON(w, r, http.StatusBadRequest, problem.CodeInvalidRequest, "failed to parse JSON body")
 //		return
 //	}
 //
 //	if err := h.Svc.AppLogout(r.Context(), req); err != nil {
 //		h.Logger.Error().Err(err).Msg("AppLogout: service error")
-//		ErrorJSON(w, http.StatusInternalServerError, "internal_error", "failed to revoke session")
+//		ErrorJSON(w, r, http.StatusInternalServerError, problem.CodeInternal, "failed to revoke session")
 //		return
 //	}
 //
@@ -69,7 +70,7 @@ func (h *Handlers) GetPublicKey(w http.ResponseWriter, r *http.Request) {
 	resp, err := h.Svc.GetPublicKey(r.Context())
 	if err != nil {
 		h.Logger.Error().Err(err).Msg("GetPublicKey: service error")
-		ErrorJSON(w, http.StatusInternalServerError, "internal_error", "failed to fetch public key")
+		ErrorJSON(w, r, http.StatusInternalServerError, problem.CodeInternal, "failed to fetch public key")
 		return
 	}
 	ResponseJSON(w, http.StatusOK, resp)
@@ -87,21 +88,7 @@ func ResponseJSON(w http.ResponseWriter, status int, payload interface{}) {
 	buf.WriteTo(w)
 }
 
-// ErrorJSON формирует JSON-ошибку в едином формате.
-func ErrorJSON(w http.ResponseWriter, status int, code, message string, details ...interface{}) {
-	resp := ErrorResponse{
-		Code:    code,
-		Message: message,
-	}
-	if len(details) > 0 {
-		resp.Details = details[0]
-	}
-	ResponseJSON(w, status, resp)
-}
-
-// ErrorResponse описывает формат JSON-ошибки.
-type ErrorResponse struct {
-	Code    string      `json:"code"`
-	Message string      `json:"message"`
-	Details interface{} `json:"details,omitempty"`
+// ErrorJSON формирует ошибку в формате application/problem+json (RFC 7807).
+func ErrorJSON(w http.ResponseWriter, r *http.Request, status int, code, detail string, errs ...problem.FieldError) {
+	problem.Write(w, r, problem.New(status, code, detail).WithErrors(errs...))
 }
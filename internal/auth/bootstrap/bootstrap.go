@@ -4,6 +4,7 @@ package bootstrap
 import (
 	"context"
 	"encoding/base64"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
@@ -19,7 +20,9 @@ import (
 	"pluto-backend/internal/auth/service"
 	"pluto-backend/internal/platform/db"
 	"pluto-backend/internal/platform/errors"
+	"pluto-backend/internal/platform/keystore"
 	"pluto-backend/internal/platform/logger"
+	"pluto-backend/internal/platform/remotesign"
 	routerpkg "pluto-backend/internal/platform/router"
 	routermw "pluto-backend/internal/platform/router/middleware"
 
@@ -38,19 +41,7 @@ func RunAuthService() error {
 	}
 	defer sqlDB.Close()
 
-	_, err = base64.StdEncoding.DecodeString(cfg.Signing.PrivateKeyB64)
-	if err != nil {
-		return logFatalWrap(log, err, "invalid signing.privateKey")
-	}
-	_, err = base64.StdEncoding.DecodeString(cfg.Signing.PublicKeyB64)
-	if err != nil {
-		return logFatalWrap(log, err, "invalid signing.publicKey")
-	}
-
-	signer, err := service.NewRSASigner(
-		cfg.Signing.PrivateKeyB64,
-		cfg.Signing.PublicKeyB64,
-	)
+	signer, err := newSigner(cfg.Signing)
 	if err != nil {
 		return logFatalWrap(log, err, "failed to initialize signer")
 	}
@@ -70,12 +61,12 @@ func RunAuthService() error {
 	}
 
 	r := chi.NewRouter()
+	r.Use(chiMiddleware.RequestID)
+	r.Use(chiMiddleware.RealIP)
+	r.Use(routerpkg.Recoverer(log))
 	r.Use(routerpkg.RequestLogger(log))
 	r.Use(middleware.OapiRequestValidatorWithOptions(swagger, &oapiOpts))
 	r.Use(routermw.JSONContentType)
-	r.Use(chiMiddleware.RequestID)
-	r.Use(chiMiddleware.RealIP)
-	r.Use(chiMiddleware.Recoverer)
 
 	// 11. Монтируем сгенерированные обработчики из OpenAPI
 	serverOpts := gen.ChiServerOptions{
@@ -119,3 +110,53 @@ func logFatalWrap(log *zerolog.Logger, err error, msg string) error {
 	log.Fatal().Err(err).Msg(msg)
 	return err
 }
+
+// remoteStartupTimeout — сколько ждать сервис подписи при старте, включая повторы
+const remoteStartupTimeout = 30 * time.Second
+
+// newSigner — внешний сервис подписи (signing.remote), ключ из зашифрованного
+// хранилища (signing.keystore) или, для старых конфигов, ключ прямо из конфига
+func newSigner(cfg config.SigningConfig) (service.Signer, error) {
+	if cfg.Remote.Endpoint != "" {
+		client, err := remotesign.NewClient(remotesign.Config{
+			Endpoint: cfg.Remote.Endpoint,
+			Token:    cfg.Remote.Token,
+			Timeout:  cfg.Remote.Timeout,
+			Retries:  cfg.Remote.Retries,
+		})
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), remoteStartupTimeout)
+		defer cancel()
+		return service.NewRemoteRSASigner(ctx, client, cfg.Remote.Key)
+	}
+
+	// открытая пара из конфига — переопределение для разработки, она важнее хранилища
+	if cfg.Keystore.Path != "" && cfg.PrivateKeyB64 == "" {
+		ks, err := keystore.Load(cfg.Keystore.Path, cfg.Keystore.PassphraseFile)
+		if err != nil {
+			return nil, fmt.Errorf("signing.keystore: %w", err)
+		}
+		key, err := ks.Get(cfg.Keystore.Key)
+		if err != nil {
+			return nil, fmt.Errorf("signing.keystore: %w", err)
+		}
+		priv, err := key.RSA()
+		if err != nil {
+			return nil, fmt.Errorf("signing.keystore: %w", err)
+		}
+		return service.NewRSASignerFromKey(priv), nil
+	}
+
+	if cfg.PrivateKeyB64 == "" {
+		return nil, fmt.Errorf("signing: no key configured: set signing.keystore.path or signing.remote.endpoint")
+	}
+	if _, err := base64.StdEncoding.DecodeString(cfg.PrivateKeyB64); err != nil {
+		return nil, fmt.Errorf("invalid signing.privateKey: %w", err)
+	}
+	if _, err := base64.StdEncoding.DecodeString(cfg.PublicKeyB64); err != nil {
+		return nil, fmt.Errorf("invalid signing.publicKey: %w", err)
+	}
+	return service.NewRSASigner(cfg.PrivateKeyB64, cfg.PublicKeyB64)
+}
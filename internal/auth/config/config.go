@@ -3,6 +3,7 @@ package config
 import (
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -26,11 +27,35 @@ type LoggingConfig struct {
 	Level string `mapstructure:"level"`
 }
 
+// SigningConfig — ключ RS256 для JWT. Открытая пара в репозитории не хранится и задаётся
+// только для разработки (PLUTO_SIGNING_PRIVATE_KEY_B64, PLUTO_SIGNING_PUBLIC_KEY_B64) —
+// тогда она важнее keystore.path
 type SigningConfig struct {
 	// Base64-encoded PEM приватного ключа
 	PrivateKeyB64 string `mapstructure:"private_key_b64"`
 	// Base64-encoded PEM публичного ключа
 	PublicKeyB64 string `mapstructure:"public_key_b64"`
+	// Внешний сервис подписи; если задан endpoint, пара выше не нужна
+	Remote RemoteSignerConfig `mapstructure:"remote"`
+	// Зашифрованное хранилище ключей; если задан path, пара выше не нужна
+	Keystore KeystoreConfig `mapstructure:"keystore"`
+}
+
+// KeystoreConfig — ключ RS256 из зашифрованного хранилища (internal/platform/keystore).
+// Пароль берётся из PLUTO_KEYSTORE_PASSPHRASE, иначе из passphrase_file
+type KeystoreConfig struct {
+	Path           string `mapstructure:"path"`
+	PassphraseFile string `mapstructure:"passphrase_file"`
+	Key            string `mapstructure:"key"` // id ключа в хранилище
+}
+
+// RemoteSignerConfig — внешний сервис подписи (cmd/pluto-signer)
+type RemoteSignerConfig struct {
+	Endpoint string        `mapstructure:"endpoint"` // unix:///path/to.sock или http://host:port
+	Key      string        `mapstructure:"key"`
+	Token    string        `mapstructure:"token"`
+	Timeout  time.Duration `mapstructure:"timeout"` // на попытку
+	Retries  int           `mapstructure:"retries"`
 }
 
 func loadConfig(path string) Config {
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"pluto-backend/internal/platform/remotesign"
+)
+
+// RemoteRSASigner выпускает JWT RS256, подпись для которых делает внешний сервис
+// подписи (cmd/pluto-signer): приватный ключ в процесс auth-service не попадает
+type RemoteRSASigner struct {
+	client    *remotesign.Client
+	key       string
+	publicKey *rsa.PublicKey
+}
+
+// NewRemoteRSASigner запрашивает у сервиса публичную часть ключа key
+func NewRemoteRSASigner(ctx context.Context, client *remotesign.Client, key string) (*RemoteRSASigner, error) {
+	info, err := client.Key(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if info.Algorithm != remotesign.AlgRS256 {
+		return nil, fmt.Errorf("remote key %q: algorithm %s, want %s", key, info.Algorithm, remotesign.AlgRS256)
+	}
+	pubIfc, err := x509.ParsePKIXPublicKey(info.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("remote key %q: %w", key, err)
+	}
+	pub, ok := pubIfc.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("public key is not RSA")
+	}
+	return &RemoteRSASigner{client: client, key: key, publicKey: pub}, nil
+}
+
+// Sign собирает JWT с теми же claims, что RSASigner; подпись сверяется с публичным
+// ключом, прежде чем токен уйдёт клиенту
+func (s *RemoteRSASigner) Sign(fingerprint []byte, exp time.Time, jti string) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, tokenClaims(fingerprint, exp, jti))
+	signingString, err := token.SigningString()
+	if err != nil {
+		return "", err
+	}
+
+	sig, err := s.client.Sign(context.Background(), s.key, []byte(signingString))
+	if err != nil {
+		return "", err
+	}
+	digest := sha256.Sum256([]byte(signingString))
+	if err := rsa.VerifyPKCS1v15(s.publicKey, crypto.SHA256, digest[:], sig); err != nil {
+		return "", fmt.Errorf("remote signer returned a signature that does not verify: %w", err)
+	}
+	return signingString + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+func (s *RemoteRSASigner) GetPublicKey() (string, error) {
+	return publicKeyPEM(s.publicKey)
+}
@@ -78,22 +78,34 @@ func NewRSASigner(privateKeyB64, publicKeyB64 string) (*RSASigner, error) {
 	}, nil
 }
 
+// NewRSASignerFromKey — подписант из уже разобранного ключа, например из keystore
+func NewRSASignerFromKey(privKey *rsa.PrivateKey) *RSASigner {
+	return &RSASigner{privateKey: privKey, publicKey: &privKey.PublicKey}
+}
+
 // Sign генерирует JWT с полным payload: issuer, sub=jti, iat, exp, fingerprint
 func (s *RSASigner) Sign(fingerprint []byte, exp time.Time, jti string) (string, error) {
-	claims := jwt.MapClaims{
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, tokenClaims(fingerprint, exp, jti))
+	return token.SignedString(s.privateKey)
+}
+
+func tokenClaims(fingerprint []byte, exp time.Time, jti string) jwt.MapClaims {
+	return jwt.MapClaims{
 		"iss": "pluto-auth",
 		"jti": jti,
 		"iat": time.Now().Unix(),
 		"exp": exp.Unix(),
 		"fp":  string(fingerprint), // положим JSON-фингерпринта в нативный claim "fp"
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
-	return token.SignedString(s.privateKey)
 }
 
 // GetPublicKey возвращает PEM-encoded public key
 func (s *RSASigner) GetPublicKey() (string, error) {
-	derBytes, _ := x509.MarshalPKIXPublicKey(s.publicKey)
+	return publicKeyPEM(s.publicKey)
+}
+
+func publicKeyPEM(pub *rsa.PublicKey) (string, error) {
+	derBytes, _ := x509.MarshalPKIXPublicKey(pub)
 	if derBytes == nil {
 		return "", errors.New("не удалось маршалить public key")
 	}
@@ -0,0 +1,348 @@
+package bootstrap
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"pluto-backend/internal/manifest/api/gen"
+	"pluto-backend/internal/manifest/canonical"
+	"pluto-backend/internal/manifest/validation"
+	"pluto-backend/internal/platform/jwk"
+)
+
+// Переменные окружения для значений, которые не стоит передавать флагами
+const (
+	authorKeyEnv = "PLUTO_AUTHOR_KEY"
+	tokenEnv     = "PLUTO_TOKEN"
+)
+
+const usage = `usage: pluto-manifest <command> [flags] <dir>
+
+<dir> — каталог манифеста:
+  meta.yaml               author, category, icon, tags, permissions, actions, compatibility
+  ui.json                 конфигурация интерфейса
+  script.js               код скрипта
+  localizations/<loc>.json  строки локали; en с title и description обязательна
+
+commands:
+  lint     проверить каталог теми же правилами, что manifest-service; с -server — ещё и
+           компоненты ui и разрешения по реестрам сервера
+  build    напечатать тело POST /api/manifests; с -key — с подписью автора
+  publish  проверить, подписать и опубликовать через API
+
+"pluto-manifest <command> -h" — флаги команды`
+
+// RunManifestTool — инструмент автора плагина: собирает манифест из каталога, проверяет
+// его кодом сервиса (internal/manifest/validation), строит канонический payload
+// (internal/manifest/canonical), подписывает ключом автора и публикует
+func RunManifestTool(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		return errors.New("no command")
+	}
+
+	var err error
+	switch args[0] {
+	case "lint":
+		err = runLint(args[1:], os.Stdout)
+	case "build":
+		err = runBuild(args[1:], os.Stdout)
+	case "publish":
+		err = runPublish(args[1:], os.Stdout)
+	case "-h", "-help", "--help", "help":
+		fmt.Fprintln(os.Stdout, usage)
+		return nil
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		err = fmt.Errorf("unknown command %q", args[0])
+	}
+	if err != nil && !errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintln(os.Stderr, "pluto-manifest:", err)
+	}
+	return err
+}
+
+func runLint(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	server := fs.String("server", "", "also check ui components and permissions against the registries of this manifest-service")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	dir, err := dirArg(fs)
+	if err != nil {
+		return err
+	}
+	m, err := loadManifest(dir, out)
+	if err != nil {
+		return err
+	}
+	if *server != "" {
+		problems, err := registryProblems(m, newAPIClient(*server, ""))
+		if err != nil {
+			return err
+		}
+		if len(problems) > 0 {
+			for _, p := range problems {
+				printProblem(out, p.Pointer, p.Parameter, p.Message)
+			}
+			return fmt.Errorf("%s: %d problem(s)", dir, len(problems))
+		}
+	}
+	fmt.Fprintf(out, "%s: ok\n", dir)
+	return nil
+}
+
+func runBuild(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("build", flag.ContinueOnError)
+	keyFile := fs.String("key", "", "author Ed25519 private key file (base64, as printed by pluto-keys generate); "+authorKeyEnv+" also works")
+	version := fs.String("version", canonical.InitialVersion, "version the author signature is made for")
+	payloadOnly := fs.Bool("canonical", false, "print the canonical author payload instead of the request body")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	dir, err := dirArg(fs)
+	if err != nil {
+		return err
+	}
+
+	m, err := loadManifest(dir, os.Stderr)
+	if err != nil {
+		return err
+	}
+	if *payloadOnly {
+		payload, err := authorPayload(m, *version)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(append(payload, '\n'))
+		return err
+	}
+
+	if err := signIfKey(&m, *keyFile, *version); err != nil {
+		return err
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(m)
+}
+
+func runPublish(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("publish", flag.ContinueOnError)
+	server := fs.String("server", "http://localhost:8081", "manifest-service base URL")
+	token := fs.String("token", "", "bearer token; "+tokenEnv+" also works")
+	keyFile := fs.String("key", "", "author Ed25519 private key file (base64, as printed by pluto-keys generate); "+authorKeyEnv+" also works")
+	idFlag := fs.String("id", "", "update this manifest instead of creating a new one")
+	bump := fs.String("bump", "", "major | minor | patch; required to sign an update")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	dir, err := dirArg(fs)
+	if err != nil {
+		return err
+	}
+	if *token == "" {
+		*token = os.Getenv(tokenEnv)
+	}
+
+	m, err := loadManifest(dir, os.Stderr)
+	if err != nil {
+		return err
+	}
+	api := newAPIClient(*server, *token)
+
+	if *idFlag == "" {
+		if *bump != "" {
+			return errors.New("-bump applies only to updates (-id)")
+		}
+		if err := signIfKey(&m, *keyFile, canonical.InitialVersion); err != nil {
+			return err
+		}
+		id, err := api.create(m)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "published %s@%s\n", id, canonical.InitialVersion)
+		return nil
+	}
+
+	id, err := uuid.Parse(*idFlag)
+	if err != nil {
+		return fmt.Errorf("-id: %w", err)
+	}
+	update := gen.ManifestUpdate{
+		Actions:       m.Actions,
+		Author:        &m.Author,
+		Category:      &m.Category,
+		Compatibility: m.Compatibility,
+		Icon:          &m.Icon,
+		Localization:  &m.Localization,
+		Permissions:   &m.Permissions,
+		Script:        &m.Script,
+		Tags:          &m.Tags,
+		Ui:            &m.Ui,
+	}
+	if *bump != "" {
+		b := gen.ManifestUpdateBump(*bump)
+		update.Bump = &b
+	}
+
+	// подпись автора покрывает версию, поэтому её надо знать заранее: сервер выводит
+	// часть версии из изменений, а подписанное обновление должно назвать её явно.
+	// PATCH заменяет переданные локали, а прочие сохранённые оставляет — и они тоже
+	// попадают под подпись, так что каталог должен содержать все локали манифеста
+	if hasAuthorKey(*keyFile) {
+		if update.Bump == nil {
+			return errors.New("-bump is required to sign an update")
+		}
+		current, err := api.version(id)
+		if err != nil {
+			return err
+		}
+		next, err := canonical.BumpVersion(current, *update.Bump)
+		if err != nil {
+			return err
+		}
+		if err := signIfKey(&m, *keyFile, next); err != nil {
+			return err
+		}
+		update.AuthorSignature = m.AuthorSignature
+	}
+
+	version, err := api.update(id, update)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "published %s@%s\n", id, version)
+	return nil
+}
+
+func dirArg(fs *flag.FlagSet) (string, error) {
+	if fs.NArg() != 1 {
+		return "", errors.New("expected exactly one manifest directory")
+	}
+	return fs.Arg(0), nil
+}
+
+// loadManifest собирает и проверяет манифест; нарушения печатаются в report с файлом-источником
+func loadManifest(dir string, report io.Writer) (gen.ManifestCreate, error) {
+	body, err := readDir(dir)
+	if err != nil {
+		return gen.ManifestCreate{}, err
+	}
+	problems, err := validation.Request(body)
+	if err != nil {
+		return gen.ManifestCreate{}, err
+	}
+	if len(problems) > 0 {
+		for _, p := range problems {
+			printProblem(report, p.Pointer, p.Parameter, p.Message)
+		}
+		return gen.ManifestCreate{}, fmt.Errorf("%s: %d problem(s)", dir, len(problems))
+	}
+
+	var m gen.ManifestCreate
+	if err := json.Unmarshal(body, &m); err != nil {
+		return gen.ManifestCreate{}, err
+	}
+	return m, nil
+}
+
+// registryProblems — проверки, которым нужны реестры сервера: компоненты ui и разрешения
+func registryProblems(m gen.ManifestCreate, api *apiClient) (validation.Errors, error) {
+	reg, err := api.components()
+	if err != nil {
+		return nil, err
+	}
+	known, err := api.permissions()
+	if err != nil {
+		return nil, err
+	}
+
+	var problems validation.Errors
+	for _, err := range []error{validation.Components(m.Ui, reg), validation.Permissions(m.Permissions, known)} {
+		if err == nil {
+			continue
+		}
+		var errs validation.Errors
+		if !errors.As(err, &errs) {
+			return nil, err
+		}
+		problems = append(problems, errs...)
+	}
+	return problems, nil
+}
+
+func printProblem(w io.Writer, pointer, parameter, message string) {
+	switch {
+	case parameter != "":
+		fmt.Fprintf(w, "%s: %s\n", parameter, message)
+	case pointer != "":
+		fmt.Fprintf(w, "%s %s: %s\n", sourceOf(pointer), pointer, message)
+	default:
+		fmt.Fprintln(w, message)
+	}
+}
+
+func authorPayload(m gen.ManifestCreate, version string) ([]byte, error) {
+	scriptCode, err := validation.Manifest(m)
+	if err != nil {
+		return nil, err
+	}
+	return canonical.AuthorPayload(version, m, scriptCode)
+}
+
+func hasAuthorKey(keyFile string) bool {
+	return keyFile != "" || os.Getenv(authorKeyEnv) != ""
+}
+
+// signIfKey ставит подпись автора, если задан ключ; kid — отпечаток ключа, как при
+// регистрации в POST /api/authors/keys
+func signIfKey(m *gen.ManifestCreate, keyFile, version string) error {
+	if !hasAuthorKey(keyFile) {
+		return nil
+	}
+	priv, err := loadAuthorKey(keyFile)
+	if err != nil {
+		return err
+	}
+	m.AuthorSignature = nil
+	payload, err := authorPayload(*m, version)
+	if err != nil {
+		return err
+	}
+	m.AuthorSignature = &gen.AuthorSignature{
+		Kid:       jwk.Ed25519Thumbprint(priv.Public().(ed25519.PublicKey)),
+		Signature: base64.StdEncoding.EncodeToString(ed25519.Sign(priv, payload)),
+	}
+	return nil
+}
+
+// loadAuthorKey читает ключ из файла или PLUTO_AUTHOR_KEY: base64 от 64 байт Ed25519
+func loadAuthorKey(keyFile string) (ed25519.PrivateKey, error) {
+	b64 := os.Getenv(authorKeyEnv)
+	source := authorKeyEnv
+	if keyFile != "" {
+		raw, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		b64, source = string(raw), keyFile
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(b64))
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid base64: %w", source, err)
+	}
+	if len(raw) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("%s: want a %d-byte Ed25519 private key", source, ed25519.PrivateKeySize)
+	}
+	return ed25519.PrivateKey(raw), nil
+}
@@ -0,0 +1,124 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Раскладка каталога манифеста. meta.yaml держит поля тела POST /api/manifests под теми же
+// именами (author, category, icon, tags, permissions, actions, compatibility); ui, script и localization
+// собираются из отдельных файлов
+const (
+	metaFile         = "meta.yaml"
+	uiFile           = "ui.json"
+	scriptFile       = "script.js"
+	localizationsDir = "localizations"
+)
+
+// fileFields — поля тела, которые берутся из файлов, а не из meta.yaml
+var fileFields = map[string]string{
+	"ui":              uiFile,
+	"script":          scriptFile,
+	"localization":    localizationsDir + "/*.json",
+	"authorSignature": "-key",
+}
+
+// readDir собирает тело POST /api/manifests из каталога. Отсутствующие ui.json, script.js
+// и localizations не считаются ошибкой здесь: их отсутствие покажет проверка схемы
+func readDir(dir string) ([]byte, error) {
+	rawMeta, err := os.ReadFile(filepath.Join(dir, metaFile))
+	if err != nil {
+		return nil, err
+	}
+	body := map[string]any{}
+	if err := yaml.Unmarshal(rawMeta, &body); err != nil {
+		return nil, fmt.Errorf("%s: %w", metaFile, err)
+	}
+	for field, source := range fileFields {
+		if _, ok := body[field]; ok {
+			return nil, fmt.Errorf("%s: %q is not set here, it comes from %s", metaFile, field, source)
+		}
+	}
+
+	ui, err := os.ReadFile(filepath.Join(dir, uiFile))
+	switch {
+	case err == nil:
+		if !json.Valid(ui) {
+			return nil, fmt.Errorf("%s: invalid JSON", uiFile)
+		}
+		body["ui"] = json.RawMessage(ui)
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, err
+	}
+
+	script, err := os.ReadFile(filepath.Join(dir, scriptFile))
+	switch {
+	case err == nil:
+		body["script"] = map[string]string{"code": string(script)}
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, err
+	}
+
+	loc, err := readLocalizations(filepath.Join(dir, localizationsDir))
+	if err != nil {
+		return nil, err
+	}
+	if loc != nil {
+		body["localization"] = loc
+	}
+
+	return json.Marshal(body)
+}
+
+// readLocalizations: localizations/<locale>.json — объект key → строка
+func readLocalizations(dir string) (map[string]map[string]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+	sort.Strings(files)
+
+	loc := make(map[string]map[string]string, len(files))
+	for _, f := range files {
+		raw, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		var entries map[string]string
+		if err := json.Unmarshal(raw, &entries); err != nil {
+			return nil, fmt.Errorf("%s/%s: want an object of strings: %w", localizationsDir, filepath.Base(f), err)
+		}
+		loc[strings.TrimSuffix(filepath.Base(f), ".json")] = entries
+	}
+	return loc, nil
+}
+
+// sourceOf — файл каталога, из которого пришло поле с указателем pointer
+func sourceOf(pointer string) string {
+	field, rest, _ := strings.Cut(strings.TrimPrefix(pointer, "/"), "/")
+	switch field {
+	case "ui":
+		return uiFile
+	case "script":
+		return scriptFile
+	case "localization":
+		if locale, _, _ := strings.Cut(rest, "/"); locale != "" {
+			return localizationsDir + "/" + locale + ".json"
+		}
+		return localizationsDir
+	case "authorSignature":
+		return "-key"
+	default:
+		return metaFile
+	}
+}
@@ -0,0 +1,153 @@
+package bootstrap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"pluto-backend/internal/manifest/api/gen"
+	"pluto-backend/internal/manifest/validation"
+	"pluto-backend/internal/platform/problem"
+)
+
+const apiTimeout = 30 * time.Second
+
+// apiClient — минимальный клиент manifest-service для публикации
+type apiClient struct {
+	http  *http.Client
+	base  string
+	token string
+}
+
+func newAPIClient(base, token string) *apiClient {
+	return &apiClient{
+		http:  &http.Client{Timeout: apiTimeout},
+		base:  strings.TrimSuffix(base, "/"),
+		token: token,
+	}
+}
+
+// create — POST /api/manifests; возвращает id нового манифеста
+func (c *apiClient) create(m gen.ManifestCreate) (string, error) {
+	var out struct {
+		ID string `json:"id"`
+	}
+	if err := c.do(http.MethodPost, "/api/manifests", m, http.StatusCreated, &out); err != nil {
+		return "", err
+	}
+	return out.ID, nil
+}
+
+// update — PATCH /api/manifests/{id}; возвращает выпущенную версию
+func (c *apiClient) update(id uuid.UUID, u gen.ManifestUpdate) (string, error) {
+	var out gen.Manifest
+	if err := c.do(http.MethodPatch, "/api/manifests/"+id.String(), u, http.StatusOK, &out); err != nil {
+		return "", err
+	}
+	if out.Meta.Version == nil {
+		return "", fmt.Errorf("response has no meta.version")
+	}
+	return *out.Meta.Version, nil
+}
+
+// version — текущая версия манифеста
+func (c *apiClient) version(id uuid.UUID) (string, error) {
+	var out gen.Manifest
+	if err := c.do(http.MethodGet, "/api/manifests/"+id.String(), nil, http.StatusOK, &out); err != nil {
+		return "", err
+	}
+	if out.Meta.Version == nil {
+		return "", fmt.Errorf("manifest %s has no meta.version", id)
+	}
+	return *out.Meta.Version, nil
+}
+
+// components — реестр компонентов UI из GET /api/components
+func (c *apiClient) components() (validation.Registry, error) {
+	var out gen.UiComponentList
+	if err := c.do(http.MethodGet, "/api/components", nil, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	reg := make(validation.Registry, len(out.Components))
+	for _, comp := range out.Components {
+		raw, err := json.Marshal(comp.PropsSchema)
+		if err != nil {
+			return nil, err
+		}
+		schema, err := validation.ParsePropsSchema(raw)
+		if err != nil {
+			return nil, fmt.Errorf("component %q: props schema: %w", comp.Type, err)
+		}
+		reg[comp.Type] = schema
+	}
+	return reg, nil
+}
+
+// permissions — имена из каталога разрешений GET /api/permissions
+func (c *apiClient) permissions() (map[string]bool, error) {
+	var out gen.PermissionList
+	if err := c.do(http.MethodGet, "/api/permissions", nil, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(out.Permissions))
+	for _, p := range out.Permissions {
+		known[p.Name] = true
+	}
+	return known, nil
+}
+
+func (c *apiClient) do(method, path string, in any, want int, out any) error {
+	var body io.Reader
+	if in != nil {
+		raw, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(raw)
+	}
+	req, err := http.NewRequest(method, c.base+path, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != want {
+		return responseError(method, path, resp)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// responseError печатает ошибки полей из problem+json и сводит ответ к одной ошибке
+func responseError(method, path string, resp *http.Response) error {
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	var p problem.Problem
+	if json.Unmarshal(raw, &p) != nil || p.Status == 0 {
+		return fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, strings.TrimSpace(string(raw)))
+	}
+	for _, fe := range p.Errors {
+		printProblem(os.Stderr, fe.Pointer, fe.Parameter, fe.Message)
+	}
+	msg := p.Detail
+	if msg == "" {
+		msg = p.Title
+	}
+	return fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, msg)
+}
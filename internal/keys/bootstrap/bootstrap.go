@@ -0,0 +1,426 @@
+package bootstrap
+
+import (
+	"crypto"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"pluto-backend/internal/platform/jwk"
+	"pluto-backend/internal/platform/keystore"
+)
+
+const usage = `usage: pluto-keys <command> [flags]
+
+commands:
+  generate  создать пару ed25519 | rsa | ec в формате конфигов или добавить её в keystore
+  inspect   алгоритм, kid и отпечаток ключей; проверка, что private и public — одна пара
+  check     проверить пары private/public_key_b64 и keystore в конфиге сервиса
+  jwks      JWKS из публичных ключей конфига, keystore или -public
+
+"pluto-keys <command> -h" — флаги команды`
+
+// RunKeys — утилита для ключей подписи: генерация в точных форматах, которые читают
+// NewRSASigner (auth) и NewEd25519Signer (manifest), kid и отпечатки, экспорт JWKS и
+// проверка, что настроенные private и public ключи действительно образуют пару
+func RunKeys(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		return errors.New("no command")
+	}
+
+	var err error
+	switch args[0] {
+	case "generate":
+		err = runGenerate(args[1:], os.Stdout)
+	case "inspect":
+		err = runInspect(args[1:], os.Stdout)
+	case "check":
+		err = runCheck(args[1:], os.Stdout)
+	case "jwks":
+		err = runJWKS(args[1:], os.Stdout)
+	case "-h", "-help", "--help", "help":
+		fmt.Fprintln(os.Stdout, usage)
+		return nil
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		err = fmt.Errorf("unknown command %q", args[0])
+	}
+	if err != nil && !errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintln(os.Stderr, "pluto-keys:", err)
+	}
+	return err
+}
+
+func runGenerate(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("generate", flag.ContinueOnError)
+	alg := fs.String("alg", "ed25519", "ed25519 (manifest), rsa (auth) or ec (P-256)")
+	bits := fs.Int("bits", 2048, "RSA key size")
+	ksPath := fs.String("keystore", "", "add the key to this keystore instead of printing it; created if missing")
+	id := fs.String("id", "", "key id in the keystore")
+	passFile := fs.String("passphrase-file", "", "keystore passphrase file; "+keystore.PassphraseEnv+" takes precedence")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	priv, err := generateKey(*alg, *bits)
+	if err != nil {
+		return err
+	}
+	info, err := describeKey(priv.Public())
+	if err != nil {
+		return err
+	}
+
+	if *ksPath != "" {
+		if *id == "" {
+			return errors.New("-id is required with -keystore")
+		}
+		if err := addToKeystore(*ksPath, *passFile, *id, priv); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "added %s to %s\n", *id, *ksPath)
+		printKeyInfo(out, "", info)
+		return nil
+	}
+
+	privB64, pubB64, err := encodeKeyPair(priv)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "# algorithm: %s\n# kid: %s\n# fingerprint: %s\n", info.Algorithm, info.Kid, info.Fingerprint)
+	fmt.Fprintf(out, "private_key_b64: '%s'\npublic_key_b64: '%s'\n", privB64, pubB64)
+	return nil
+}
+
+func addToKeystore(path, passFile, id string, priv crypto.Signer) error {
+	key, err := toKeystoreKey(id, priv)
+	if err != nil {
+		return err
+	}
+	key.CreatedAt = time.Now().UTC()
+
+	passphrase, err := keystore.LoadPassphrase(passFile)
+	if err != nil {
+		return err
+	}
+	ks, err := keystore.Open(path, passphrase)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		ks = &keystore.Keystore{}
+	case err != nil:
+		return err
+	}
+	if _, err := ks.Get(id); err == nil {
+		return fmt.Errorf("keystore already has a key %q", id)
+	}
+	ks.Keys = append(ks.Keys, key)
+	return keystore.Save(path, passphrase, ks)
+}
+
+func runInspect(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("inspect", flag.ContinueOnError)
+	privB64 := fs.String("private", "", "private_key_b64 value")
+	pubB64 := fs.String("public", "", "public_key_b64 value")
+	ksPath := fs.String("keystore", "", "list keys of this keystore")
+	passFile := fs.String("passphrase-file", "", "keystore passphrase file; "+keystore.PassphraseEnv+" takes precedence")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *privB64 == "" && *pubB64 == "" && *ksPath == "" {
+		return errors.New("nothing to inspect: pass -private, -public or -keystore")
+	}
+
+	if *privB64 != "" || *pubB64 != "" {
+		if err := checkPair("key", *privB64, *pubB64, out); err != nil {
+			return err
+		}
+	}
+	if *ksPath != "" {
+		ks, err := keystore.Load(*ksPath, *passFile)
+		if err != nil {
+			return err
+		}
+		for _, k := range ks.Keys {
+			priv, err := fromKeystoreKey(k)
+			if err != nil {
+				return err
+			}
+			info, err := describeKey(priv.Public())
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "%s:\n", k.ID)
+			printKeyInfo(out, "  ", info)
+			printWindow(out, "  ", k)
+		}
+	}
+	return nil
+}
+
+// checkPair печатает сведения о ключе и сверяет пару; пустая сторона пропускается
+func checkPair(name, privB64, pubB64 string, out io.Writer) error {
+	var pub, derived crypto.PublicKey
+	if pubB64 != "" {
+		p, err := parsePublicKeyB64(pubB64)
+		if err != nil {
+			return fmt.Errorf("%s: public key: %w", name, err)
+		}
+		pub = p
+	}
+	if privB64 != "" {
+		priv, err := parsePrivateKeyB64(privB64)
+		if err != nil {
+			return fmt.Errorf("%s: private key: %w", name, err)
+		}
+		derived = priv.Public()
+	}
+
+	shown := pub
+	if shown == nil {
+		shown = derived
+	}
+	info, err := describeKey(shown)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	fmt.Fprintf(out, "%s:\n", name)
+	printKeyInfo(out, "  ", info)
+
+	if pub != nil && derived != nil {
+		if !publicKeysEqual(pub, derived) {
+			fmt.Fprintln(out, "  pair:        MISMATCH")
+			return fmt.Errorf("%s: private key does not match public key", name)
+		}
+		fmt.Fprintln(out, "  pair:        ok")
+	}
+	return nil
+}
+
+// signingConfig — общее подмножество секции signing в configs/auth.yaml и configs/manifest.yaml
+type signingConfig struct {
+	PrivateKeyB64 string `mapstructure:"private_key_b64"`
+	PublicKeyB64  string `mapstructure:"public_key_b64"`
+	Keys          []struct {
+		PrivateKeyB64 string `mapstructure:"private_key_b64"`
+		PublicKeyB64  string `mapstructure:"public_key_b64"`
+	} `mapstructure:"keys"`
+	Keystore struct {
+		Path           string `mapstructure:"path"`
+		PassphraseFile string `mapstructure:"passphrase_file"`
+		Key            string `mapstructure:"key"`
+	} `mapstructure:"keystore"`
+}
+
+// loadSigningConfig читает секцию signing так же, как сервисы: с переопределением из PLUTO_*
+func loadSigningConfig(path string) (signingConfig, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.AutomaticEnv()
+	v.SetEnvPrefix("PLUTO")
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	if err := v.ReadInConfig(); err != nil {
+		return signingConfig{}, err
+	}
+	var cfg signingConfig
+	if err := v.UnmarshalKey("signing", &cfg); err != nil {
+		return signingConfig{}, err
+	}
+	return cfg, nil
+}
+
+func runCheck(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	cfgPath := fs.String("config", "", "service config, e.g. configs/auth.yaml or configs/manifest.yaml")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *cfgPath == "" {
+		return errors.New("-config is required")
+	}
+	cfg, err := loadSigningConfig(*cfgPath)
+	if err != nil {
+		return err
+	}
+
+	var problems []error
+	checked := 0
+	check := func(name, privB64, pubB64 string) {
+		checked++
+		if privB64 != "" && pubB64 == "" {
+			problems = append(problems, fmt.Errorf("%s: private_key_b64 without public_key_b64", name))
+			return
+		}
+		if err := checkPair(name, privB64, pubB64, out); err != nil {
+			problems = append(problems, err)
+		}
+	}
+
+	if cfg.PrivateKeyB64 != "" || cfg.PublicKeyB64 != "" {
+		check("signing", cfg.PrivateKeyB64, cfg.PublicKeyB64)
+	}
+	for i, k := range cfg.Keys {
+		check(fmt.Sprintf("signing.keys[%d]", i), k.PrivateKeyB64, k.PublicKeyB64)
+	}
+	if cfg.Keystore.Path != "" {
+		checked++
+		if err := checkKeystore(cfg.Keystore.Path, cfg.Keystore.PassphraseFile, cfg.Keystore.Key, out); err != nil {
+			problems = append(problems, fmt.Errorf("signing.keystore: %w", err))
+		}
+	}
+
+	if checked == 0 {
+		return fmt.Errorf("%s: no signing keys configured", *cfgPath)
+	}
+	for _, p := range problems {
+		fmt.Fprintln(out, "error:", p)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%d problem(s) in %s", len(problems), *cfgPath)
+	}
+	fmt.Fprintf(out, "%s: ok\n", *cfgPath)
+	return nil
+}
+
+func checkKeystore(path, passFile, key string, out io.Writer) error {
+	ks, err := keystore.Load(path, passFile)
+	if err != nil {
+		return err
+	}
+	if key != "" {
+		if _, err := ks.Get(key); err != nil {
+			return err
+		}
+	}
+	for _, k := range ks.Keys {
+		priv, err := fromKeystoreKey(k)
+		if err != nil {
+			return err
+		}
+		info, err := describeKey(priv.Public())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "signing.keystore %s:\n", k.ID)
+		printKeyInfo(out, "  ", info)
+	}
+	return nil
+}
+
+func runJWKS(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("jwks", flag.ContinueOnError)
+	cfgPath := fs.String("config", "", "take public keys from this service config")
+	ksPath := fs.String("keystore", "", "take public keys from this keystore")
+	passFile := fs.String("passphrase-file", "", "keystore passphrase file; "+keystore.PassphraseEnv+" takes precedence")
+	var pubs []string
+	fs.Func("public", "public_key_b64 value; may be repeated", func(s string) error {
+		pubs = append(pubs, s)
+		return nil
+	})
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var keys []crypto.PublicKey
+	addB64 := func(name, b64 string) error {
+		pub, err := parsePublicKeyB64(b64)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		keys = append(keys, pub)
+		return nil
+	}
+	addKeystore := func(path, passFile string) error {
+		ks, err := keystore.Load(path, passFile)
+		if err != nil {
+			return err
+		}
+		for _, k := range ks.Keys {
+			priv, err := fromKeystoreKey(k)
+			if err != nil {
+				return err
+			}
+			keys = append(keys, priv.Public())
+		}
+		return nil
+	}
+
+	if *cfgPath != "" {
+		cfg, err := loadSigningConfig(*cfgPath)
+		if err != nil {
+			return err
+		}
+		// как и сервис, одиночная пара читается, только если keys пуст
+		if cfg.PublicKeyB64 != "" && len(cfg.Keys) == 0 {
+			if err := addB64("signing.public_key_b64", cfg.PublicKeyB64); err != nil {
+				return err
+			}
+		}
+		for i, k := range cfg.Keys {
+			if err := addB64(fmt.Sprintf("signing.keys[%d]", i), k.PublicKeyB64); err != nil {
+				return err
+			}
+		}
+		if cfg.Keystore.Path != "" {
+			if err := addKeystore(cfg.Keystore.Path, cfg.Keystore.PassphraseFile); err != nil {
+				return fmt.Errorf("signing.keystore: %w", err)
+			}
+		}
+	}
+	if *ksPath != "" {
+		if err := addKeystore(*ksPath, *passFile); err != nil {
+			return err
+		}
+	}
+	for i, b64 := range pubs {
+		if err := addB64(fmt.Sprintf("-public #%d", i+1), b64); err != nil {
+			return err
+		}
+	}
+	if len(keys) == 0 {
+		return errors.New("no keys: pass -config, -keystore or -public")
+	}
+
+	set := jwk.Set{Keys: make([]jwk.Key, 0, len(keys))}
+	seen := make(map[string]bool, len(keys))
+	for _, pub := range keys {
+		k, err := jwk.FromPublicKey(pub)
+		if err != nil {
+			return err
+		}
+		if seen[k.Kid] {
+			continue
+		}
+		seen[k.Kid] = true
+		set.Keys = append(set.Keys, k)
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(set)
+}
+
+func printKeyInfo(out io.Writer, indent string, info keyInfo) {
+	fmt.Fprintf(out, "%salgorithm:   %s\n", indent, info.Algorithm)
+	fmt.Fprintf(out, "%skid:         %s\n", indent, info.Kid)
+	fmt.Fprintf(out, "%sfingerprint: %s\n", indent, info.Fingerprint)
+}
+
+func printWindow(out io.Writer, indent string, k keystore.Key) {
+	if !k.CreatedAt.IsZero() {
+		fmt.Fprintf(out, "%screated:     %s\n", indent, k.CreatedAt.Format(time.RFC3339))
+	}
+	if !k.NotBefore.IsZero() {
+		fmt.Fprintf(out, "%snot before:  %s\n", indent, k.NotBefore.Format(time.RFC3339))
+	}
+	if !k.NotAfter.IsZero() {
+		fmt.Fprintf(out, "%snot after:   %s\n", indent, k.NotAfter.Format(time.RFC3339))
+	}
+}
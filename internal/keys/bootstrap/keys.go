@@ -0,0 +1,191 @@
+package bootstrap
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"pluto-backend/internal/platform/jwk"
+	"pluto-backend/internal/platform/keystore"
+)
+
+// Форматы ключей в конфигах: manifest-service держит Ed25519 как сырые байты в base64
+// (64 байта приватного, 32 публичного), auth-service — RSA как base64 от PEM
+// (PKCS #8 или PKCS #1 для приватного, PKIX для публичного). EC-ключи пишутся так же, как RSA
+
+func generateKey(alg string, bits int) (crypto.Signer, error) {
+	switch strings.ToLower(alg) {
+	case "ed25519":
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		return priv, err
+	case "rsa":
+		if bits < 2048 {
+			return nil, fmt.Errorf("rsa key size %d is too small, need at least 2048", bits)
+		}
+		return rsa.GenerateKey(rand.Reader, bits)
+	case "ec":
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		return nil, fmt.Errorf("unknown algorithm %q: want ed25519, rsa or ec", alg)
+	}
+}
+
+// encodeKeyPair — private_key_b64 и public_key_b64 в формате конфигов
+func encodeKeyPair(priv crypto.Signer) (privB64, pubB64 string, err error) {
+	if priv, ok := priv.(ed25519.PrivateKey); ok {
+		return base64.StdEncoding.EncodeToString(priv),
+			base64.StdEncoding.EncodeToString(priv.Public().(ed25519.PublicKey)), nil
+	}
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return "", "", err
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(priv.Public())
+	if err != nil {
+		return "", "", err
+	}
+	privPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER})
+	pubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})
+	return base64.StdEncoding.EncodeToString(privPEM), base64.StdEncoding.EncodeToString(pubPEM), nil
+}
+
+// parsePrivateKeyB64 разбирает private_key_b64 любого из форматов конфигов
+func parsePrivateKeyB64(s string) (crypto.Signer, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64: %w", err)
+	}
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		if len(raw) != ed25519.PrivateKeySize {
+			return nil, fmt.Errorf("neither PEM nor a %d-byte Ed25519 key", ed25519.PrivateKeySize)
+		}
+		priv := ed25519.PrivateKey(raw)
+		// вторая половина ключа Ed25519 — публичная часть; сверяем её с зерном
+		if !ed25519.NewKeyFromSeed(priv.Seed()).Equal(priv) {
+			return nil, errors.New("corrupted Ed25519 key: public half does not match the seed")
+		}
+		return priv, nil
+	}
+
+	var parsed any
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		parsed, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unexpected PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", parsed)
+	}
+	return signer, nil
+}
+
+// parsePublicKeyB64 разбирает public_key_b64 любого из форматов конфигов
+func parsePublicKeyB64(s string) (crypto.PublicKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64: %w", err)
+	}
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		if len(raw) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("neither PEM nor a %d-byte Ed25519 key", ed25519.PublicKeySize)
+		}
+		return ed25519.PublicKey(raw), nil
+	}
+	switch block.Type {
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unexpected PEM block %q", block.Type)
+	}
+}
+
+// publicKeysEqual — совпадают ли публичные ключи; нужна проверка пары private/public
+func publicKeysEqual(a, b crypto.PublicKey) bool {
+	eq, ok := a.(interface{ Equal(crypto.PublicKey) bool })
+	return ok && eq.Equal(b)
+}
+
+// keyInfo — то, что печатает inspect: алгоритм, kid (RFC 7638) и отпечаток SPKI
+type keyInfo struct {
+	Algorithm   string
+	Kid         string
+	Fingerprint string
+}
+
+func describeKey(pub crypto.PublicKey) (keyInfo, error) {
+	j, err := jwk.FromPublicKey(pub)
+	if err != nil {
+		return keyInfo{}, err
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return keyInfo{}, err
+	}
+	sum := sha256.Sum256(der)
+
+	alg := j.Alg
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		alg = fmt.Sprintf("%s (RSA-%d)", alg, pub.N.BitLen())
+	case *ecdsa.PublicKey:
+		alg = fmt.Sprintf("%s (%s)", alg, j.Crv)
+	case ed25519.PublicKey:
+		alg = fmt.Sprintf("%s (%s)", alg, j.Crv)
+	}
+	return keyInfo{
+		Algorithm:   alg,
+		Kid:         j.Kid,
+		Fingerprint: "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:]),
+	}, nil
+}
+
+// toKeystoreKey — ключ в виде, в котором его хранит keystore; EC там не поддерживается
+func toKeystoreKey(id string, priv crypto.Signer) (keystore.Key, error) {
+	switch priv := priv.(type) {
+	case ed25519.PrivateKey:
+		return keystore.Key{ID: id, Algorithm: keystore.AlgEd25519, Private: priv}, nil
+	case *rsa.PrivateKey:
+		der, err := x509.MarshalPKCS8PrivateKey(priv)
+		if err != nil {
+			return keystore.Key{}, err
+		}
+		return keystore.Key{ID: id, Algorithm: keystore.AlgRS256, Private: der}, nil
+	default:
+		return keystore.Key{}, fmt.Errorf("keystore holds only Ed25519 and RSA keys, got %T", priv)
+	}
+}
+
+// fromKeystoreKey — приватный ключ из записи keystore
+func fromKeystoreKey(k keystore.Key) (crypto.Signer, error) {
+	switch k.Algorithm {
+	case keystore.AlgEd25519:
+		return k.Ed25519()
+	case keystore.AlgRS256:
+		return k.RSA()
+	default:
+		return nil, fmt.Errorf("key %s: unsupported algorithm %q", k.ID, k.Algorithm)
+	}
+}
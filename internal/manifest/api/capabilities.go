@@ -0,0 +1,100 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"pluto-backend/internal/manifest/service"
+)
+
+// capabilitiesHeader — возможности клиента: "app_version=1.4.0; os=ios; components=text,button;
+// permissions=clipboard.write". Поля, которых нет в заголовке, берутся из claim fp токена
+const capabilitiesHeader = "X-Client-Capabilities"
+
+// clientCapabilities собирает возможности клиента из claim fp токена pluto-auth и заголовка
+// X-Client-Capabilities; заголовок важнее. Подпись токена здесь не проверяется: возможности
+// клиент заявляет о себе сам, как и в заголовке, и они лишь скрывают то, что он не сможет
+// показать, а не защищают доступ
+func clientCapabilities(r *http.Request) service.ClientCapabilities {
+	c := fingerprintCapabilities(r.Header.Get("Authorization"))
+
+	for _, part := range strings.Split(r.Header.Get(capabilitiesHeader), ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "app_version":
+			c.AppVersion = value
+		case "os":
+			c.OS = normalizeOS(value)
+		case "components":
+			c.Components = splitList(value)
+		case "permissions":
+			c.Permissions = splitList(value)
+		}
+	}
+	return c
+}
+
+// varyCapabilities — ответ зависит от возможностей клиента, что важно для кэшей
+func varyCapabilities(w http.ResponseWriter) {
+	w.Header().Add("Vary", capabilitiesHeader)
+	w.Header().Add("Vary", "Authorization")
+}
+
+// fingerprintCapabilities читает claim fp — JSON запроса /auth/app-login: app_version, os
+// ("iOS 17.4" → ios) и additional.components / additional.permissions через запятую
+func fingerprintCapabilities(authorization string) service.ClientCapabilities {
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok {
+		return service.ClientCapabilities{}
+	}
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(strings.TrimSpace(token), claims); err != nil {
+		return service.ClientCapabilities{}
+	}
+	raw, _ := claims["fp"].(string)
+
+	var fp struct {
+		AppVersion string            `json:"app_version"`
+		OS         string            `json:"os"`
+		Additional map[string]string `json:"additional"`
+	}
+	if json.Unmarshal([]byte(raw), &fp) != nil {
+		return service.ClientCapabilities{}
+	}
+
+	c := service.ClientCapabilities{
+		AppVersion: strings.TrimSpace(fp.AppVersion),
+		OS:         normalizeOS(fp.OS),
+	}
+	if v, ok := fp.Additional["components"]; ok {
+		c.Components = splitList(v)
+	}
+	if v, ok := fp.Additional["permissions"]; ok {
+		c.Permissions = splitList(v)
+	}
+	return c
+}
+
+// normalizeOS оставляет имя ОС без версии и в нижнем регистре, как в compatibility.os
+func normalizeOS(os string) string {
+	name, _, _ := strings.Cut(strings.TrimSpace(os), " ")
+	return strings.ToLower(name)
+}
+
+// splitList разбирает список через запятую; пустой список — клиент не поддерживает ничего
+func splitList(s string) []string {
+	out := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"pluto-backend/internal/manifest/service"
+	"pluto-backend/internal/platform/problem"
+)
+
+// fail переводит доменную ошибку сервиса в HTTP-статус и problem+json;
+// всё нераспознанное логируется и отдаётся как 500
+func (h *Handlers) fail(w http.ResponseWriter, r *http.Request, op string, err error) {
+	var (
+		ve  *service.ValidationError
+		ves service.ValidationErrors
+	)
+	switch {
+	case errors.As(err, &ves):
+		fields := make([]problem.FieldError, len(ves))
+		for i, e := range ves {
+			fields[i] = problem.FieldError{Pointer: e.Pointer, Parameter: e.Parameter, Message: e.Message}
+		}
+		Error(w, r, http.StatusBadRequest, problem.CodeValidation, "request validation failed", fields...)
+	case errors.As(err, &ve):
+		Error(w, r, http.StatusBadRequest, problem.CodeValidation, "request validation failed",
+			problem.FieldError{Pointer: ve.Pointer, Parameter: ve.Parameter, Message: ve.Message})
+	case errors.Is(err, service.ErrManifestNotFound), errors.Is(err, service.ErrVersionNotFound),
+		errors.Is(err, service.ErrLogEntryNotFound):
+		Error(w, r, http.StatusNotFound, problem.CodeNotFound, err.Error())
+	case errors.Is(err, service.ErrAuthorKeyApproval):
+		Error(w, r, http.StatusForbidden, problem.CodeForbidden, err.Error())
+	case errors.Is(err, service.ErrVersionConflict), errors.Is(err, service.ErrAuthorKeyExists),
+		errors.Is(err, service.ErrAlreadyRevoked):
+		Error(w, r, http.StatusConflict, problem.CodeConflict, err.Error())
+	default:
+		h.Logger.Error().Err(err).Msg(op + " failed")
+		Error(w, r, http.StatusInternalServerError, problem.CodeInternal, "internal error")
+	}
+}
@@ -13,6 +13,36 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// list author public keys (JWKS)
+	// (GET /api/authors/keys)
+	ListAuthorKeys(w http.ResponseWriter, r *http.Request, params ListAuthorKeysParams)
+	// register author public key
+	// (POST /api/authors/keys)
+	RegisterAuthorKey(w http.ResponseWriter, r *http.Request)
+	// signed catalog snapshot
+	// (GET /api/catalog/snapshot)
+	GetCatalogSnapshot(w http.ResponseWriter, r *http.Request)
+	// signed catalog timestamp
+	// (GET /api/catalog/timestamp)
+	GetCatalogTimestamp(w http.ResponseWriter, r *http.Request)
+	// UI component registry
+	// (GET /api/components)
+	ListComponents(w http.ResponseWriter, r *http.Request)
+	// list currently trusted signing keys (JWKS)
+	// (GET /api/keys)
+	ListSigningKeys(w http.ResponseWriter, r *http.Request)
+	// transparency log entries
+	// (GET /api/log/entries)
+	ListLogEntries(w http.ResponseWriter, r *http.Request, params ListLogEntriesParams)
+	// consistency proof between two tree heads
+	// (GET /api/log/proof/consistency)
+	GetConsistencyProof(w http.ResponseWriter, r *http.Request, params GetConsistencyProofParams)
+	// inclusion proof for a log entry
+	// (GET /api/log/proof/inclusion)
+	GetInclusionProof(w http.ResponseWriter, r *http.Request, params GetInclusionProofParams)
+	// signed tree head of the transparency log
+	// (GET /api/log/tree-head)
+	GetLogTreeHead(w http.ResponseWriter, r *http.Request)
 	// Список манифестов (только meta)
 	// (GET /api/manifests)
 	ListManifests(w http.ResponseWriter, r *http.Request, params ListManifestsParams)
@@ -22,15 +52,30 @@ type ServerInterface interface {
 	// Поиск манифестов (только meta)
 	// (GET /api/manifests/search)
 	SearchManifests(w http.ResponseWriter, r *http.Request, params SearchManifestsParams)
+	// Проверить подпись манифеста
+	// (POST /api/manifests/verify)
+	VerifyManifest(w http.ResponseWriter, r *http.Request)
 	// Получить полный манифест по ID
 	// (GET /api/manifests/{id})
-	GetManifestById(w http.ResponseWriter, r *http.Request, id Id)
+	GetManifestById(w http.ResponseWriter, r *http.Request, id Id, params GetManifestByIdParams)
 	// Частичное обновление манифеста
 	// (PATCH /api/manifests/{id})
 	UpdateManifest(w http.ResponseWriter, r *http.Request, id Id)
+	// История подписанных версий манифеста
+	// (GET /api/manifests/{id}/versions)
+	ListManifestVersions(w http.ResponseWriter, r *http.Request, id Id)
+	// Получить конкретную версию манифеста
+	// (GET /api/manifests/{id}/versions/{version})
+	GetManifestVersion(w http.ResponseWriter, r *http.Request, id Id, version Version, params GetManifestVersionParams)
+	// permission catalog
+	// (GET /api/permissions)
+	ListPermissions(w http.ResponseWriter, r *http.Request)
 	// get public key (base64)
 	// (GET /api/public-key)
 	GetPublicKey(w http.ResponseWriter, r *http.Request)
+	// signed revocation list
+	// (GET /api/revocations)
+	GetRevocationList(w http.ResponseWriter, r *http.Request)
 	// health check
 	// (GET /health)
 	HealthCheck(w http.ResponseWriter, r *http.Request)
@@ -40,6 +85,66 @@ type ServerInterface interface {
 
 type Unimplemented struct{}
 
+// list author public keys (JWKS)
+// (GET /api/authors/keys)
+func (_ Unimplemented) ListAuthorKeys(w http.ResponseWriter, r *http.Request, params ListAuthorKeysParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// register author public key
+// (POST /api/authors/keys)
+func (_ Unimplemented) RegisterAuthorKey(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// signed catalog snapshot
+// (GET /api/catalog/snapshot)
+func (_ Unimplemented) GetCatalogSnapshot(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// signed catalog timestamp
+// (GET /api/catalog/timestamp)
+func (_ Unimplemented) GetCatalogTimestamp(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// UI component registry
+// (GET /api/components)
+func (_ Unimplemented) ListComponents(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// list currently trusted signing keys (JWKS)
+// (GET /api/keys)
+func (_ Unimplemented) ListSigningKeys(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// transparency log entries
+// (GET /api/log/entries)
+func (_ Unimplemented) ListLogEntries(w http.ResponseWriter, r *http.Request, params ListLogEntriesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// consistency proof between two tree heads
+// (GET /api/log/proof/consistency)
+func (_ Unimplemented) GetConsistencyProof(w http.ResponseWriter, r *http.Request, params GetConsistencyProofParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// inclusion proof for a log entry
+// (GET /api/log/proof/inclusion)
+func (_ Unimplemented) GetInclusionProof(w http.ResponseWriter, r *http.Request, params GetInclusionProofParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// signed tree head of the transparency log
+// (GET /api/log/tree-head)
+func (_ Unimplemented) GetLogTreeHead(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Список манифестов (только meta)
 // (GET /api/manifests)
 func (_ Unimplemented) ListManifests(w http.ResponseWriter, r *http.Request, params ListManifestsParams) {
@@ -58,9 +163,15 @@ func (_ Unimplemented) SearchManifests(w http.ResponseWriter, r *http.Request, p
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Проверить подпись манифеста
+// (POST /api/manifests/verify)
+func (_ Unimplemented) VerifyManifest(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Получить полный манифест по ID
 // (GET /api/manifests/{id})
-func (_ Unimplemented) GetManifestById(w http.ResponseWriter, r *http.Request, id Id) {
+func (_ Unimplemented) GetManifestById(w http.ResponseWriter, r *http.Request, id Id, params GetManifestByIdParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -70,12 +181,36 @@ func (_ Unimplemented) UpdateManifest(w http.ResponseWriter, r *http.Request, id
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// История подписанных версий манифеста
+// (GET /api/manifests/{id}/versions)
+func (_ Unimplemented) ListManifestVersions(w http.ResponseWriter, r *http.Request, id Id) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить конкретную версию манифеста
+// (GET /api/manifests/{id}/versions/{version})
+func (_ Unimplemented) GetManifestVersion(w http.ResponseWriter, r *http.Request, id Id, version Version, params GetManifestVersionParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// permission catalog
+// (GET /api/permissions)
+func (_ Unimplemented) ListPermissions(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // get public key (base64)
 // (GET /api/public-key)
 func (_ Unimplemented) GetPublicKey(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// signed revocation list
+// (GET /api/revocations)
+func (_ Unimplemented) GetRevocationList(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // health check
 // (GET /health)
 func (_ Unimplemented) HealthCheck(w http.ResponseWriter, r *http.Request) {
@@ -91,6 +226,267 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// ListAuthorKeys operation middleware
+func (siw *ServerInterfaceWrapper) ListAuthorKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAuthorKeysParams
+
+	// ------------- Required query parameter "email" -------------
+
+	if paramValue := r.URL.Query().Get("email"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "email"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "email", r.URL.Query(), &params.Email)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "email", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAuthorKeys(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RegisterAuthorKey operation middleware
+func (siw *ServerInterfaceWrapper) RegisterAuthorKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RegisterAuthorKey(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetCatalogSnapshot operation middleware
+func (siw *ServerInterfaceWrapper) GetCatalogSnapshot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCatalogSnapshot(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetCatalogTimestamp operation middleware
+func (siw *ServerInterfaceWrapper) GetCatalogTimestamp(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCatalogTimestamp(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListComponents operation middleware
+func (siw *ServerInterfaceWrapper) ListComponents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListComponents(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListSigningKeys operation middleware
+func (siw *ServerInterfaceWrapper) ListSigningKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListSigningKeys(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListLogEntries operation middleware
+func (siw *ServerInterfaceWrapper) ListLogEntries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListLogEntriesParams
+
+	// ------------- Required query parameter "start" -------------
+
+	if paramValue := r.URL.Query().Get("start"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "start"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "start", r.URL.Query(), &params.Start)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "start", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "end" -------------
+
+	if paramValue := r.URL.Query().Get("end"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "end"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "end", r.URL.Query(), &params.End)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "end", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListLogEntries(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetConsistencyProof operation middleware
+func (siw *ServerInterfaceWrapper) GetConsistencyProof(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetConsistencyProofParams
+
+	// ------------- Required query parameter "first" -------------
+
+	if paramValue := r.URL.Query().Get("first"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "first"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "first", r.URL.Query(), &params.First)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "first", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "second" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "second", r.URL.Query(), &params.Second)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "second", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetConsistencyProof(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetInclusionProof operation middleware
+func (siw *ServerInterfaceWrapper) GetInclusionProof(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetInclusionProofParams
+
+	// ------------- Required query parameter "hash" -------------
+
+	if paramValue := r.URL.Query().Get("hash"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "hash"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "hash", r.URL.Query(), &params.Hash)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "hash", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "treeSize" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "treeSize", r.URL.Query(), &params.TreeSize)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "treeSize", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInclusionProof(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetLogTreeHead operation middleware
+func (siw *ServerInterfaceWrapper) GetLogTreeHead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLogTreeHead(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // ListManifests operation middleware
 func (siw *ServerInterfaceWrapper) ListManifests(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -116,6 +512,86 @@ func (siw *ServerInterfaceWrapper) ListManifests(w http.ResponseWriter, r *http.
 		return
 	}
 
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "withTotal" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "withTotal", r.URL.Query(), &params.WithTotal)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "withTotal", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", r.URL.Query(), &params.Category)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tags" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "tags", r.URL.Query(), &params.Tags)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tags", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tagsMatch" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tagsMatch", r.URL.Query(), &params.TagsMatch)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tagsMatch", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "excludePermissions" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "excludePermissions", r.URL.Query(), &params.ExcludePermissions)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "excludePermissions", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "author" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "author", r.URL.Query(), &params.Author)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "author", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "createdAfter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdAfter", r.URL.Query(), &params.CreatedAfter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "createdAfter", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "createdBefore" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdBefore", r.URL.Query(), &params.CreatedBefore)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "createdBefore", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.ListManifests(w, r, params)
 	}))
@@ -198,6 +674,21 @@ func (siw *ServerInterfaceWrapper) SearchManifests(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// VerifyManifest operation middleware
+func (siw *ServerInterfaceWrapper) VerifyManifest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.VerifyManifest(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetManifestById operation middleware
 func (siw *ServerInterfaceWrapper) GetManifestById(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -213,8 +704,19 @@ func (siw *ServerInterfaceWrapper) GetManifestById(w http.ResponseWriter, r *htt
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetManifestByIdParams
+
+	// ------------- Optional query parameter "signatureFormat" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "signatureFormat", r.URL.Query(), &params.SignatureFormat)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "signatureFormat", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetManifestById(w, r, id)
+		siw.Handler.GetManifestById(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -250,6 +752,93 @@ func (siw *ServerInterfaceWrapper) UpdateManifest(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// ListManifestVersions operation middleware
+func (siw *ServerInterfaceWrapper) ListManifestVersions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListManifestVersions(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetManifestVersion operation middleware
+func (siw *ServerInterfaceWrapper) GetManifestVersion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "version" -------------
+	var version Version
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "version", runtime.ParamLocationPath, chi.URLParam(r, "version"), &version)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "version", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetManifestVersionParams
+
+	// ------------- Optional query parameter "signatureFormat" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "signatureFormat", r.URL.Query(), &params.SignatureFormat)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "signatureFormat", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetManifestVersion(w, r, id, version, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListPermissions operation middleware
+func (siw *ServerInterfaceWrapper) ListPermissions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListPermissions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetPublicKey operation middleware
 func (siw *ServerInterfaceWrapper) GetPublicKey(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -265,6 +854,21 @@ func (siw *ServerInterfaceWrapper) GetPublicKey(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// GetRevocationList operation middleware
+func (siw *ServerInterfaceWrapper) GetRevocationList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRevocationList(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // HealthCheck operation middleware
 func (siw *ServerInterfaceWrapper) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -393,6 +997,36 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/authors/keys", wrapper.ListAuthorKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/authors/keys", wrapper.RegisterAuthorKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/catalog/snapshot", wrapper.GetCatalogSnapshot)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/catalog/timestamp", wrapper.GetCatalogTimestamp)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/components", wrapper.ListComponents)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/keys", wrapper.ListSigningKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/log/entries", wrapper.ListLogEntries)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/log/proof/consistency", wrapper.GetConsistencyProof)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/log/proof/inclusion", wrapper.GetInclusionProof)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/log/tree-head", wrapper.GetLogTreeHead)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/manifests", wrapper.ListManifests)
 	})
@@ -402,15 +1036,30 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/manifests/search", wrapper.SearchManifests)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/manifests/verify", wrapper.VerifyManifest)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/manifests/{id}", wrapper.GetManifestById)
 	})
 	r.Group(func(r chi.Router) {
 		r.Patch(options.BaseURL+"/api/manifests/{id}", wrapper.UpdateManifest)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/manifests/{id}/versions", wrapper.ListManifestVersions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/manifests/{id}/versions/{version}", wrapper.GetManifestVersion)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/permissions", wrapper.ListPermissions)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/public-key", wrapper.GetPublicKey)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/revocations", wrapper.GetRevocationList)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/health", wrapper.HealthCheck)
 	})
@@ -18,34 +18,168 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+RZzW7bxhZ+FWLuXeQilCXfBBe4ArpwErR1YjVG7aT5gRdjciRNTHKY4bCNahCInbRZ",
-	"NEDRbVu06KpbNYkRJa6VVxi+UTEzJMVfiYzkoEBXNodnzs93zvnmcHQIDGK7xEEO80D3ELiQQhsxROUT",
-	"NAzksi3oDHw4QGIFO6ALhgiaiAIdONBGoAs2pFgrkdOBZwyRDcUG9AjariWkkNO6tQN0wEauePQYxc4A",
-	"BIEOsJmodiEbzhRjE+iAooc+psgEXUZ9lNbdJ9SGDHSB70vJomYL25glyh/6iI5m2tXLtEIT9aFvMdBd",
-	"73R0YGMH274Nup1ENXYYGiAqdZN+30OVyqO3pdoX6Q5E0J5LHA/JNDiEfUx8R6JkEIchR5qFrmthAzJM",
-	"nPYDjzhibWbNpcRFlGGlAlFKaDYfPejgPvKY5hCm9aX+MgijFbL/ABlMOWciz6DYFYZBF3xGmKbcE++U",
-	"A9Lmhs+GymjOFxtiS/yTMxZjd1jixawI7ispPVKzV/BQTyIrmoaGcFr++2+K+qAL/tWeNUA7cr4dK9iQ",
-	"4kKlRQxo4a+hirne7q30nkAHNmKw7t6ekA104CJqY8+LncYM2V4pdNECpBSOgMyDyFBdcztKWuzDAwcy",
-	"n8o8UATNm441iluvYNXHdS3cwoU8Sjxy0EqVifd6krAsEvOSHuVMJNuybvZB936TXF+BHgLBng4etQak",
-	"hW2XUCa5yyAmdgaqz6K3kQtiae1z+FUPeZ5gv4IvUmehFLGhvCxgqtiwsGzBfVTeNcTZhe7itpEUqbTo",
-	"ynq8tQDnisIvDzzVg0k5N01Qsd5hQjbzdEWUFOjAgAwNCB2Vp6AqNx+0HZNQ4aChtSZtqazka0WVRwJS",
-	"5EQC84KurdurVymCDDXv1QiZfGUtS9KRO3kwMmqL4eylAtrKedAsrPTu1RJRQbPwzTSxeIbWdgbFQnVl",
-	"j3v+E5/yt3zMT/mEv+ZT/oKP+Rk/C7/jJ1p4FB6Hj6XAROMTfhYe85PwcfiUn/A34REfg3OimpI0zgmx",
-	"duiFyk3Gp0OAHDXUpcG5lnrSAcNMTlq78m8QzOmEXjQb5KhyCVJbeHYbEiZzg2VGaRMy1GJYTlgLVcQ8",
-	"uVjQLJvXF24TE8LV5d1cTKAVKhJC/RJRD9eKtezQjXfrNXh1lpV8+HsLyidqAWQ2J55o2lw1ny7DpDvJ",
-	"gdkslPTRuSr2TOkstKhBTFTkges7Lf6WT/krjb/jp3zMXwoyTNNfRb1Idec1j93CzfGMB4S9lfkQ45g7",
-	"Vn7kU34WPuUT/jJ8Ej7m4/BbPgm/FwBO+Wn4PD5rxIkinsMjifBLPq06aPKJSt9zJFSweDbNStQctisG",
-	"65JZrWLcF1bnnFUZ7skrr/UlIEViO3UcteCI+CXf1MYQWyZVp2EFaKrQFrklpep44rnQEApmGpO7k7oQ",
-	"RNHHmvRZFEUH8iSmcNDTFXVuHeuaf9MBuUDd8iLP6ROJfTT4bFs+I1py0bSxvZk6Dbtgfa2z1pFfsC5y",
-	"oItBF1xa66yti7KEbChdbkMXt+1Ig1wZqEs3EZd0atMEXbCFPdZLpPTMPWYFZjORtroJFHgtEIxu9QQb",
-	"Zm7o/tvpNLqca/Thmz3cC/1QvJHjP/NxeBQe8Ql/ofE/+Ul4zMf8VTynh9+IRfEwEXwpZnZBrer6zrdt",
-	"KAZIwH/j7/gkPBLDfKm8dkH+FdT8lk81Maz8RzIX8UoSpEonuZlTLSUqlZijRtDVQSyp0yB/fRwUEre+",
-	"cuulKflVQCW/kt4U0CwiP+WvRb7C4/C5xs8E2tUbsx3S9hCkxrCyUXbk6zmtUnaZHT9WX8UX2HZhK+V+",
-	"W/hHtJQoAtFSTRqqmOFDbAaV+f0EJTx4ZbSpvrKWQPWc6l0HlzuXq/QnDreTHz5KcDwNn4TP+ES1yLs5",
-	"1uRbbfOa5KZGxwI2ZVm6kKmGyiKtzuYPxGjRIFCL0T5Qhn/hfyhq4qfhD9EN0Jv5qVg68b/LLjzmk/CZ",
-	"tH2i8enMDX4ijInFfHeNZ33k+vsWNloHaDSvh7al1A00WraBsgOYm6jN/BQ3ut7bgbd33YPru96dwe17",
-	"F+/1Lh7sbt3dcjdGXwzX73jr/zcfXrq77aLOR+/3I50yrImos5AOENNmL7UL+9BD/7sc8c4QQYtVnyWf",
-	"ytdXh8g4WC1MHoPM97IYkYP3i/zmjVzEKijNkG4HQRD8FQAA//8wt4uiBB8AAA==",
+	"H4sIAAAAAAAC/+x963LbRproq3Th7A+pAlGUb7GlctWxNfHGjp2oLMVxJswZQ0RLQkQCDABa0nhdZUmT",
+	"OHPstSupqdqp2d3JyWydM39pWbRpXehXaLzCPMmp7+tuoAE0SFAXJzs1+RGLJNCXr7/7rR8Yda/Z8lzq",
+	"hoEx/cBYoZZNffyz7rkhdcOblrvctpYpfGXToO47rdDxXGPaYP/O+myPddh+9JSwfrTFdlg32mIdk7Cd",
+	"6Al7ET1iHXbIDlknek7YW9YnV+p12gon5JgzhO2y/eg5iTbZW9aLNtke67Md8rdHf4AhNlk3M1L0BL7a",
+	"l/OyHokes270iHXZa8Jesw57Gz2PtqLt6JlhGkF9hTYtWDhdt5qtBjWmjVY4cfW2YRrhRgs+BqHvuMvG",
+	"w4cPTaNl+VaThmL7Fi5V3b0Dm+YAMkzDtZowQGZHBbNSd+LTec2spmG1wxXPv+Y0QuprQPxHdgCw6+Fe",
+	"adNyGoR12E60xfoAE5OwF7j3aDt6HH0PwCcIjZcAzWgLnjFMvvKv29TfSBbOJ06tN7+6uhXSZc/fiLef",
+	"GST+Xb/tgNbbvhNuaHde96kVUvvKkn7nf2Zvo232AjbOsULFgEPWJRwnoqfRd6xLxtgO22P70bPoMetF",
+	"W6wLSMkOWX+8YPup2dXVL3l+0wqNacO2QjoROk06aPVX6ZLn0xGXL06mz16yfmobg9cq5jrKYtt+4OmA",
+	"/J+sy97iUl7DOqLHuMI3hO1F29GjaBOwDLDvNbk78TFdDydmcSSCL3XZbvSE7Ubb0e9Zl72R24J99qJv",
+	"oydFu+GLGYx4dL3eaNt0jvpNJwgczw10xBFtqocOXOhATP871sXlPDElW4Dtfcd6cBDRs+j3rIecJHrG",
+	"XrA+6/JNsrecmeB4m2yfdfmZRd/wY3qNP36HX/fYGwPW2Wp4NjWml6xGQPUb1uxFSy5fGPWG01r0LN+u",
+	"rPlOSI0vTcMJaTPQwCg+Zsv3rQ34HIQbMAriBHx27JhqW1a4kqzHsQ3T8OnXbcentjEd+m2qxal2G5/M",
+	"H07DaTphEUvgP6oD2nTJajdCY3qqWjWNpuM6zXbTmK7GQztuSJepj2N7S0sBLRxc/KodffjYgbPsWmHb",
+	"p9fEFrMY5VtrKHuAvSID2WN9smgF9MK5CZBfbJeLKcC0HRIPN0O+WgvwRbbL+vjgPjtM8yFi09Cqr1Cb",
+	"3PhsvuaO3b42S94/P3XeRGaGOLqHE/SiRyl28Ya0rI2GZ9lcguJ77196fzy1ghtrQaXmsh/YAcd71oen",
+	"O4i08BXSJVBHn73C1UhMZ7usg5TD5dg0sVqthlO3ACCTXwWeO5NMcvmrtaDmFlB1FrbaIwIAG6ZBXTij",
+	"L8Snr9YC48v4vBQ0Czxfc0YuXaNBOOk1bBqEHOgAF74RIGSV73aib1mP9UwSOmGDKk8DvF/iOQGs96Jt",
+	"AGfMKfqCxg9ZH5ihom8U7d7zi7bM16vsOv6Cb8EwDVycHgahtazjfH/hIr5Y+5khuOI9wnrAvICRP+bK",
+	"GZy2YIoAsu+jLQKT3LLC+kpJhoaLKmBhfmAZIB03WqF3PP6VrCq3f8vd4HT6DaidoCC9iJ4QTj7skPNy",
+	"JD444h2TWI2GqlOKn4oOUwWH7kQtd0M5Tv7JajT0B3if+gEuWsuK5a+D+HEMXGOqcqZS1XLkNSdcWfBC",
+	"q6HBlR8QRQ6jbS4h++wFyusuiaVcPyc3UQuXFKHQCeuSuxM40cSs13bDAggmy9FCUKCW2Mai5zWo5XId",
+	"3KdBy3MDiiizaNm36ddtIJLEHoE/VSbV8r3FBm2+B8wKfkvm+yefLhnTxv+YTEycSf5rMDnH3+KTpgF2",
+	"1bKJnBZ0J89dajj1d7qEWTnnQxPIYdGxbeq+ywVciyd9aBquF17z2q79LhfwsRcSPin8Jl6A8a5ws2X6",
+	"gdHyvRb1Q4fjCtpGWj7DcVKnZCY09wV/yhTDJJTsLX5F+TnwiT+iGxoS+1GKHEWB5nppyloDiop+h38f",
+	"CGl147OPiNQF3h+fIauOzTkVmNNvUROFB/tsj0v+C2cvGmZm61ZjOc0nPrB/NX8lzycSkyssazvAK/ez",
+	"g585f37qku7Z4jNYdWz99+FGevRPPprTjdwOaPq5wFnWPbc+0uGYQrdr+w1pR4PWxHa5HGEvWSc/SQZv",
+	"VtG6BSjB7HynfL0mHoyEigr8geg1i48NQPAECDa9/z/Fp0rda2qPxLU9P6BNQbODqJGvYF7qcfB2y/e8",
+	"JR1IU6pwxuHQix5F26zLDqIn7CCGNeuzg8Tw3WM9UjNajXboTXBHxMQq3ZiutavVs3XcKf5JxTerjs0/",
+	"1wx5ZrrNttqLDac+MokKjFZGTkC8cePWvHVnobV6YyG4u3zn1+/9+tZ7qws3P7/ZurLx2crU3WDqkv31",
+	"2c/nWrR6eSiuSExI1ilBPBAj5mmYR4dVuoH/xvrV8KOFCXN6VxadYdji1STYMQwpUnxPQBhMnQ7bVX5D",
+	"A77HDqSNM83VVtC7D/GouvETN+Y/+ZjcPyMJtUlDq+LYNZf1+N8xeZkwRl+ML7xFHfYa/g/cFLRgUIhB",
+	"KdqBxVZIdulv2T7qyYJVR0/IWGzejNfcaJPULddznbrVuMMVOHKGoNm3h1PuiFlAQf9XXMgW17v+N+uy",
+	"vWjLJNFWhXUrJHrOdsD/CU9Hm6DHgg14iESSMjejZ2h4ZVDAsfPHAAIkprlORvyAXvzPHyyQSavlTHK6",
+	"CybxyDXUFJQ+6zwBDeGZyCOT8XX4NmuFVsNbvmW5zhINwmtOQ7eOf2OdeBWBa7WCFS8krM81/L3YM4WO",
+	"aUC2Huvldd3ODBgS3ei7WPLqsZD76wSmZrDsDVEPDF7OHdeKFazwvyzbdmAHVmMu9USRiZRApUHd5XBF",
+	"eTZ2b6QsjTImg3ogiRkiJjDlagcczbyAd543/Ya/kpLX8mGdjFpvOT4NyuskTYEUA4E5iB/qkCungjq2",
+	"BlXI3779gXDDUkUyDHDESBY9NzRgU84n3qbjhiq9qM4q9Xw4QE3lnCTMVGCUOKsCMvop2oyesH3AeuSW",
+	"MS1pKOMF67A38IcS8EmYSp3PN6mc989GBaNC+UhUsOA0aRBazVYpMgjjp0+CDgKFAkugewoJTg8h41Vp",
+	"oea5gROE1K1vzEnlMg21JccPwlJrUvTTWAuKX1rcCLUwy3mdaN1z7dSrJWHAFxqPMEiVu+bQhv2B7+sM",
+	"1yYNAhFgzCu0MiJZGBlEx8skOJUmeWRS4/fVqsqe42rHRTVrjv/KLdMLl6pT45wtCNe28KDtsw53EHGX",
+	"6SbOlOD6ZMOrWw3nt9xDQN1J7uYcJoskPHRwvO7WG21AuALcsdq2E85Z4crxcKJBraXrrk3XS6Jh6FM6",
+	"7/yWHgWLkrmUcUxlJzo43FQg+ytneZgoPDqnzeDcH4S3eYf1hWDg6pXiIGevhdP9DWYBfANKUfRceBzZ",
+	"DkchxXbU6dLTNRdxh6K0XaUb+O/8h1cmzpy/kDYix1bo+rjQ4F/DuqQ6TURcE4WyjA7GIgvmxXVkPfyx",
+	"qh3j8QODu9449k4bl5YuXrCrF6cuXjxXf9++cP6SdWaJWla1fv68ZVenzltnF5fOLU0tnlmsLl48c6Zu",
+	"T523L9Snzi9Wl6pVq3rReKgD9E1v+QM39DeG6LjsFcRl0aBB6uuzF6l9QLLErki66GeVUp3qWyHsvzLq",
+	"8S58FITN35uuuayfxIHArlE/31gLyBjHewgRjXPXPBo+Imwqw/aZ1cRmisa4yWKFXtovFQTyZDwuWTOE",
+	"QNguIsMrQIdUPE6x726sBeMzijcIESoOHOWNHmkPpFgfhLQG6K3X7QJujyPNr1hnzl/I72mFrsc0UMo+",
+	"Eca2aqaACwagANiTcPR4U0PNwOJfPyrw8N3PHV8R30+Ao+oVOTzIwmmYIXnTW75JLY2wOIIzlEoSHaRt",
+	"xaQsJMmvrNAqJYZGEzsD5Eg8q1zxMOejNIQ0ErUeyuSLQXuWA1zBx404rynlLxrR+ajjADm75RtwNLJO",
+	"iiBiEdTLcJwZMsUpWVB3gdg6gw+BZJJAI5wvxsKPHQwXfypDOANnZdmfuI0NGd/L8zKAihU6i07DCTfK",
+	"Anw29ZIyit7OQ4EocwTAq8b2NWIBpeMOStQ4YwC+j56zA8G3IX2GHQrniZotASOw/cxTPAqNsAGPV4/t",
+	"G4UQicOBpuG4OZjk/VDA/thBtJ3fBSRWRJvZLWfXC2pCjyRgu4zRSWNQ5Lpg5Yn+iMrL4ITJUXIkDzFP",
+	"o88ldpK8FKc+wWG9xsj/Ez5E9C3kdSFsIGSLAmAPA0kdMoYZkKhRtUL8h7rjKeklUyQLtqkwLEUNLYuw",
+	"quqaHUNRZQcz2PwrIF1paJVdxS14FkRunJM1u2K5y8M9R3O5F1KjpF3yQw0NwcCD22BCBiJGowvfc68S",
+	"Ty8CBwzSEOtostKksqJSX7TJ0TybsKfoffhxF627V/AW6AmHPA8J/pQWHtejkahewgL4u6WI2af3vXop",
+	"VLmdPImxXwBG2YOd509n9ZahqKzqf/lD+JWqKwq9Ck3gAwLgKg5aJGGNdCIFxHMf1CBAWDOmazxgWzPM",
+	"GrjGa8b03x79P7NmLF44V5PJEjWj7jthzZj+Qnz/5UOz5ibBZHL1yvwHF859evvmGPcDjFcqyVdJ9KJC",
+	"2F/y3C+TvHX5K5FOFj2BbCrcTSeJUSTsFFJZVbuAj6bLwJoh0baKb2/MtGzupBMtRT6sCYYHTruN/99i",
+	"OxBajLbE2+ivRNFyyM0LLqGTgb+Dh4lULSd8CoutuWWYW1azHRxmyVg2qUALBFhmiH4jCBb15S6AJqWk",
+	"c7AAcRKkwq1Eu5EL6LI3ZbbUdsqS0aeOxi+DCmWK5+OQMYmasbaYZomDNE6hMGIWQ+OTJWP6i1EUzatW",
+	"QI2HX5rG+sSyN+E0W5gxaFC37tmOu4wJjIb4VSwBvqrcttZuCTdTbi04Zk4PdupaG0Zm2eZFo7VI9akQ",
+	"nrtgtYabQxgi46OYfHb5ag6cJ7R9/cYVA6BUqFl3QHnBZ8VZPMONgpOyJpJKhnySy4mo3oU4cmT94Ciy",
+	"Lwa5tTzibKOwBz5LFmfrwmSPi0JEpmhcazKQe5TlGbNDjIKfRO4rSOCcuYJfoYx5wXkpdxZmbIcZwgUf",
+	"qNSsm/iBuJzps5dxncPjuKRgq0KgniL2eoKyVHPhoazfM5/bbRLdc5k0imkSbfNChiRrgCSGAeZn9lGy",
+	"dvhC0z4vnpBjYsYESHB2qCYeCJP4UKgn6Zg5F7fcpYe21tyVhdkP04YU2MvqwYglbKFEfiGkXYaONX6/",
+	"prV+pdW6o4tln6lUMZbdssKQ+nDU/6tWs9+r1SrKP/+kdbw5btGgMkA++qBeUJgYkcAcU0EQ8l3C/gyo",
+	"uYNHjUY16I+Z5KkuGXO8wCSWa/ueY5ukadXh85rj2t5aYJKG47bXU/baF4bjIZHxV0ZJun44iMriPLTR",
+	"JLPgP1k5clxbUSwn5/ZSh80zjS+VDd3MrGC0balvn6zakRt5lIhNkYMBPVEFVWd7XEMFdggU/jtQH0X0",
+	"7lQUC80xnkqwKheyyRpxySczDucs4L8DKeGWcCxkY42jqTCqAjJUUx/umx46hNRGhj9o66q+hr4G9sDs",
+	"8Zc5XE0Z5m1TIgxDZtOp2EmkYbj2kpxKdvtfDkEfQQLUHp3xCFeVlp/+w8dYwsd4dIkxH6vfox2Zqoif",
+	"lJRQxsxHsjxbgwk35id4SaFIaAUtA3BgaAYGDndaVuanzujw/NQ5WViK8fK08yfMvv0d67GXGGdHR4ug",
+	"GVR0hUyVhZ1K2LVAoGYPSu21ELO84RZ3+omSLoQCd4HG8itwYsCsA2RyimKzg5fyb+Ajcp4yC21YG15b",
+	"E6asrzgN26fuAKBxRBu2LHyqzEqCllWHAbRpCeVAIHYvRzKTXeQXkGViHA6milGnRrEtW1uQ8vfiGVps",
+	"N1taXtDB5N5nBGuwNnkGUSpThpvqhygVn84ooU+0fLdRDL/mkQFZfvqE7UjfALe7se1ADyOtEL9ROguI",
+	"+tKWqERtOi4qIU3rK8/X1Jr+vC6ukzLv/r6dZYU64h3qO0tOEh5LU5pPrcBzh4W/M44jHhGBgAzH0Hyk",
+	"Hn8k962GY8dB71SFnQjr2B4NiOuFpAmoGEdTiKwF1aAiDqrAManxzaRc43NfDoYM4MJ8u9m0/I08bMrl",
+	"iR0h1+dkQsOnE+FFZftQRmSVJhO6MK+hi8a+q6wuJZFLMZ6S2XVw1kFNiyI0tGwrtFLMP1clVyZ6lwvX",
+	"FSTCla2EGpSfhwnWAABqly+Ywm3wJeggkaBeHgSp9RZx7iMZkrx1F++swXrETvlX1N5XOmjKquzkuWzz",
+	"G1PnCpB4cd+ha5o1/0e2/Q+4pdFv3WMHGlriIfzDaDtui5IkDXOxDjt/BN212KHoKpYnJt8JVnEvQmQ3",
+	"vDX0ENhOu2mYxoqzvKIV2MIHpQLhM9g6CT0SQ2MocojKdVxEDkRykrSnMD71wcikML4MnP/Kulp4psqg",
+	"ME042hJFtxgi4TpPP9rScqpszIFHQ+Ak2C53MuTzl2su+y8Z+c8HLgjnpmp/uY4S2qkQ9qckOGTK4xe/",
+	"g/seW0a9kalke6r4VJMZam5c37nLBa4oAE0rdtL9wt39sDVcZMx64lquyQeO/XBSMM9AEyaxbJvao2ks",
+	"Pm1690d7KYNpfNJkpMHYc9PRJZQWqXflJOvQJQ6LIcqWE5qGbXjUL9ietk8DNl+4WH2fjBW1vRjPnZFN",
+	"w6J+CBRqgcoDQKkf0lnvbhBabj3DSTIIVZ26VF28uHhuolqtVifeh/9dhP9Vlf+KWG5xynoQWmE7SE18",
+	"rnpOWyOT53VJj4/Cmhz18bbvTmPHgGkB9mnXCyeW9APoTW3JC8WydQhyO5WmlsORLfYabDhtEbGIoQqq",
+	"5byPV6VAQtOTuKMlrx5V2ZQmCjqwUiAxCDQ/3fdWR9N0j5aiL9agzjgYnnp+oCmSTDIFgxMpkxQLLE1t",
+	"6fzDfMHg120qqO2oZZPxGGrdpFymDorzqC8OLbyO9eoRvDI5JVq3Z5x+xDLT3O7FKKa6zKGbHVBf+4vY",
+	"bbK+421XLcEYrNSidMJEDIJW4i7rcl1pT/QQPEizGFRv98mVueszSiLLjkyZTOUt7krth+2pChvGi1+i",
+	"xSn6u8jf8orJf+uCkYFOvA468aa0JaCJFTXUNXbU2P5Pavg+bY5luxTGfZXvnwHVV5oz8jyjTaJJux8a",
+	"1tM1NXi36ftpUskn8b8Dj+EIzpMSqeTo/VUbKUSbumRsFS2Vxp3s0MxYrMOTtI2TTXUeRDBmzidZtFbM",
+	"ez6QDGhH7JAnrhykKkXho3FKqc3F2YjDagw1eHmi+SKD80MGeKTiXkSS7vPF1oZZUo0akgI9pG3wUXzs",
+	"pRXTETM5io9wmLb686ocmdUdT+FY8Cn9kFr26TpNfc8LP7SClVIFsCN3nBrqZ30gWyqYRK7EJHETloeD",
+	"msmFquJZjkKO0wcifleBmZnqF6M4gYewIsddPmoTvF9Ai8ry/SZFA7Tk2dU5/6PffN1cv/OZ9fmdK5fW",
+	"1q5eu3C97Z29f+e3v31/Yf3D2YW1u1c3lv35c6va8Up2o3S9ML61oBxmuF6Y3BRQ7pXEtZI+RFCw79O4",
+	"yI/3scwU+bO3WRmQ6PKY4c3zkRUPxAzxaQi4mO9/LlRJVXiDDqq2QMYloQmLY2id3f+dW3gO8Bcl9Hb8",
+	"No3JWEfv0/ipMysHHj0W1HTc2YZD3bDYgvpReNqzHc8yZRYqSuKz0TY6wrHk7RHqjiKTCvRCtFoRaQ/5",
+	"AKkA0pTI+9fnJ83HrYYHZSlpmhzxFyV641ialejNnrzTarEdhp5b1hGprlwD9vR6hxyzXlMpSDUbhH7K",
+	"mEPxb1DeEbZzdtwlT22bMweeWyJVZPBGKCqbPOOHpuG1qGu1HGPaOFupVqZ4ZcYKrj3ftXL6gbFMQ23y",
+	"jkhb5Z3u8x1qsxfKJGU2vE8tkW10kJ9i49Z8n9BMa+dccUuRvZNuiQsW1PcgVWd4aTHUJT0i+cYF8Ghr",
+	"dTnx6TvLlTnLDyjnPRWMEgEKoIYInmMDUCPu/RoYSkOxAJMv01D74CSvF5K9bov72mcJ5ctM9/cz1eqA",
+	"juOjdRpP9dLVtBuHHlMBRbQ/V60WjRYvb1LpTA+DBTI7xWg4QSgQgfA2v9C/KiBjNz77aH6cd17TOvn+",
+	"j66HMo+TDtfZlCOrEGxDp+tYFMfBC3sy19zBTZmHVi8Vd21mL8FRWUZ5hJDuvyVd5UQmUwcqpwVLBldo",
+	"D1NzYzrOVagBrrI9bW/qxA+Wrl/D+352o21t9rsCYmiHBXbHK4xeZ/omwE0UXKiBDyR2ZZAxpRP3OMGE",
+	"g24Z3pQ9rS2Zb/wqzbY6NTdpTID+wFfpmp8+e4McDMV3Go/E2fZ58pHkVQSvsumi/MY5zExPNbwiAjPL",
+	"d2U2BR+q5o7FJfcJIilttQ6lgzppyHauehYOHjLaNnmLwQyfSTr6yFA/jqAYpLm7nzIcWiTDAQkoCnG2",
+	"iDHDxXVs9TZddoKQ+jFXMeI46VXP3jh5rhUXvz3MMtSHOaY5dfLT6zimL2BAbWBwR2Kc8MrZ4a8sqXdR",
+	"nKteGv5GfGVHmjfLJef5Mz6o719bqGL8pbD5L5HcQeJovouwdKmj0/4lfNFLert1JWYPa/EGGoamqrib",
+	"Zhl5Rw0Zy0gG5Mhxu7gB/de5i2m8UnNllBtJE6jte5nU00uuVTvIpzb3cnufzrSKkjzhEfyIvEEeBuHQ",
+	"Q87QrbmSiapJrgggrIDmAiB5oo924StNoyh+2U1PiXWIpiJKhg8ZU9Wu2RVaX73tNRqLVn0VoMF+SuWM",
+	"KQuWQkV2nYz7homD4eEZFB5wtLlGyrEHqFJzZWSVn9VB4iZnfS4lsXQF6lK2pP7AFc63PBiobeiVMdym",
+	"ge0fct6f8iBzcGxGTzMHCFgo6s1l+8nocbRtcojKG5mw1yD6+aNvKoT9UXTjl6cHYBBYol0ozw5LLRRe",
+	"wXb7skY/x/5rLi7oJdbK834qPXFTVIIQPMPrAC2FHkkdbAoV+YvyYHVS4Z9pmI2An6JGq88I0DBqTrLx",
+	"yjM8UfwqsC39lB4PBxldfdGgZg/PDtLnYv6YY1T4dbSZ7TyOVRdb0TZPPBUab0xPYyqfNdF250VtpmSf",
+	"SI1/5ByJ7Yn0vXjxBFFLuQnCJNFjfl2eglzAI2Abu1xN4KrcAd8dV/MSTVyhhMFIsaC4ct8NVqipCUVo",
+	"ESrPDMCL9GMcMVIOhiIZ2WNvgd7ybhW8ly17UQenec4pldYV3EvUdirJnNOkyIdTc6V0O2CHYjxsuMT6",
+	"5JMWda/MXSdnK9VxwmtaBziwOJr0YlRVlosaNlpTbyVqZ3o+cNZbqblzn8wvwFy8ZUY6Ky+bbwzIJhxR",
+	"GIuPDTahy4vkBXZQIWgwckb3KNfOo+ZmtVrkhHtCWd/jy5d3oHSFqZ82oBTdHDIBgJLSQqVXuHNyfWni",
+	"Y8+lE3iLXpFjYjZBoFOkiKyjTEMK8VuEa4joxlAuwv5gwVrWpuVkWqhkQu7ypgTRcwbVwT3hBpWOxWJv",
+	"CCzzbPVcfl6u/ciDPxRX1/JRUfqLSG/qDDLU/el1otl0TNqDfWs/RJuqitkzNS2GoQAWq+aUiL6wKtWr",
+	"d6YFu0fU4Sx1jCN8mQAGyPckgiEIGp/uCotUaMOoZ6VapyHbfxp9T9A0PmR9ZZ2cGXSj30ffC/ji5axc",
+	"1WB9YaWqfkCNfl/O84chVVidmoFRRC9JdCA4bRGSBDQG+cryjq962/epGzY2SOi3gxDUDj5a2gkm8Qz0",
+	"CuqGvkMHoNu/CxfA0+h5tv05oBew5100xTYFoX0RhJYfmoS69rgpTvAF54Z4VfhUtVrFM01dXlAhsmty",
+	"HAETDjfAM0HKIMr2+Xqgy0GBrfSGyybYGTg9xA0qyoswgVBXxqrr1Sr5l3+JZx8vOn7RVtqhGj+u9kpa",
+	"gEK5i55liHrw9cmaGyhi0HPhwR03yZ/Yl15Ys/Hui/zFrj3aYnMLPK7/uFSMRHYTz8dHcnQCepPE7mP7",
+	"lUPfcoOW5cPFKSQ1skpM6PqdrCd3rBST1R9EtuBrtZuBUJPAE3tp6sKZRDneVS9+4C39ufnZIXgNinA3",
+	"wzNog+5xzi9f6mRf4nemgCNVoWgi6YhjTKalJzcc9rmuA67XbZgB2mjCPShKb1XOBVL2OpbXbih3z4wX",
+	"6erZ62lKkZq8CeYopDZVitR+BLhvo368j6YLQOqZ5FTSEQWsRwV0hl8WUF58f827JLaBmdXZQ9DqbPEz",
+	"POBxfBLLDUkWabhGqUvCNY+EPqUElEItyTnyYppigvtRXsDcibZ5AwDWUdgiYXtcj4J87WcDiUemCulw",
+	"PL4hB+3g/5uVPNEmXvq8pbbzHXLfHcoydSJ5v0EmE7SAojJX9gwJQeZWrGRo6XB3hadJlQ8ymprAm0Iv",
+	"KtR5cm7eb4HBlZjdRE9mhLFWhjwLtqEkf/1SiDBzbhoSjHH+GAQIr5wb/kp8A3WaYjMrIEueT6xYOm6k",
+	"CRVgPLEiEh71JJpChZ4kR7DWnmZQ4z9EFhRooeKSrAtnxmMfupLQrBoyz4WfUbmlRyQ4q0YRGAc/g2tf",
+	"xOYwy5CoPcGLZOVNbznOIT11l1Y80wBXluTQeldW/DPxlki4QklWpUrQJXW/ZAE3Fw70pA2sKIqPXeCs",
+	"I1irsFchoLAXRzDg3CuE/YGn1r2IOe4hhsTl5U08dIsx6y3RthErmUUzqmgb/Qtdcq/e9gPPv4eRaRD5",
+	"0aZMIlFj2l30+iir/lb6wntJy1rttf+d6Bty7+7Ex3Q9nJjlcwGO3LvpuKv3yJhPG5drhkvXw5oxzhvM",
+	"6rudx3cOpQyE7Krgatx73tJSQMN7RGlvu88j7CIfsc8LYHgnDBwjmzEjXu3xFR2yrnREvuBx4JQ/K9qM",
+	"wQii80+p+xNYH6f7fXJ9MAQpevpYRS8PPyDWe3cneJbXxKzVsrCnjkODezV37J7Vav1GBNQuT1XOVaoz",
+	"xAsuO14wk/hpgsshXQ9Nnmc2Q5TyhMuZzgn38Ahg/aTesJwmubfUusfj3HuwI9YhSZIHHPc9HukVNTnT",
+	"5Cq1fOrfI2PKukziBQRAmWTZKe5YMkmU75W1keix4JuvpbkNIbLt6Nn4DP8ifSkC6u88u6QLx5Br6GAW",
+	"XOsiWpwml8zspytICq+z4bVzSuSKJJVxPJMUfU8zQFpqmPJFvKf0dNE32slkf4NeTGXaaFqR9X8rZkk5",
+	"DUrHPJNHJhtO0wmxgeSQBznBlXmSk0mZJ9eccGXBC61GqWFlvUaJZ7Ggo+RzwgM6/GG6Xm+0bZq0Gyg1",
+	"Bc8muOY0QloKJrJ2Z2m050X+eIkXAs8PjXfjDtH3OC3hHAHChuAfzyI64NVviVIUfZP3qvbZTtovP8u3",
+	"EncxLVqseGVSbD1+HC6Hc9zVMvchpwVphF3h0oJ0OyMFTaUwro9jCA72dKDb3zRSUlYXZR1Nvh9jHUi4",
+	"E7NeW9u16q+oi+yzfkE+BaqhqYwE7pnRdyGLGcVlsOB0S0vMnofHNvXZT0KvRpGjW/5YapFNGlqp5M00",
+	"g+bpWZIWTikZLNOX/R3ngsWb09HyjyjCRUg8A80jm4VHz/PCa+5eo+Deip7GCrh2cTmVfzKgll9fGZha",
+	"/g/F8OdXDP+zUAnU5YGW0fB0qtc8IsMA5UvnzZEfR/JKDVMysGF3Irr+Id2HSveHGa4AXApbgI3A8fPs",
+	"4T66WWExBYn8P6pZxtrLZY7ewMOMAyKI+y8wW7wjczS0sciaK7JFecJGNiydK9FPR6gVr1RH1L8Udw8Z",
+	"U5tBmLzFBDVrbuoa1Wirwt5WxgsbiuAFdR2i5m3KksNOXI2SylyRpQ4dTFPZytXIaMia+8pPWWBn+rqU",
+	"EtjVExfYqc6yOlKFPCLwJKGSxpNfs5Wex9e3fszfxf52SA6FjvigK96AxBS9yE1njar9M9QaACgWAaQz",
+	"BRkCQeb78vErnrSC503NzRO6zCL8XunAkLlpNqbLnkw2Ew4Lkrn+tsANG9+7s4FtuUZzDmR6jRinGlI4",
+	"ug550oLheGEHwQa3eShN4nLR4vFXcv1X/Ib3UQ7HsfE8eAPwnNHB27K/I6ODT/Zz8TAtwvyZvRC3le1H",
+	"3ycZuW/fNR6ddsTrmLbQX3l5hghR9Pn1dTHk4rqt8vw37kqqMOJif+Ud+fC71FczPcPLKKw/pBrPJnEb",
+	"HohJ4jbw+bhhS56eLlSf50X34KaqT3THcyR2MuRMJx+Ivx4Wnq4icpJy9b9HqVNGTe+Lik+2nzvGX7zQ",
+	"4uHGPbQmtnh3azUh/tmJId1w61YgnYKfmZ5uA+L16fbTg2oK1HRPCYTMFk0s0eIpG5tq6jZYPdG/IrQ6",
+	"0iOs3IEePYfbuLeFlhvXRscdp6WKx4tPBPbIvuJqsDdWutEOEsvgvWOfSHsvSYXWXOVVcyEIlnTtQzTd",
+	"I9StkMGVCPwVcLgcikoEfXtvmXGeq+MrCl3NpRqpnRqdZxpPa6g9wSlZ13Li8YRfVqlA+oSOWiygAVus",
+	"GvASWqjuHiQv5vApWSd9jPPPtBKPh011Ytm4cWveurPQWr2xENxdvvPr9359673VhZuf32xd2fhsZepu",
+	"MHXJ/vrs53MtWr2s7dei6WaSQaR04XACqmUaKlXFZIznril+JLW1cSFby19ikCpVylVcxCWw+3F5Gqp0",
+	"eNyyNwHWOHAGKNu7bsHFDtGmeFtYn8iL+rLPNVK1Wu4ASxueg5TPS0xa1wXjM0T2P05VD3POlTRWOFDX",
+	"ATlRJGPNp++4URxucbf/vqq5dXl7ylesG8tzkc/3kvUrBJt7Y70TRvpzBU9YGPsWF/yduPMZJIjwH/ai",
+	"rRwdmcoRvMIJO9Fz7u3g2Y1dTHbmfFX0gS4w8jOd/0493SrXabAo6SpBaNLgK/vlFk6pWHFEXliwa6Tv",
+	"FWo1wpVCPvgh/owFxifLBjU9+A1v9Wic7ZOPMhvmmyJ1XDYA9/8PANVPUvUxsgAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
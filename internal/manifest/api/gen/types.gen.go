@@ -10,21 +10,241 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for ManifestUpdateBump.
+const (
+	Major ManifestUpdateBump = "major"
+	Minor ManifestUpdateBump = "minor"
+	Patch ManifestUpdateBump = "patch"
+)
+
+// Defines values for PermissionRisk.
+const (
+	High   PermissionRisk = "high"
+	Low    PermissionRisk = "low"
+	Medium PermissionRisk = "medium"
+)
+
+// Defines values for SignatureFormat.
+const (
+	Jws SignatureFormat = "jws"
+	Raw SignatureFormat = "raw"
+)
+
+// Defines values for SigningKeyStatus.
+const (
+	Active  SigningKeyStatus = "active"
+	Retired SigningKeyStatus = "retired"
+)
+
+// Defines values for Sort.
+const (
+	Newest Sort = "newest"
+	Oldest Sort = "oldest"
+	Title  Sort = "title"
+)
+
+// Defines values for TagsMatch.
+const (
+	All TagsMatch = "all"
+	Any TagsMatch = "any"
+)
+
 // Author defines model for Author.
 type Author struct {
 	Email string `json:"email"`
 	Name  string `json:"name"`
 }
 
+// AuthorKey Публичный ключ автора в формате JWK (RFC 7517); kid — отпечаток RFC 7638
+type AuthorKey struct {
+	Alg       string    `json:"alg"`
+	CreatedAt time.Time `json:"createdAt"`
+	Crv       string    `json:"crv"`
+	Email     string    `json:"email"`
+	Kid       string    `json:"kid"`
+	Kty       string    `json:"kty"`
+	Use       string    `json:"use"`
+
+	// X Публичный ключ, base64url без паддинга
+	X string `json:"x"`
+}
+
+// AuthorKeyCreate defines model for AuthorKeyCreate.
+type AuthorKeyCreate struct {
+	Email string `json:"email"`
+
+	// Endorsement Подпись автора Ed25519 над авторским payload: каноническим JSON v2 без meta.id
+	// и meta.createdAt, которые назначает сервис. Подпись платформы (signature)
+	// с canonicalVersion 2 покрывает и этот объект, т.е. является контрподписью
+	Endorsement *AuthorSignature `json:"endorsement,omitempty"`
+
+	// Proof Подпись регистрируемым ключом строки "pluto-author-key:<email>:<kid>", base64
+	Proof string `json:"proof"`
+
+	// PublicKey Публичный ключ Ed25519, base64
+	PublicKey string `json:"publicKey"`
+}
+
+// AuthorKeySet defines model for AuthorKeySet.
+type AuthorKeySet struct {
+	Keys []AuthorKey `json:"keys"`
+}
+
+// AuthorSignature Подпись автора Ed25519 над авторским payload: каноническим JSON v2 без meta.id
+// и meta.createdAt, которые назначает сервис. Подпись платформы (signature)
+// с canonicalVersion 2 покрывает и этот объект, т.е. является контрподписью
+type AuthorSignature struct {
+	// Kid kid ключа автора из GET /api/authors/keys
+	Kid string `json:"kid"`
+
+	// Signature Подпись Ed25519, base64
+	Signature string `json:"signature"`
+}
+
+// CatalogManifestFile Запись snapshot о текущей версии манифеста; хеш — от канонического payload, который подписан
+type CatalogManifestFile struct {
+	Hashes  map[string]string `json:"hashes"`
+	Length  int               `json:"length"`
+	Version string            `json:"version"`
+}
+
+// CatalogSnapshot defines model for CatalogSnapshot.
+type CatalogSnapshot struct {
+	Type    string    `json:"_type"`
+	Expires time.Time `json:"expires"`
+
+	// Manifests id манифеста → его текущая версия
+	Manifests map[string]CatalogManifestFile `json:"manifests"`
+	Version   int64                          `json:"version"`
+}
+
+// CatalogSnapshotFile Ссылка на snapshot; хеш — от байт ответа GET /api/catalog/snapshot
+type CatalogSnapshotFile struct {
+	Hashes  map[string]string `json:"hashes"`
+	Length  int               `json:"length"`
+	Version int64             `json:"version"`
+}
+
+// CatalogTimestamp defines model for CatalogTimestamp.
+type CatalogTimestamp struct {
+	Type    string    `json:"_type"`
+	Expires time.Time `json:"expires"`
+
+	// Snapshot Ссылка на snapshot; хеш — от байт ответа GET /api/catalog/snapshot
+	Snapshot CatalogSnapshotFile `json:"snapshot"`
+	Version  int64               `json:"version"`
+}
+
+// ConsistencyProof defines model for ConsistencyProof.
+type ConsistencyProof struct {
+	First  int64    `json:"first"`
+	Proof  [][]byte `json:"proof"`
+	Second int64    `json:"second"`
+}
+
+// FieldError defines model for FieldError.
+type FieldError struct {
+	Message string `json:"message"`
+
+	// Parameter Имя query/path/header параметра
+	Parameter *string `json:"parameter,omitempty"`
+
+	// Pointer JSON Pointer (RFC 6901) на поле тела запроса
+	Pointer *string `json:"pointer,omitempty"`
+}
+
+// InclusionProof defines model for InclusionProof.
+type InclusionProof struct {
+	AuditPath [][]byte `json:"auditPath"`
+	LeafIndex int64    `json:"leafIndex"`
+	TreeSize  int64    `json:"treeSize"`
+}
+
+// LocalizationDigests Дерево хешей локализаций, входящее в подпись с canonicalVersion 2:
+// locale → key → SHA-256 строки (hex). Позволяет проверить ответ с одной локалью
+type LocalizationDigests map[string]map[string]string
+
+// LogEntry Запись журнала об одной выданной подписи манифеста. У версии две записи:
+// о signature и о signatureJws (format=jws) — это разные подписи ключа
+type LogEntry struct {
+	CanonicalVersion int `json:"canonicalVersion"`
+
+	// Format jws — signature содержит detached JWS (signatureJws); без поля — подпись Ed25519 payload
+	Format     *string `json:"format,omitempty"`
+	ManifestId string  `json:"manifestId"`
+
+	// PayloadSha256 hex SHA-256 канонического payload, над которым сделана подпись
+	PayloadSha256 string  `json:"payloadSha256"`
+	Signature     string  `json:"signature"`
+	SignatureKid  *string `json:"signatureKid,omitempty"`
+	Version       string  `json:"version"`
+}
+
+// LogLeaf defines model for LogLeaf.
+type LogLeaf struct {
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Entry Запись журнала об одной выданной подписи манифеста. У версии две записи:
+	// о signature и о signatureJws (format=jws) — это разные подписи ключа
+	Entry     LogEntry `json:"entry"`
+	LeafData  []byte   `json:"leafData"`
+	LeafIndex int64    `json:"leafIndex"`
+}
+
 // Manifest defines model for Manifest.
 type Manifest struct {
-	Actions      ManifestAction       `json:"actions"`
+	Actions ManifestAction `json:"actions"`
+
+	// AuthorSignature Подпись автора Ed25519 над авторским payload: каноническим JSON v2 без meta.id
+	// и meta.createdAt, которые назначает сервис. Подпись платформы (signature)
+	// с canonicalVersion 2 покрывает и этот объект, т.е. является контрподписью
+	AuthorSignature *AuthorSignature `json:"authorSignature,omitempty"`
+
+	// CanonicalVersion Схема канонизации подписи; 1 — без локализаций, 2 — с createdAt и деревом хешей локализаций
+	CanonicalVersion *int `json:"canonicalVersion,omitempty"`
+
+	// Compatibility С какими клиентами работает манифест; пустые поля не ограничивают. Не входит
+	// в подписанный payload: управляет доставкой, а не содержимым, и меняется без новой версии
+	Compatibility *ManifestCompatibility `json:"compatibility,omitempty"`
+
+	// Compatible Совместим ли манифест с возможностями клиента; только если клиент их заявил
+	Compatible *bool `json:"compatible,omitempty"`
+
+	// Incompatibility Почему манифест несовместим; только при compatible=false
+	Incompatibility *[]string `json:"incompatibility,omitempty"`
+
+	// Locale Локаль, выбранная по Accept-Language; недостающие ключи взяты по цепочке отката (pt-BR → pt → en)
+	Locale       *string              `json:"locale,omitempty"`
 	Localization ManifestLocalization `json:"localization"`
-	Meta         ManifestMeta         `json:"meta"`
-	Permissions  []string             `json:"permissions"`
-	Script       ManifestScript       `json:"script"`
-	Signature    *string              `json:"signature,omitempty"`
-	Ui           ManifestUi           `json:"ui"`
+
+	// LocalizationDigests Дерево хешей локализаций, входящее в подпись с canonicalVersion 2:
+	// locale → key → SHA-256 строки (hex). Позволяет проверить ответ с одной локалью
+	LocalizationDigests *LocalizationDigests `json:"localizationDigests,omitempty"`
+	Meta                ManifestMeta         `json:"meta"`
+
+	// PermissionChanges Чем разрешения версии отличаются от предыдущей версии, в порядке манифеста.
+	// У первой версии оба списка пусты. Клиент, пропустивший несколько версий,
+	// объединяет изменения по истории GET /api/manifests/{id}/versions
+	PermissionChanges *PermissionChanges `json:"permissionChanges,omitempty"`
+	Permissions       []string           `json:"permissions"`
+
+	// RequiresReconsent Версия добавила разрешения — клиент с предыдущей версией должен снова спросить согласие
+	RequiresReconsent *bool `json:"requiresReconsent,omitempty"`
+
+	// Revocation Отзыв манифеста; без version отозваны все его версии
+	Revocation *Revocation    `json:"revocation,omitempty"`
+	Script     ManifestScript `json:"script"`
+	Signature  *string        `json:"signature,omitempty"`
+
+	// SignatureJws Detached JWS над тем же каноническим payload: заголовок {"alg":"EdDSA","kid":…,"b64":false,"crit":["b64"]},
+	// форма BASE64URL(header)..BASE64URL(signature). Только при signatureFormat=jws.
+	// Выпускается вместе с signature при публикации; у версий, подписанных раньше,
+	// отсутствует, пока их не переподпишет manifest-resign
+	SignatureJws *string `json:"signatureJws,omitempty"`
+
+	// SignatureKid kid ключа подписи из GET /api/keys; отсутствует у подписей, сделанных до ротации ключей
+	SignatureKid *string    `json:"signatureKid,omitempty"`
+	Ui           ManifestUi `json:"ui"`
 }
 
 // ManifestAction defines model for ManifestAction.
@@ -35,28 +255,56 @@ type ManifestActionBase = json.RawMessage
 
 // ManifestBase defines model for ManifestBase.
 type ManifestBase struct {
-	Actions     *[]ManifestActionBase `json:"actions,omitempty"`
-	Author      Author                `json:"author"`
-	Category    string                `json:"category"`
-	Icon        string                `json:"icon"`
-	Permissions []string              `json:"permissions"`
-	Script      ManifestScriptBase    `json:"script"`
-	Tags        []string              `json:"tags"`
+	Actions *[]ManifestActionBase `json:"actions,omitempty"`
+	Author  Author                `json:"author"`
+
+	// AuthorSignature Подпись автора Ed25519 над авторским payload: каноническим JSON v2 без meta.id
+	// и meta.createdAt, которые назначает сервис. Подпись платформы (signature)
+	// с canonicalVersion 2 покрывает и этот объект, т.е. является контрподписью
+	AuthorSignature *AuthorSignature `json:"authorSignature,omitempty"`
+	Category        string           `json:"category"`
+
+	// Compatibility С какими клиентами работает манифест; пустые поля не ограничивают. Не входит
+	// в подписанный payload: управляет доставкой, а не содержимым, и меняется без новой версии
+	Compatibility *ManifestCompatibility `json:"compatibility,omitempty"`
+	Icon          string                 `json:"icon"`
+	Permissions   []string               `json:"permissions"`
+	Script        ManifestScriptBase     `json:"script"`
+	Tags          []string               `json:"tags"`
 
 	// Ui Конфигурация пользовательского интерфейса
 	Ui ManifestUiBase `json:"ui"`
 }
 
+// ManifestCompatibility С какими клиентами работает манифест; пустые поля не ограничивают. Не входит
+// в подписанный payload: управляет доставкой, а не содержимым, и меняется без новой версии
+type ManifestCompatibility struct {
+	MaxAppVersion *string `json:"maxAppVersion,omitempty"`
+	MinAppVersion *string `json:"minAppVersion,omitempty"`
+
+	// Os Поддерживаемые ОС в нижнем регистре (ios, android, macos, windows, linux)
+	Os *[]string `json:"os,omitempty"`
+}
+
 // ManifestCreate defines model for ManifestCreate.
 type ManifestCreate struct {
-	Actions      *[]ManifestActionBase      `json:"actions,omitempty"`
-	Author       Author                     `json:"author"`
-	Category     string                     `json:"category"`
-	Icon         string                     `json:"icon"`
-	Localization ManifestLocalizationCreate `json:"localization"`
-	Permissions  []string                   `json:"permissions"`
-	Script       ManifestScriptBase         `json:"script"`
-	Tags         []string                   `json:"tags"`
+	Actions *[]ManifestActionBase `json:"actions,omitempty"`
+	Author  Author                `json:"author"`
+
+	// AuthorSignature Подпись автора Ed25519 над авторским payload: каноническим JSON v2 без meta.id
+	// и meta.createdAt, которые назначает сервис. Подпись платформы (signature)
+	// с canonicalVersion 2 покрывает и этот объект, т.е. является контрподписью
+	AuthorSignature *AuthorSignature `json:"authorSignature,omitempty"`
+	Category        string           `json:"category"`
+
+	// Compatibility С какими клиентами работает манифест; пустые поля не ограничивают. Не входит
+	// в подписанный payload: управляет доставкой, а не содержимым, и меняется без новой версии
+	Compatibility *ManifestCompatibility     `json:"compatibility,omitempty"`
+	Icon          string                     `json:"icon"`
+	Localization  ManifestLocalizationCreate `json:"localization"`
+	Permissions   []string                   `json:"permissions"`
+	Script        ManifestScriptBase         `json:"script"`
+	Tags          []string                   `json:"tags"`
 
 	// Ui Конфигурация пользовательского интерфейса
 	Ui ManifestUiBase `json:"ui"`
@@ -85,11 +333,14 @@ type ManifestMeta struct {
 
 // ManifestMetaLocalized defines model for ManifestMetaLocalized.
 type ManifestMetaLocalized struct {
-	Author        Author               `json:"author"`
-	Category      *string              `json:"category,omitempty"`
-	CreatedAt     *time.Time           `json:"createdAt,omitempty"`
-	Icon          *string              `json:"icon,omitempty"`
-	Id            *openapi_types.UUID  `json:"id,omitempty"`
+	Author    Author              `json:"author"`
+	Category  *string             `json:"category,omitempty"`
+	CreatedAt *time.Time          `json:"createdAt,omitempty"`
+	Icon      *string             `json:"icon,omitempty"`
+	Id        *openapi_types.UUID `json:"id,omitempty"`
+
+	// Locale Локаль, выбранная по Accept-Language; недостающие ключи взяты по цепочке отката (pt-BR → pt → en)
+	Locale        *string              `json:"locale,omitempty"`
 	Localization  ManifestLocalization `json:"localization"`
 	MetaCreatedAt *time.Time           `json:"metaCreatedAt,omitempty"`
 	Tags          *[]string            `json:"tags,omitempty"`
@@ -110,22 +361,264 @@ type ManifestUiBase = json.RawMessage
 
 // ManifestUpdate defines model for ManifestUpdate.
 type ManifestUpdate struct {
-	Actions      *[]ManifestActionBase      `json:"actions,omitempty"`
-	Author       Author                     `json:"author"`
-	Category     string                     `json:"category"`
-	Icon         string                     `json:"icon"`
-	Localization ManifestLocalizationCreate `json:"localization"`
-	Permissions  []string                   `json:"permissions"`
-	Script       ManifestScriptBase         `json:"script"`
-	Tags         []string                   `json:"tags"`
+	Actions *[]ManifestActionBase `json:"actions,omitempty"`
+	Author  *Author               `json:"author,omitempty"`
+
+	// AuthorSignature Подпись автора Ed25519 над авторским payload: каноническим JSON v2 без meta.id
+	// и meta.createdAt, которые назначает сервис. Подпись платформы (signature)
+	// с canonicalVersion 2 покрывает и этот объект, т.е. является контрподписью
+	AuthorSignature *AuthorSignature `json:"authorSignature,omitempty"`
+
+	// Bump Какую часть версии поднять; если не указано — выводится из изменений
+	Bump     *ManifestUpdateBump `json:"bump,omitempty"`
+	Category *string             `json:"category,omitempty"`
+
+	// Compatibility С какими клиентами работает манифест; пустые поля не ограничивают. Не входит
+	// в подписанный payload: управляет доставкой, а не содержимым, и меняется без новой версии
+	Compatibility *ManifestCompatibility      `json:"compatibility,omitempty"`
+	Icon          *string                     `json:"icon,omitempty"`
+	Localization  *ManifestLocalizationCreate `json:"localization,omitempty"`
+	Permissions   *[]string                   `json:"permissions,omitempty"`
+	Script        *ManifestScriptBase         `json:"script,omitempty"`
+	Tags          *[]string                   `json:"tags,omitempty"`
 
 	// Ui Конфигурация пользовательского интерфейса
-	Ui ManifestUiBase `json:"ui"`
+	Ui *ManifestUiBase `json:"ui,omitempty"`
+}
+
+// ManifestUpdateBump Какую часть версии поднять; если не указано — выводится из изменений
+type ManifestUpdateBump string
+
+// ManifestVerification defines model for ManifestVerification.
+type ManifestVerification struct {
+	// Reason Почему подпись не принята; только при valid=false
+	Reason *string `json:"reason,omitempty"`
+	Valid  bool    `json:"valid"`
+}
+
+// ManifestVersionSummary defines model for ManifestVersionSummary.
+type ManifestVersionSummary struct {
+	CanonicalVersion *int      `json:"canonicalVersion,omitempty"`
+	CreatedAt        time.Time `json:"createdAt"`
+
+	// PermissionChanges Чем разрешения версии отличаются от предыдущей версии, в порядке манифеста.
+	// У первой версии оба списка пусты. Клиент, пропустивший несколько версий,
+	// объединяет изменения по истории GET /api/manifests/{id}/versions
+	PermissionChanges PermissionChanges `json:"permissionChanges"`
+
+	// RequiresReconsent Версия добавила разрешения относительно предыдущей
+	RequiresReconsent bool    `json:"requiresReconsent"`
+	Signature         string  `json:"signature"`
+	SignatureKid      *string `json:"signatureKid,omitempty"`
+	Version           string  `json:"version"`
+}
+
+// MetadataSignature defines model for MetadataSignature.
+type MetadataSignature struct {
+	// Keyid kid ключа из GET /api/keys
+	Keyid string `json:"keyid"`
+
+	// Sig Подпись Ed25519 канонического JSON signed, base64
+	Sig string `json:"sig"`
+}
+
+// Permission defines model for Permission.
+type Permission struct {
+	Description string `json:"description"`
+
+	// Locale Локаль, выбранная для title и description
+	Locale string `json:"locale"`
+	Name   string `json:"name"`
+
+	// RequiresReview Манифесты с этим разрешением нужно проверять вручную
+	RequiresReview bool           `json:"requiresReview"`
+	Risk           PermissionRisk `json:"risk"`
+	Title          string         `json:"title"`
+}
+
+// PermissionRisk defines model for Permission.Risk.
+type PermissionRisk string
+
+// PermissionChanges Чем разрешения версии отличаются от предыдущей версии, в порядке манифеста.
+// У первой версии оба списка пусты. Клиент, пропустивший несколько версий,
+// объединяет изменения по истории GET /api/manifests/{id}/versions
+type PermissionChanges struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+// PermissionList defines model for PermissionList.
+type PermissionList struct {
+	Permissions []Permission `json:"permissions"`
+}
+
+// Problem Ошибка в формате RFC 7807 (application/problem+json)
+type Problem struct {
+	Detail    *string       `json:"detail,omitempty"`
+	Errors    *[]FieldError `json:"errors,omitempty"`
+	Instance  *string       `json:"instance,omitempty"`
+	RequestId *string       `json:"requestId,omitempty"`
+	Status    int           `json:"status"`
+	Title     string        `json:"title"`
+	Type      string        `json:"type"`
+}
+
+// Revocation Отзыв манифеста; без version отозваны все его версии
+type Revocation struct {
+	ManifestId string    `json:"manifestId"`
+	Reason     string    `json:"reason"`
+	RevokedAt  time.Time `json:"revokedAt"`
+	Version    *string   `json:"version,omitempty"`
+}
+
+// RevocationList defines model for RevocationList.
+type RevocationList struct {
+	Type     string       `json:"_type"`
+	Expires  time.Time    `json:"expires"`
+	Revoked  []Revocation `json:"revoked"`
+	Sequence int64        `json:"sequence"`
+}
+
+// SignedCatalogSnapshot defines model for SignedCatalogSnapshot.
+type SignedCatalogSnapshot struct {
+	Signatures []MetadataSignature `json:"signatures"`
+	Signed     CatalogSnapshot     `json:"signed"`
+}
+
+// SignedCatalogTimestamp defines model for SignedCatalogTimestamp.
+type SignedCatalogTimestamp struct {
+	Signatures []MetadataSignature `json:"signatures"`
+	Signed     CatalogTimestamp    `json:"signed"`
+}
+
+// SignedManifest Манифест в том виде, в каком его вернул API; поля вне подписи допускаются и игнорируются
+type SignedManifest struct {
+	Actions *ManifestAction `json:"actions,omitempty"`
+
+	// AuthorSignature Подпись автора Ed25519 над авторским payload: каноническим JSON v2 без meta.id
+	// и meta.createdAt, которые назначает сервис. Подпись платформы (signature)
+	// с canonicalVersion 2 покрывает и этот объект, т.е. является контрподписью
+	AuthorSignature *AuthorSignature `json:"authorSignature,omitempty"`
+
+	// CanonicalVersion Схема канонизации; если не указана — 1
+	CanonicalVersion *int    `json:"canonicalVersion,omitempty"`
+	Locale           *string `json:"locale,omitempty"`
+
+	// Localization Строки выбранной локали; для v2 сверяются с localizationDigests по цепочке отката
+	Localization *map[string]string `json:"localization,omitempty"`
+
+	// LocalizationDigests Дерево хешей локализаций, входящее в подпись с canonicalVersion 2:
+	// locale → key → SHA-256 строки (hex). Позволяет проверить ответ с одной локалью
+	LocalizationDigests *LocalizationDigests `json:"localizationDigests,omitempty"`
+	Meta                SignedManifestMeta   `json:"meta"`
+	Permissions         []string             `json:"permissions"`
+	Script              ManifestScript       `json:"script"`
+	Signature           string               `json:"signature"`
+
+	// SignatureJws Detached JWS из ответа с signatureFormat=jws; если передан, проверяется вместе с signature
+	SignatureJws *string `json:"signatureJws,omitempty"`
+
+	// SignatureKid kid ключа подписи; если не указан, подпись проверяется всеми доверенными ключами
+	SignatureKid *string `json:"signatureKid,omitempty"`
+
+	// Ui Конфигурация пользовательского интерфейса
+	Ui ManifestUi `json:"ui"`
+}
+
+// SignedManifestMeta defines model for SignedManifestMeta.
+type SignedManifestMeta struct {
+	Author   Author `json:"author"`
+	Category string `json:"category"`
+
+	// CreatedAt Подписывается с canonicalVersion 2
+	CreatedAt *time.Time         `json:"createdAt,omitempty"`
+	Icon      string             `json:"icon"`
+	Id        openapi_types.UUID `json:"id"`
+	Tags      []string           `json:"tags"`
+	Version   string             `json:"version"`
+}
+
+// SignedRevocationList defines model for SignedRevocationList.
+type SignedRevocationList struct {
+	Signatures []MetadataSignature `json:"signatures"`
+	Signed     RevocationList      `json:"signed"`
+}
+
+// SignedTreeHead defines model for SignedTreeHead.
+type SignedTreeHead struct {
+	// Keyid kid ключа из GET /api/keys
+	Keyid    string `json:"keyid"`
+	RootHash []byte `json:"rootHash"`
+
+	// Signature Подпись Ed25519 канонического JSON {treeSize, rootHash, timestamp}, base64
+	Signature string    `json:"signature"`
+	Timestamp time.Time `json:"timestamp"`
+	TreeSize  int64     `json:"treeSize"`
+}
+
+// SigningKey Публичный ключ Ed25519 в формате JWK (RFC 7517); kid — отпечаток RFC 7638
+type SigningKey struct {
+	Alg       string     `json:"alg"`
+	Crv       string     `json:"crv"`
+	Kid       string     `json:"kid"`
+	Kty       string     `json:"kty"`
+	NotAfter  *time.Time `json:"notAfter,omitempty"`
+	NotBefore *time.Time `json:"notBefore,omitempty"`
+
+	// Status active — ключ, которым подписываются новые версии; retired — только для проверки
+	Status SigningKeyStatus `json:"status"`
+	Use    string           `json:"use"`
+
+	// X Публичный ключ, base64url без паддинга
+	X string `json:"x"`
+}
+
+// SigningKeyStatus active — ключ, которым подписываются новые версии; retired — только для проверки
+type SigningKeyStatus string
+
+// SigningKeySet defines model for SigningKeySet.
+type SigningKeySet struct {
+	Keys []SigningKey `json:"keys"`
+}
+
+// UiComponent defines model for UiComponent.
+type UiComponent struct {
+	Description string `json:"description"`
+
+	// MinClientVersion Первая версия клиента, которая умеет рисовать компонент
+	MinClientVersion string `json:"minClientVersion"`
+
+	// PropsSchema JSON Schema для props компонента
+	PropsSchema map[string]interface{} `json:"propsSchema"`
+	Type        string                 `json:"type"`
+}
+
+// UiComponentList defines model for UiComponentList.
+type UiComponentList struct {
+	Components []UiComponent `json:"components"`
 }
 
 // AcceptLanguage defines model for acceptLanguage.
 type AcceptLanguage = string
 
+// AuthorFilter Имя или email автора, без учёта регистра
+type AuthorFilter = string
+
+// Category defines model for category.
+type Category = string
+
+// CreatedAfter Опубликованные не раньше (включительно)
+type CreatedAfter = time.Time
+
+// CreatedBefore Опубликованные строго раньше
+type CreatedBefore = time.Time
+
+// Cursor Непрозрачный курсор из X-Next-Cursor предыдущей страницы
+type Cursor = string
+
+// ExcludePermissions Исключить манифесты, запрашивающие любое из перечисленных разрешений
+type ExcludePermissions = []string
+
 // Id defines model for id.
 type Id = openapi_types.UUID
 
@@ -135,15 +628,98 @@ type Limit = int
 // Offset defines model for offset.
 type Offset = int
 
+// SignatureFormat raw — только base64-подпись в signature; jws — дополнительно detached JWS
+// (RFC 7515, незакодированный payload по RFC 7797) в signatureJws.
+// Вместо параметра можно передать Accept: application/json; signature=jws
+type SignatureFormat string
+
+// Sort newest/oldest — по дате публикации, title — по заголовку в запрошенной локали
+type Sort string
+
+// Tags Теги через запятую; как их сочетать, задаёт tagsMatch
+type Tags = []string
+
+// TagsMatch any — хотя бы один из тегов, all — все теги
+type TagsMatch string
+
+// Version defines model for version.
+type Version = string
+
+// WithTotal Вернуть общее число манифестов в заголовке X-Total-Count
+type WithTotal = bool
+
+// BadRequest defines model for badRequest.
+type BadRequest = Problem
+
+// Conflict defines model for conflict.
+type Conflict = Problem
+
 // NotFound defines model for notFound.
-type NotFound struct {
-	Error *string `json:"error,omitempty"`
+type NotFound = Problem
+
+// ListAuthorKeysParams defines parameters for ListAuthorKeys.
+type ListAuthorKeysParams struct {
+	// Email Email автора, без учёта регистра
+	Email string `form:"email" json:"email"`
+}
+
+// ListLogEntriesParams defines parameters for ListLogEntries.
+type ListLogEntriesParams struct {
+	Start int64 `form:"start" json:"start"`
+
+	// End Индекс после последнего листа
+	End int64 `form:"end" json:"end"`
+}
+
+// GetConsistencyProofParams defines parameters for GetConsistencyProof.
+type GetConsistencyProofParams struct {
+	First int64 `form:"first" json:"first"`
+
+	// Second По умолчанию — текущий размер журнала
+	Second *int64 `form:"second,omitempty" json:"second,omitempty"`
+}
+
+// GetInclusionProofParams defines parameters for GetInclusionProof.
+type GetInclusionProofParams struct {
+	// Hash Хеш листа, base64
+	Hash string `form:"hash" json:"hash"`
+
+	// TreeSize Размер дерева из подписанной головы; по умолчанию — текущий
+	TreeSize *int64 `form:"treeSize,omitempty" json:"treeSize,omitempty"`
 }
 
 // ListManifestsParams defines parameters for ListManifests.
 type ListManifestsParams struct {
 	Limit  *Limit  `form:"limit,omitempty" json:"limit,omitempty"`
 	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Непрозрачный курсор из X-Next-Cursor предыдущей страницы
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// WithTotal Вернуть общее число манифестов в заголовке X-Total-Count
+	WithTotal *WithTotal `form:"withTotal,omitempty" json:"withTotal,omitempty"`
+	Category  *Category  `form:"category,omitempty" json:"category,omitempty"`
+
+	// Tags Теги через запятую; как их сочетать, задаёт tagsMatch
+	Tags *Tags `form:"tags,omitempty" json:"tags,omitempty"`
+
+	// TagsMatch any — хотя бы один из тегов, all — все теги
+	TagsMatch *TagsMatch `form:"tagsMatch,omitempty" json:"tagsMatch,omitempty"`
+
+	// ExcludePermissions Исключить манифесты, запрашивающие любое из перечисленных разрешений
+	ExcludePermissions *ExcludePermissions `form:"excludePermissions,omitempty" json:"excludePermissions,omitempty"`
+
+	// Author Имя или email автора, без учёта регистра
+	Author *AuthorFilter `form:"author,omitempty" json:"author,omitempty"`
+
+	// CreatedAfter Опубликованные не раньше (включительно)
+	CreatedAfter *CreatedAfter `form:"createdAfter,omitempty" json:"createdAfter,omitempty"`
+
+	// CreatedBefore Опубликованные строго раньше
+	CreatedBefore *CreatedBefore `form:"createdBefore,omitempty" json:"createdBefore,omitempty"`
+
+	// Sort newest/oldest — по дате публикации, title — по заголовку в запрошенной локали
+	Sort *Sort `form:"sort,omitempty" json:"sort,omitempty"`
 }
 
 // SearchManifestsParams defines parameters for SearchManifests.
@@ -152,8 +728,30 @@ type SearchManifestsParams struct {
 	AcceptLanguage *AcceptLanguage `json:"Accept-Language,omitempty"`
 }
 
+// GetManifestByIdParams defines parameters for GetManifestById.
+type GetManifestByIdParams struct {
+	// SignatureFormat raw — только base64-подпись в signature; jws — дополнительно detached JWS
+	// (RFC 7515, незакодированный payload по RFC 7797) в signatureJws.
+	// Вместо параметра можно передать Accept: application/json; signature=jws
+	SignatureFormat *SignatureFormat `form:"signatureFormat,omitempty" json:"signatureFormat,omitempty"`
+}
+
+// GetManifestVersionParams defines parameters for GetManifestVersion.
+type GetManifestVersionParams struct {
+	// SignatureFormat raw — только base64-подпись в signature; jws — дополнительно detached JWS
+	// (RFC 7515, незакодированный payload по RFC 7797) в signatureJws.
+	// Вместо параметра можно передать Accept: application/json; signature=jws
+	SignatureFormat *SignatureFormat `form:"signatureFormat,omitempty" json:"signatureFormat,omitempty"`
+}
+
+// RegisterAuthorKeyJSONRequestBody defines body for RegisterAuthorKey for application/json ContentType.
+type RegisterAuthorKeyJSONRequestBody = AuthorKeyCreate
+
 // CreateManifestJSONRequestBody defines body for CreateManifest for application/json ContentType.
 type CreateManifestJSONRequestBody = ManifestCreate
 
 // UpdateManifestJSONRequestBody defines body for UpdateManifest for application/json ContentType.
 type UpdateManifestJSONRequestBody = ManifestUpdate
+
+// VerifyManifestJSONRequestBody defines body for VerifyManifest for application/json ContentType.
+type VerifyManifestJSONRequestBody = SignedManifest
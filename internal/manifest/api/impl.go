@@ -3,12 +3,22 @@ package api
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 	"github.com/rs/zerolog"
+	"golang.org/x/text/language"
 	"net/http"
+	"net/url"
 	"pluto-backend/internal/manifest/api/gen"
+	"pluto-backend/internal/manifest/repository"
 	"pluto-backend/internal/manifest/service"
+	"pluto-backend/internal/platform/jwk"
+	"pluto-backend/internal/platform/problem"
 	"pluto-backend/internal/platform/utils"
+	"pluto-backend/pkg/manifestsig"
+	"strconv"
+	"time"
 )
 
 type Handlers struct {
@@ -31,8 +41,7 @@ func (h *Handlers) HealthCheck(w http.ResponseWriter, r *http.Request) {
 func (h *Handlers) GetPublicKey(w http.ResponseWriter, r *http.Request) {
 	pubKey, err := h.Svc.GetPublicKey(r.Context())
 	if err != nil {
-		h.Logger.Error().Err(err).Msg("GetPublicKey failed")
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		h.fail(w, r, "GetPublicKey", err)
 		return
 	}
 
@@ -45,34 +54,380 @@ func (h *Handlers) GetPublicKey(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ListSigningKeys публикует JWKS доверенных ключей: активный первым, затем выведенные из ротации
+func (h *Handlers) ListSigningKeys(w http.ResponseWriter, r *http.Request) {
+	keys := h.Svc.SigningKeys()
+
+	out := gen.SigningKeySet{Keys: make([]gen.SigningKey, len(keys))}
+	for i, k := range keys {
+		j := jwk.FromEd25519(k.Key)
+		status := gen.Retired
+		if k.Active {
+			status = gen.Active
+		}
+		out.Keys[i] = gen.SigningKey{
+			Kty:       j.Kty,
+			Crv:       j.Crv,
+			X:         j.X,
+			Kid:       k.Kid,
+			Use:       j.Use,
+			Alg:       j.Alg,
+			Status:    status,
+			NotBefore: toTimePtr(k.NotBefore),
+			NotAfter:  toTimePtr(k.NotAfter),
+		}
+	}
+
+	JSON(w, http.StatusOK, out)
+}
+
+// GetCatalogSnapshot отдаёт подписанный snapshot байт в байт: хеш в timestamp считается от них
+func (h *Handlers) GetCatalogSnapshot(w http.ResponseWriter, r *http.Request) {
+	snapshot, err := h.Svc.CatalogSnapshot(r.Context())
+	if err != nil {
+		h.fail(w, r, "GetCatalogSnapshot", err)
+		return
+	}
+	writeMetadata(w, snapshot)
+}
+
+func (h *Handlers) GetCatalogTimestamp(w http.ResponseWriter, r *http.Request) {
+	timestamp, err := h.Svc.CatalogTimestamp(r.Context())
+	if err != nil {
+		h.fail(w, r, "GetCatalogTimestamp", err)
+		return
+	}
+	writeMetadata(w, timestamp)
+}
+
+// writeMetadata — готовый подписанный документ; кешировать его нельзя, иначе промежуточный
+// кеш сам станет источником устаревшего каталога
+func writeMetadata(w http.ResponseWriter, body []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// GetLogTreeHead — подписанная голова журнала прозрачности
+func (h *Handlers) GetLogTreeHead(w http.ResponseWriter, r *http.Request) {
+	head, err := h.Svc.TreeHead(r.Context())
+	if err != nil {
+		h.fail(w, r, "GetLogTreeHead", err)
+		return
+	}
+	JSON(w, http.StatusOK, gen.SignedTreeHead{
+		Keyid:     head.KeyID,
+		RootHash:  head.RootHash,
+		Signature: head.Signature,
+		Timestamp: head.Timestamp,
+		TreeSize:  head.TreeSize,
+	})
+}
+
+func (h *Handlers) GetInclusionProof(w http.ResponseWriter, r *http.Request, params gen.GetInclusionProofParams) {
+	var treeSize int64
+	if params.TreeSize != nil {
+		treeSize = *params.TreeSize
+	}
+	proof, err := h.Svc.InclusionProof(r.Context(), params.Hash, treeSize)
+	if err != nil {
+		h.fail(w, r, "GetInclusionProof", err)
+		return
+	}
+	JSON(w, http.StatusOK, gen.InclusionProof{
+		AuditPath: nonNilPath(proof.AuditPath),
+		LeafIndex: proof.LeafIndex,
+		TreeSize:  proof.TreeSize,
+	})
+}
+
+func (h *Handlers) GetConsistencyProof(w http.ResponseWriter, r *http.Request, params gen.GetConsistencyProofParams) {
+	var second int64
+	if params.Second != nil {
+		second = *params.Second
+	}
+	proof, err := h.Svc.ConsistencyProof(r.Context(), params.First, second)
+	if err != nil {
+		h.fail(w, r, "GetConsistencyProof", err)
+		return
+	}
+	JSON(w, http.StatusOK, gen.ConsistencyProof{
+		First:  proof.First,
+		Proof:  nonNilPath(proof.Proof),
+		Second: proof.Second,
+	})
+}
+
+func (h *Handlers) ListLogEntries(w http.ResponseWriter, r *http.Request, params gen.ListLogEntriesParams) {
+	entries, err := h.Svc.LogEntries(r.Context(), params.Start, params.End)
+	if err != nil {
+		h.fail(w, r, "ListLogEntries", err)
+		return
+	}
+	out := make([]gen.LogLeaf, 0, len(entries))
+	for _, e := range entries {
+		leaf := gen.LogLeaf{
+			CreatedAt: e.CreatedAt,
+			Entry: gen.LogEntry{
+				CanonicalVersion: e.Entry.CanonicalVersion,
+				ManifestId:       e.Entry.ManifestID,
+				PayloadSha256:    e.Entry.PayloadSHA256,
+				Signature:        e.Entry.Signature,
+				Version:          e.Entry.Version,
+			},
+			LeafData:  e.LeafData,
+			LeafIndex: e.LeafIndex,
+		}
+		if e.Entry.SignatureKid != "" {
+			leaf.Entry.SignatureKid = &e.Entry.SignatureKid
+		}
+		if e.Entry.Format != "" {
+			leaf.Entry.Format = &e.Entry.Format
+		}
+		out = append(out, leaf)
+	}
+	JSON(w, http.StatusOK, out)
+}
+
+// nonNilPath — пустое доказательство (дерево из одного листа, равные размеры) отдаётся как [], а не null
+func nonNilPath(path [][]byte) [][]byte {
+	if path == nil {
+		return [][]byte{}
+	}
+	return path
+}
+
+// revocationCacheControl — сколько список отзыва может жить в кешах; это и есть
+// наибольшая задержка kill switch для клиента, который опрашивает его через кеш
+const revocationCacheControl = "public, max-age=60"
+
+// GetRevocationList отдаёт подписанный список отзыва. Приложение опрашивает его часто,
+// поэтому ответ кешируется ненадолго и поддерживает If-None-Match
+func (h *Handlers) GetRevocationList(w http.ResponseWriter, r *http.Request) {
+	list, err := h.Svc.RevocationList(r.Context())
+	if err != nil {
+		h.fail(w, r, "GetRevocationList", err)
+		return
+	}
+	Cached(w, r, revocationCacheControl, list)
+}
+
+// componentsCacheControl — реестр компонентов меняется только с выкладкой сервера
+const componentsCacheControl = "public, max-age=300"
+
+// ListComponents отдаёт реестр компонентов UI: клиент сверяет с ним, что умеет рисовать
+func (h *Handlers) ListComponents(w http.ResponseWriter, r *http.Request) {
+	rows, err := h.Svc.ListComponents(r.Context())
+	if err != nil {
+		h.fail(w, r, "ListComponents", err)
+		return
+	}
+
+	out := gen.UiComponentList{Components: make([]gen.UiComponent, 0, len(rows))}
+	for _, row := range rows {
+		var props map[string]interface{}
+		if err := json.Unmarshal(row.PropsSchema, &props); err != nil {
+			h.fail(w, r, "ListComponents: props schema "+row.Type, err)
+			return
+		}
+		out.Components = append(out.Components, gen.UiComponent{
+			Type:             row.Type,
+			PropsSchema:      props,
+			MinClientVersion: row.MinClientVersion,
+			Description:      row.Description,
+		})
+	}
+	body, err := json.Marshal(out)
+	if err != nil {
+		h.fail(w, r, "ListComponents: encode", err)
+		return
+	}
+	Cached(w, r, componentsCacheControl, body)
+}
+
+// permissionsCacheControl — каталог разрешений, как и реестр компонентов, меняется с выкладкой
+const permissionsCacheControl = "public, max-age=300"
+
+// ListPermissions отдаёт каталог разрешений для экрана согласия: тексты каждого разрешения
+// локализуются по Accept-Language с откатом к en
+func (h *Handlers) ListPermissions(w http.ResponseWriter, r *http.Request) {
+	rows, err := h.Svc.ListPermissions(r.Context())
+	if err != nil {
+		h.fail(w, r, "ListPermissions", err)
+		return
+	}
+
+	prefs := utils.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	out := gen.PermissionList{Permissions: make([]gen.Permission, 0, len(rows))}
+	locales := make([]string, 0, len(rows))
+	for _, row := range rows {
+		var all map[string]map[string]string
+		if err := json.Unmarshal(row.Localizations, &all); err != nil {
+			h.fail(w, r, "ListPermissions: localizations "+row.Name, err)
+			return
+		}
+		locale, texts := utils.Localize(all, prefs)
+		locales = append(locales, locale)
+		out.Permissions = append(out.Permissions, gen.Permission{
+			Name:           row.Name,
+			Risk:           gen.PermissionRisk(row.Risk),
+			RequiresReview: row.RequiresReview,
+			Title:          texts["title"],
+			Description:    texts["description"],
+			Locale:         locale,
+		})
+	}
+	body, err := json.Marshal(out)
+	if err != nil {
+		h.fail(w, r, "ListPermissions: encode", err)
+		return
+	}
+
+	setContentLanguage(w, locales...)
+	Cached(w, r, permissionsCacheControl, body)
+}
+
+// ListAuthorKeys публикует JWKS ключей автора; формат совместим с manifestsig.ParseKeySet
+func (h *Handlers) ListAuthorKeys(w http.ResponseWriter, r *http.Request, params gen.ListAuthorKeysParams) {
+	keys, err := h.Svc.ListAuthorKeys(r.Context(), params.Email)
+	if err != nil {
+		h.fail(w, r, "ListAuthorKeys", err)
+		return
+	}
+
+	out := gen.AuthorKeySet{Keys: make([]gen.AuthorKey, len(keys))}
+	for i, k := range keys {
+		out.Keys[i] = toAuthorKey(k)
+	}
+
+	JSON(w, http.StatusOK, out)
+}
+
+func (h *Handlers) RegisterAuthorKey(w http.ResponseWriter, r *http.Request) {
+	var req gen.AuthorKeyCreate
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.Logger.Error().Err(err).Msg("registerAuthorKey: failed to decode")
+		Error(w, r, http.StatusBadRequest, problem.CodeInvalidRequest, "invalid request body")
+		return
+	}
+
+	key, err := h.Svc.RegisterAuthorKey(r.Context(), req)
+	if err != nil {
+		h.fail(w, r, "registerAuthorKey", err)
+		return
+	}
+
+	JSON(w, http.StatusCreated, toAuthorKey(key))
+}
+
+func toAuthorKey(k repository.AuthorKey) gen.AuthorKey {
+	j := jwk.FromEd25519(k.PublicKey)
+	return gen.AuthorKey{
+		Kty:       j.Kty,
+		Crv:       j.Crv,
+		X:         j.X,
+		Kid:       k.Kid,
+		Use:       j.Use,
+		Alg:       j.Alg,
+		Email:     k.AuthorEmail,
+		CreatedAt: k.CreatedAt,
+	}
+}
+
+// toRevocation — отметка об отзыве в ответе с манифестом
+func toRevocation(rev *repository.Revocation) *gen.Revocation {
+	if rev == nil {
+		return nil
+	}
+	return &gen.Revocation{
+		ManifestId: rev.ManifestID.String(),
+		Reason:     rev.Reason,
+		RevokedAt:  rev.RevokedAt,
+		Version:    toStringPtr(rev.Version),
+	}
+}
+
+func toAuthorSignature(kid, signature sql.NullString) *gen.AuthorSignature {
+	if !kid.Valid {
+		return nil
+	}
+	return &gen.AuthorSignature{Kid: kid.String, Signature: signature.String}
+}
+
 func (h *Handlers) ListManifests(
 	w http.ResponseWriter,
 	r *http.Request,
 	params gen.ListManifestsParams,
 ) {
-	limit := int32(100)
-	offset := int32(0)
+	query := service.ListManifestsQuery{Limit: 100}
 	if params.Limit != nil {
-		limit = int32(*params.Limit)
+		query.Limit = int32(*params.Limit)
 	}
 	if params.Offset != nil {
-		offset = int32(*params.Offset)
+		query.Offset = int32(*params.Offset)
+	}
+	if params.Cursor != nil {
+		query.Cursor = *params.Cursor
+	}
+	if params.WithTotal != nil {
+		query.WithTotal = *params.WithTotal
 	}
+	if params.Sort != nil {
+		query.Sort = *params.Sort
+	}
+	if params.Category != nil {
+		query.Filter.Category = *params.Category
+	}
+	if params.Tags != nil {
+		query.Filter.Tags = *params.Tags
+	}
+	if params.TagsMatch != nil {
+		query.Filter.MatchAllTags = *params.TagsMatch == gen.All
+	}
+	if params.ExcludePermissions != nil {
+		query.Filter.ExcludePermissions = *params.ExcludePermissions
+	}
+	if params.Author != nil {
+		query.Filter.Author = *params.Author
+	}
+	query.Filter.CreatedAfter = params.CreatedAfter
+	query.Filter.CreatedBefore = params.CreatedBefore
+	query.Client = clientCapabilities(r)
+	varyCapabilities(w)
 
-	locale := r.Header.Get("Accept-Language")
-	if locale == "" {
-		locale = "en"
+	prefs := utils.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	chain, err := h.localeChain(r.Context(), prefs)
+	if err != nil {
+		h.fail(w, r, "ListManifests: locales", err)
+		return
 	}
+	query.Locales = chain
 
-	repos, err := h.Svc.ListManifests(r.Context(), limit, offset, locale)
+	page, err := h.Svc.ListManifests(r.Context(), query)
 	if err != nil {
-		h.Logger.Error().Err(err).Msg("ListManifests failed")
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		h.fail(w, r, "ListManifests", err)
 		return
 	}
 
-	out := make([]gen.ManifestMetaLocalized, len(repos))
-	for i, m := range repos {
+	if page.NextCursor != "" {
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, nextPageURL(r, page.NextCursor)))
+	}
+	if page.Total != nil {
+		w.Header().Set("X-Total-Count", strconv.FormatInt(*page.Total, 10))
+	}
+
+	out := make([]gen.ManifestMetaLocalized, len(page.Items))
+	locales := make([]string, len(page.Items))
+	for i, m := range page.Items {
+		locale, localization, err := localize(m.Localizations, prefs)
+		if err != nil {
+			h.fail(w, r, "ListManifests: localize", err)
+			return
+		}
+		locales[i] = locale
 		out[i] = gen.ManifestMetaLocalized{
 			Id:       &m.ID,
 			Version:  &m.Version,
@@ -85,13 +440,32 @@ func (h *Handlers) ListManifests(
 			},
 			CreatedAt:     &m.CreatedAt,
 			MetaCreatedAt: &m.MetaCreatedAt,
-			Localization:  m.Localization,
+			Localization:  localization,
+			Locale:        &locales[i],
 		}
 	}
 
+	setContentLanguage(w, locales...)
 	json.NewEncoder(w).Encode(out)
 }
 
+// nextPageURL — тот же запрос с курсором следующей страницы; offset сбрасывается,
+// т.к. курсор уже задаёт позицию
+func nextPageURL(r *http.Request, cursor string) string {
+	q := r.URL.Query()
+	q.Del("offset")
+	q.Set("cursor", cursor)
+	u := url.URL{Path: r.URL.Path, RawQuery: q.Encode()}
+	return u.String()
+}
+
+func toTimePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
 func toStringPtr(ns sql.NullString) *string {
 	if ns.Valid {
 		return &ns.String
@@ -105,28 +479,37 @@ func (h *Handlers) SearchManifests(
 	params gen.SearchManifestsParams,
 ) {
 
-	locale := r.Header.Get("Accept-Language")
-	var parsedLocale string
+	var header string
+	if params.AcceptLanguage != nil {
+		header = *params.AcceptLanguage
+	}
+	prefs := utils.ParseAcceptLanguage(header)
 
-	if locale == "" {
-		parsedLocale = "en"
-	} else {
-		var err error
-		parsedLocale, err = utils.ParsePrimaryLanguage(locale)
-		if err != nil {
-			parsedLocale = "en"
-		}
+	chain, err := h.localeChain(r.Context(), prefs)
+	if err != nil {
+		h.fail(w, r, "GetManifestsSearch: locales", err)
+		return
 	}
 
-	repos, err := h.Svc.SearchManifestsFTS(r.Context(), params.Query, parsedLocale, utils.GetDisplayName(parsedLocale))
+	// словарь FTS подбирается по базовому языку: у Postgres нет конфигураций под регионы
+	base, _ := prefs[0].Base()
+	repos, err := h.Svc.SearchManifestsFTS(r.Context(), params.Query,
+		chain, utils.GetDisplayName(base.String()), clientCapabilities(r))
 	if err != nil {
-		h.Logger.Error().Err(err).Msg("GetManifestsSearch failed")
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		h.fail(w, r, "GetManifestsSearch", err)
 		return
 	}
+	varyCapabilities(w)
 
 	out := make([]gen.ManifestMetaLocalized, len(repos))
+	locales := make([]string, len(repos))
 	for i, m := range repos {
+		locale, localization, err := localize(m.Localizations, prefs)
+		if err != nil {
+			h.fail(w, r, "GetManifestsSearch: localize", err)
+			return
+		}
+		locales[i] = locale
 		out[i] = gen.ManifestMetaLocalized{
 			Id:       &m.ID,
 			Version:  &m.Version,
@@ -139,27 +522,141 @@ func (h *Handlers) SearchManifests(
 			},
 			CreatedAt:     &m.CreatedAt,
 			MetaCreatedAt: &m.MetaCreatedAt,
-			Localization:  m.Localization,
+			Localization:  localization,
+			Locale:        &locales[i],
 		}
 	}
 
+	setContentLanguage(w, locales...)
 	json.NewEncoder(w).Encode(out)
 }
 
+func (h *Handlers) VerifyManifest(w http.ResponseWriter, r *http.Request) {
+	var req gen.SignedManifest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.Logger.Error().Err(err).Msg("verifyManifest: failed to decode")
+		Error(w, r, http.StatusBadRequest, problem.CodeInvalidRequest, "invalid request body")
+		return
+	}
+
+	m := manifestsig.Manifest{
+		Meta: manifestsig.Meta{
+			ID:      req.Meta.Id.String(),
+			Version: req.Meta.Version,
+			Author: manifestsig.Author{
+				Name:  req.Meta.Author.Name,
+				Email: req.Meta.Author.Email,
+			},
+			Category: req.Meta.Category,
+			Icon:     req.Meta.Icon,
+			Tags:     req.Meta.Tags,
+		},
+		UI:          req.Ui,
+		Script:      req.Script,
+		Permissions: req.Permissions,
+		Signature:   req.Signature,
+	}
+	if req.SignatureKid != nil {
+		m.SignatureKid = *req.SignatureKid
+	}
+	if req.SignatureJws != nil {
+		m.SignatureJWS = *req.SignatureJws
+	}
+	if req.CanonicalVersion != nil {
+		m.CanonicalVersion = *req.CanonicalVersion
+	}
+	if req.Meta.CreatedAt != nil {
+		m.Meta.CreatedAt = *req.Meta.CreatedAt
+	}
+	if req.Locale != nil {
+		m.Locale = *req.Locale
+	}
+	if req.Localization != nil {
+		m.Localization = *req.Localization
+	}
+	if req.LocalizationDigests != nil {
+		m.LocalizationDigests = *req.LocalizationDigests
+	}
+	if req.Actions != nil {
+		m.Actions = *req.Actions
+	}
+	if req.AuthorSignature != nil {
+		m.AuthorSignature = &manifestsig.AuthorSignature{
+			Kid:       req.AuthorSignature.Kid,
+			Signature: req.AuthorSignature.Signature,
+		}
+	}
+
+	out := gen.ManifestVerification{Valid: true}
+	switch err := h.Svc.VerifyManifest(r.Context(), m); {
+	case err == nil:
+	case errors.Is(err, manifestsig.ErrNoSignature),
+		errors.Is(err, manifestsig.ErrMalformedSignature),
+		errors.Is(err, manifestsig.ErrMalformedJWS),
+		errors.Is(err, manifestsig.ErrSignatureMismatch),
+		errors.Is(err, manifestsig.ErrUnknownKey),
+		errors.Is(err, manifestsig.ErrLocalizationMismatch):
+		reason := err.Error()
+		out = gen.ManifestVerification{Valid: false, Reason: &reason}
+	default:
+		h.fail(w, r, "verifyManifest", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, out)
+}
+
 func (h *Handlers) GetManifestById(
 	w http.ResponseWriter,
 	r *http.Request,
 	id openapi_types.UUID,
+	params gen.GetManifestByIdParams,
 ) {
-	locale := utils.ParseAcceptLanguageHeader(r.Header.Get("Accept-Language"))
+	repo, err := h.Svc.GetManifestById(r.Context(), id)
+	if err != nil {
+		h.fail(w, r, "GetManifestById", err)
+		return
+	}
 
-	repo, err := h.Svc.GetManifestById(r.Context(), id, locale)
+	out, err := toManifest(repo, utils.ParseAcceptLanguage(r.Header.Get("Accept-Language")))
 	if err != nil {
-		h.Logger.Error().Err(err).Msg("GetManifestById failed")
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		h.fail(w, r, "GetManifestById: build response", err)
 		return
 	}
+	revocation, err := h.Svc.ManifestRevocation(r.Context(), id, repo.Version)
+	if err != nil {
+		h.fail(w, r, "GetManifestById: revocation", err)
+		return
+	}
+	out.Revocation = toRevocation(revocation)
+
+	// несовместимый манифест не скрываем: клиент пришёл по прямой ссылке и должен понять почему
+	varyCapabilities(w)
+	if client := clientCapabilities(r); client.Declared() {
+		reasons, err := h.Svc.Incompatibility(r.Context(), repo, client)
+		if err != nil {
+			h.fail(w, r, "GetManifestById: compatibility", err)
+			return
+		}
+		compatible := len(reasons) == 0
+		out.Compatible = &compatible
+		if !compatible {
+			out.Incompatibility = &reasons
+		}
+	}
+
+	w.Header().Add("Vary", "Accept")
+	if wantsJWS(r, params.SignatureFormat) {
+		out.SignatureJws = toStringPtr(repo.SignatureJws)
+	}
 
+	setContentLanguage(w, *out.Locale)
+	JSON(w, http.StatusOK, out)
+}
+
+// toManifest собирает полный ответ из строки GetManifest, локализуя его под prefs
+func toManifest(repo repository.GetManifestRow, prefs []language.Tag) (gen.Manifest, error) {
 	meta := gen.ManifestMeta{
 		Id: &repo.ID,
 		Author: gen.Author{
@@ -179,26 +676,69 @@ func (h *Handlers) GetManifestById(
 			"code": repo.Script.String,
 		})
 		if err != nil {
-			h.Logger.Error().Err(err).Msg("failed to marshal script object")
-			http.Error(w, "internal error", http.StatusInternalServerError)
-			return
+			return gen.Manifest{}, err
 		}
 		scriptRaw = scriptJSON
 	} else {
 		scriptRaw = nil
 	}
 
-	out := gen.Manifest{
+	locale, localization, err := localize(repo.Localizations.RawMessage, prefs)
+	if err != nil {
+		return gen.Manifest{}, err
+	}
+	digests, err := localizationDigests(repo.CanonicalVersion, repo.Localizations.RawMessage)
+	if err != nil {
+		return gen.Manifest{}, err
+	}
+	canonicalVersion := int(repo.CanonicalVersion)
+	changes, reconsent := toPermissionChanges(repo.PermissionsAdded, repo.PermissionsRemoved)
+
+	return gen.Manifest{
 		Meta:         meta,
-		Localization: repo.Localization.RawMessage,
+		Locale:       &locale,
+		Localization: localization,
 		Ui:           repo.UI.RawMessage,
 		Script:       scriptRaw,
 		Actions:      repo.Actions.RawMessage,
 		Permissions:  repo.Permissions,
 		Signature:    &repo.Signature,
+		SignatureKid: toStringPtr(repo.SignatureKid),
+
+		AuthorSignature:     toAuthorSignature(repo.AuthorKid, repo.AuthorSignature),
+		CanonicalVersion:    &canonicalVersion,
+		LocalizationDigests: digests,
+		Compatibility:       toCompatibility(repo),
+		PermissionChanges:   &changes,
+		RequiresReconsent:   &reconsent,
+	}, nil
+}
+
+// toPermissionChanges — изменение разрешений версии относительно предыдущей; второе значение —
+// нужно ли снова спрашивать согласие: оно нужно, только если разрешения добавились
+func toPermissionChanges(added, removed []string) (gen.PermissionChanges, bool) {
+	if added == nil {
+		added = []string{}
+	}
+	if removed == nil {
+		removed = []string{}
 	}
+	return gen.PermissionChanges{Added: added, Removed: removed}, len(added) > 0
+}
 
-	JSON(w, http.StatusOK, out)
+// toCompatibility — ограничения совместимости; без ограничений поле не отдаётся
+func toCompatibility(repo repository.GetManifestRow) *gen.ManifestCompatibility {
+	if !repo.MinAppVersion.Valid && !repo.MaxAppVersion.Valid && len(repo.Os) == 0 {
+		return nil
+	}
+	c := &gen.ManifestCompatibility{
+		MinAppVersion: toStringPtr(repo.MinAppVersion),
+		MaxAppVersion: toStringPtr(repo.MaxAppVersion),
+	}
+	if len(repo.Os) > 0 {
+		c.Os = &repo.Os
+	}
+	return c
 }
 
 func (h *Handlers) CreateManifest(w http.ResponseWriter, r *http.Request) {
@@ -206,14 +746,13 @@ func (h *Handlers) CreateManifest(w http.ResponseWriter, r *http.Request) {
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.Logger.Error().Err(err).Msg("createManifest: failed to decode")
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+		Error(w, r, http.StatusBadRequest, problem.CodeInvalidRequest, "invalid request body")
 		return
 	}
 
 	id, err := h.Svc.CreateManifest(r.Context(), req)
 	if err != nil {
-		h.Logger.Error().Err(err).Msg("createManifest: service error")
-		http.Error(w, "failed to create manifest", http.StatusInternalServerError)
+		h.fail(w, r, "createManifest", err)
 		return
 	}
 
@@ -225,4 +764,137 @@ func (h *Handlers) CreateManifest(w http.ResponseWriter, r *http.Request) {
 		h.Logger.Error().Err(err).Msg("createManifest: failed to encode response")
 	}
 }
-func (h *Handlers) UpdateManifest(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {}
+
+func (h *Handlers) UpdateManifest(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var req gen.ManifestUpdate
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.Logger.Error().Err(err).Msg("updateManifest: failed to decode")
+		Error(w, r, http.StatusBadRequest, problem.CodeInvalidRequest, "invalid request body")
+		return
+	}
+
+	if err := h.Svc.UpdateManifest(r.Context(), id, req); err != nil {
+		h.fail(w, r, "updateManifest", err)
+		return
+	}
+
+	repo, err := h.Svc.GetManifestById(r.Context(), id)
+	if err != nil {
+		h.fail(w, r, "updateManifest: reload manifest", err)
+		return
+	}
+
+	out, err := toManifest(repo, utils.ParseAcceptLanguage(r.Header.Get("Accept-Language")))
+	if err != nil {
+		h.fail(w, r, "updateManifest: build response", err)
+		return
+	}
+
+	setContentLanguage(w, *out.Locale)
+	JSON(w, http.StatusOK, out)
+}
+
+func (h *Handlers) ListManifestVersions(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	versions, err := h.Svc.ListManifestVersions(r.Context(), id)
+	if err != nil {
+		h.fail(w, r, "ListManifestVersions", err)
+		return
+	}
+
+	out := make([]gen.ManifestVersionSummary, len(versions))
+	for i, v := range versions {
+		canonicalVersion := int(v.CanonicalVersion)
+		changes, reconsent := toPermissionChanges(v.PermissionsAdded, v.PermissionsRemoved)
+		out[i] = gen.ManifestVersionSummary{
+			Version:           v.Version,
+			Signature:         v.Signature,
+			SignatureKid:      toStringPtr(v.SignatureKid),
+			CanonicalVersion:  &canonicalVersion,
+			CreatedAt:         v.CreatedAt,
+			PermissionChanges: changes,
+			RequiresReconsent: reconsent,
+		}
+	}
+
+	JSON(w, http.StatusOK, out)
+}
+
+func (h *Handlers) GetManifestVersion(
+	w http.ResponseWriter,
+	r *http.Request,
+	id openapi_types.UUID,
+	version gen.Version,
+	params gen.GetManifestVersionParams,
+) {
+	repo, err := h.Svc.GetManifestVersion(r.Context(), id, version)
+	if err != nil {
+		h.fail(w, r, "GetManifestVersion", err)
+		return
+	}
+
+	scriptJSON, err := json.Marshal(map[string]string{
+		"code": repo.Script,
+	})
+	if err != nil {
+		h.fail(w, r, "GetManifestVersion: marshal script object", err)
+		return
+	}
+
+	locale, localization, err := localize(repo.Localization, utils.ParseAcceptLanguage(r.Header.Get("Accept-Language")))
+	if err != nil {
+		h.fail(w, r, "GetManifestVersion: localize", err)
+		return
+	}
+	digests, err := localizationDigests(repo.CanonicalVersion, repo.Localization)
+	if err != nil {
+		h.fail(w, r, "GetManifestVersion: localization digests", err)
+		return
+	}
+	canonicalVersion := int(repo.CanonicalVersion)
+	changes, reconsent := toPermissionChanges(repo.PermissionsAdded, repo.PermissionsRemoved)
+	revocation, err := h.Svc.ManifestRevocation(r.Context(), id, version)
+	if err != nil {
+		h.fail(w, r, "GetManifestVersion: revocation", err)
+		return
+	}
+
+	out := gen.Manifest{
+		Meta: gen.ManifestMeta{
+			Id: &repo.ID,
+			Author: gen.Author{
+				Email: repo.AuthorEmail,
+				Name:  repo.AuthorName,
+			},
+			CreatedAt:     &repo.CreatedAt,
+			MetaCreatedAt: &repo.MetaCreatedAt,
+			Version:       &repo.Version,
+			Icon:          &repo.Icon,
+			Category:      &repo.Category,
+			Tags:          &repo.Tags,
+		},
+		Locale:       &locale,
+		Localization: localization,
+		Ui:           repo.Ui,
+		Script:       scriptJSON,
+		Actions:      repo.Actions,
+		Permissions:  repo.Permissions,
+		Signature:    &repo.Signature,
+		SignatureKid: toStringPtr(repo.SignatureKid),
+
+		AuthorSignature:     toAuthorSignature(repo.AuthorKid, repo.AuthorSignature),
+		CanonicalVersion:    &canonicalVersion,
+		LocalizationDigests: digests,
+		Revocation:          toRevocation(revocation),
+		PermissionChanges:   &changes,
+		RequiresReconsent:   &reconsent,
+	}
+
+	w.Header().Add("Vary", "Accept")
+	if wantsJWS(r, params.SignatureFormat) {
+		out.SignatureJws = toStringPtr(repo.SignatureJws)
+	}
+
+	setContentLanguage(w, locale)
+	JSON(w, http.StatusOK, out)
+}
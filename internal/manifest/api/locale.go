@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"golang.org/x/text/language"
+	"pluto-backend/internal/manifest/api/gen"
+	"pluto-backend/internal/platform/utils"
+	"pluto-backend/pkg/manifestsig"
+)
+
+// localeChain — цепочка локалей каталога для SQL: title для сортировки и строки для поиска
+// берутся из той же локали, которую localize выберет для манифеста
+func (h *Handlers) localeChain(ctx context.Context, prefs []language.Tag) ([]string, error) {
+	available, err := h.Svc.Locales(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return utils.LocaleChain(prefs, available), nil
+}
+
+// localize выбирает под Accept-Language локаль из всех локализаций манифеста
+// ({"en": {...}, "pt-BR": {...}}) и собирает строки с откатом по каждому ключу
+func localize(raw []byte, prefs []language.Tag) (string, gen.ManifestLocalization, error) {
+	var all map[string]map[string]string
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &all); err != nil {
+			return "", nil, err
+		}
+	}
+
+	locale, strs := utils.Localize(all, prefs)
+	out, err := json.Marshal(strs)
+	if err != nil {
+		return "", nil, err
+	}
+	return locale, out, nil
+}
+
+// localizationDigests — подписанное дерево хешей всех локалей; у подписей v1
+// локализация не подписана, и дерево не отдаётся
+func localizationDigests(canonicalVersion int16, raw []byte) (*gen.LocalizationDigests, error) {
+	if canonicalVersion < manifestsig.CanonicalV2 {
+		return nil, nil
+	}
+	var all map[string]map[string]string
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &all); err != nil {
+			return nil, err
+		}
+	}
+	digests := gen.LocalizationDigests(manifestsig.DigestLocalization(all))
+	return &digests, nil
+}
+
+// setContentLanguage отдаёт выбранные локали (для списков — уникальные через запятую);
+// ответ зависит от Accept-Language, что важно для кэшей
+func setContentLanguage(w http.ResponseWriter, locales ...string) {
+	w.Header().Add("Vary", "Accept-Language")
+
+	seen := make(map[string]bool, len(locales))
+	uniq := make([]string, 0, len(locales))
+	for _, l := range locales {
+		if l != "" && !seen[l] {
+			seen[l] = true
+			uniq = append(uniq, l)
+		}
+	}
+	if len(uniq) > 0 {
+		w.Header().Set("Content-Language", strings.Join(uniq, ", "))
+	}
+}
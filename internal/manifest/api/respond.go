@@ -2,16 +2,14 @@ package api
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
-)
 
-type ErrorResponse struct {
-	Code    string      `json:"code"`
-	Message string      `json:"message"`
-	Details interface{} `json:"details,omitempty"`
-}
+	"pluto-backend/internal/platform/problem"
+)
 
 // JSON — общая функция для обычного JSON-ответа
 func JSON(w http.ResponseWriter, status int, payload interface{}) {
@@ -30,14 +28,23 @@ func JSON(w http.ResponseWriter, status int, payload interface{}) {
 	buf.WriteTo(w)
 }
 
-// Error — JSON-ошибка в едином формате
-func Error(w http.ResponseWriter, status int, code, message string, details ...interface{}) {
-	resp := ErrorResponse{
-		Code:    code,
-		Message: message,
-	}
-	if len(details) > 0 {
-		resp.Details = details[0]
+// Cached отдаёт готовый JSON-документ с ETag по его байтам и Cache-Control;
+// на совпавший If-None-Match отвечает 304 без тела
+func Cached(w http.ResponseWriter, r *http.Request, cacheControl string, body []byte) {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", cacheControl)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
 	}
-	JSON(w, status, resp)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// Error — ошибка в формате application/problem+json (RFC 7807)
+func Error(w http.ResponseWriter, r *http.Request, status int, code, detail string, errs ...problem.FieldError) {
+	problem.Write(w, r, problem.New(status, code, detail).WithErrors(errs...))
 }
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"pluto-backend/internal/manifest/api/gen"
+)
+
+// wantsJWS — клиент просит detached JWS: ?signatureFormat=jws или
+// Accept: application/json; signature=jws. Явный параметр запроса важнее заголовка
+func wantsJWS(r *http.Request, format *gen.SignatureFormat) bool {
+	if format != nil {
+		return *format == gen.Jws
+	}
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		_, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err == nil && params["signature"] == string(gen.Jws) {
+			return true
+		}
+	}
+	return false
+}
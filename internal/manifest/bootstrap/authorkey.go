@@ -0,0 +1,71 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"pluto-backend/internal/manifest/api/gen"
+	"pluto-backend/internal/manifest/config"
+	"pluto-backend/internal/manifest/service"
+	"pluto-backend/internal/platform/db"
+	"pluto-backend/internal/platform/logger"
+)
+
+// RunAuthorKey одобряет ключ автора. Первый ключ email по HTTP не регистрируется: запрос
+// не аутентифицирован, поэтому владение email проверяет оператор, а затем запускает
+//
+//	manifest-author-key -email dev@example.com -public-key <base64> -proof <base64>
+//
+// proof автор делает сам, как для POST /api/authors/keys. Так же оператор добавляет ключ
+// автору, потерявшему все свои ключи
+func RunAuthorKey(args []string) error {
+	fs := flag.NewFlagSet("manifest-author-key", flag.ContinueOnError)
+	email := fs.String("email", "", "author email, verified by the operator")
+	publicKey := fs.String("public-key", "", "Ed25519 public key, base64")
+	proof := fs.String("proof", "", `signature of "pluto-author-key:<email>:<kid>" by this key, base64`)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg := config.GetConfig()
+	log := logger.New(cfg.Logging.Level)
+
+	if fs.NArg() != 0 || *email == "" || *publicKey == "" || *proof == "" {
+		err := errors.New("expected -email, -public-key and -proof")
+		log.Error().Err(err).Msg("invalid arguments")
+		return err
+	}
+
+	sqlDB, err := db.NewDB(cfg.Database.DSN)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to connect to database")
+		return err
+	}
+	defer sqlDB.Close()
+
+	// регистрация ключа автора ничего не подписывает ключами платформы
+	svc := service.New(sqlDB, nil)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	key, err := svc.ApproveAuthorKey(ctx, gen.AuthorKeyCreate{
+		Email:     *email,
+		PublicKey: *publicKey,
+		Proof:     *proof,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("email", *email).Msg("author key approval failed")
+		return err
+	}
+
+	log.Info().
+		Str("kid", key.Kid).
+		Str("email", key.AuthorEmail).
+		Msg("author key approved")
+	return nil
+}
@@ -1,13 +1,11 @@
 package bootstrap
 
 import (
-	"encoding/base64"
 	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/go-chi/chi/v5"
 	chiMiddleware "github.com/go-chi/chi/v5/middleware"
 	middleware "github.com/oapi-codegen/nethttp-middleware"
 	"github.com/rs/zerolog"
-	"golang.org/x/crypto/ed25519"
 	"pluto-backend/internal/manifest/api"
 	"pluto-backend/internal/manifest/api/gen"
 	"pluto-backend/internal/manifest/config"
@@ -28,18 +26,11 @@ func RunManifestService() error {
 		return logFatalWrap(log, err, "failed to connect to database")
 	}
 
-	privKeyBytes, err := base64.StdEncoding.DecodeString(cfg.Signing.PrivateKeyB64)
+	signer, err := newKeyring(cfg.Signing)
 	if err != nil {
-		return logFatalWrap(log, err, "invalid signing.privateKey")
+		return logFatalWrap(log, err, "invalid signing keys")
 	}
-	pubKeyBytes, err := base64.StdEncoding.DecodeString(cfg.Signing.PublicKeyB64)
-	if err != nil {
-		return logFatalWrap(log, err, "invalid signing.publicKey")
-	}
-	signer := service.NewEd25519Signer(
-		ed25519.PrivateKey(privKeyBytes),
-		ed25519.PublicKey(pubKeyBytes),
-	)
+	log.Info().Str("kid", signer.KeyID()).Int("trusted_keys", len(signer.PublicKeys())).Msg("signing keyring loaded")
 
 	_, err = signer.Sign([]byte("test"))
 	if err != nil {
@@ -47,6 +38,8 @@ func RunManifestService() error {
 	}
 
 	svc := service.New(sqlDB, signer)
+	svc.SetCatalogExpiry(cfg.Catalog.SnapshotTTL, cfg.Catalog.TimestampTTL)
+	svc.SetRevocationExpiry(cfg.Catalog.RevocationTTL)
 
 	impl := api.NewHandlers(svc, log)
 
@@ -61,12 +54,12 @@ func RunManifestService() error {
 	}
 
 	r := chi.NewRouter()
+	r.Use(chiMiddleware.RequestID)
+	r.Use(chiMiddleware.RealIP)
+	r.Use(routerpkg.Recoverer(log))
 	r.Use(routerpkg.RequestLogger(log))
 	r.Use(middleware.OapiRequestValidatorWithOptions(spec, &oapiOpts))
 	r.Use(routermw.JSONContentType)
-	r.Use(chiMiddleware.RequestID)
-	r.Use(chiMiddleware.RealIP)
-	r.Use(chiMiddleware.Recoverer)
 
 	serverOpts := gen.ChiServerOptions{
 		BaseRouter:       r,
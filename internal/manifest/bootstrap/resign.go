@@ -0,0 +1,100 @@
+package bootstrap
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"pluto-backend/internal/manifest/config"
+	"pluto-backend/internal/manifest/service"
+	"pluto-backend/internal/platform/db"
+	"pluto-backend/internal/platform/logger"
+)
+
+// RunResign переподписывает каталог активным ключом связки.
+//
+//	manifest-resign [-dry-run] [-force] [-batch-size N] [-after <manifest_id>@<version>]
+//
+// Прерванный запуск можно просто повторить: уже переподписанные версии пропускаются.
+// -after нужен, чтобы продолжить проверку в режиме -dry-run с последней партии.
+// Версии, прежняя подпись которых не сходится, печатаются и остаются как есть;
+// -force переподписывает и их — только когда старый ключ скомпрометирован
+func RunResign(args []string) error {
+	fs := flag.NewFlagSet("manifest-resign", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "only verify existing signatures, change nothing")
+	force := fs.Bool("force", false, "also re-sign versions whose stored signature does not verify (old key compromised)")
+	batchSize := fs.Int("batch-size", 100, "versions per transaction")
+	after := fs.String("after", "", "resume after this version: <manifest_id>@<version>")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg := config.GetConfig()
+	log := logger.New(cfg.Logging.Level)
+
+	opts := service.ResignOptions{BatchSize: *batchSize, DryRun: *dryRun, Force: *force}
+	if *after != "" {
+		cursor, err := service.ParseResignCursor(*after)
+		if err != nil {
+			log.Error().Err(err).Msg("invalid -after")
+			return err
+		}
+		opts.After = cursor
+	}
+
+	sqlDB, err := db.NewDB(cfg.Database.DSN)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to connect to database")
+		return err
+	}
+	defer sqlDB.Close()
+
+	signer, err := newKeyring(cfg.Signing)
+	if err != nil {
+		log.Error().Err(err).Msg("invalid signing keys")
+		return err
+	}
+
+	svc := service.New(sqlDB, signer)
+
+	reported := 0
+	opts.Progress = func(p service.ResignProgress) {
+		for _, c := range p.Unverified[reported:] {
+			log.Warn().Str("version", c.String()).Bool("resigned", *force && !*dryRun).
+				Msg("stored signature does not verify")
+		}
+		reported = len(p.Unverified)
+
+		log.Info().
+			Int64("processed", p.Processed).
+			Int64("total", p.Total).
+			Int64("valid", p.Valid).
+			Int64("invalid", p.Invalid).
+			Int64("resigned", p.Resigned).
+			Str("cursor", p.Cursor.String()).
+			Msg("batch done")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Info().Str("kid", signer.KeyID()).Bool("dry_run", *dryRun).Bool("force", *force).Msg("re-signing catalog")
+
+	p, err := svc.ResignCatalog(ctx, opts)
+	if err != nil {
+		// партия откатилась целиком, продолжать можно с последнего курсора
+		log.Error().Err(err).Str("resume_after", p.Cursor.String()).Msg("re-signing interrupted")
+		return err
+	}
+
+	log.Info().
+		Int64("processed", p.Processed).
+		Int64("valid", p.Valid).
+		Int64("invalid", p.Invalid).
+		Int64("resigned", p.Resigned).
+		Int64("current", p.Current).
+		Msg("re-signing finished")
+	return nil
+}
@@ -0,0 +1,92 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/google/uuid"
+
+	"pluto-backend/internal/manifest/config"
+	"pluto-backend/internal/manifest/service"
+	"pluto-backend/internal/platform/db"
+	"pluto-backend/internal/platform/logger"
+)
+
+// RunRevoke отзывает манифест или его версию: запись попадает в подписанный
+// список GET /api/revocations, по которому приложение перестаёт запускать плагин.
+//
+//	manifest-revoke -reason "..." <manifest_id>[@<version>]
+//
+// Без версии отзываются все версии манифеста, включая будущие. Отзыв необратим
+func RunRevoke(args []string) error {
+	fs := flag.NewFlagSet("manifest-revoke", flag.ContinueOnError)
+	reason := fs.String("reason", "", "why the manifest is revoked; published in the revocation list")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg := config.GetConfig()
+	log := logger.New(cfg.Logging.Level)
+
+	if fs.NArg() != 1 {
+		err := errors.New("expected exactly one <manifest_id>[@<version>]")
+		log.Error().Err(err).Msg("invalid arguments")
+		return err
+	}
+	id, version, err := parseRevokeTarget(fs.Arg(0))
+	if err != nil {
+		log.Error().Err(err).Msg("invalid arguments")
+		return err
+	}
+
+	sqlDB, err := db.NewDB(cfg.Database.DSN)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to connect to database")
+		return err
+	}
+	defer sqlDB.Close()
+
+	signer, err := newKeyring(cfg.Signing)
+	if err != nil {
+		log.Error().Err(err).Msg("invalid signing keys")
+		return err
+	}
+
+	svc := service.New(sqlDB, signer)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	rev, err := svc.RevokeManifest(ctx, id, version, *reason)
+	if err != nil {
+		log.Error().Err(err).Str("manifest_id", id.String()).Str("version", version).Msg("revocation failed")
+		return err
+	}
+
+	log.Info().
+		Int64("sequence", rev.Sequence).
+		Str("manifest_id", rev.ManifestID.String()).
+		Str("version", rev.Version.String).
+		Str("reason", rev.Reason).
+		Msg("manifest revoked")
+	return nil
+}
+
+// parseRevokeTarget разбирает "<manifest_id>[@<version>]"
+func parseRevokeTarget(s string) (uuid.UUID, string, error) {
+	id, version, hasVersion := strings.Cut(s, "@")
+	manifestID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, "", fmt.Errorf("target %q: %w", s, err)
+	}
+	if hasVersion && version == "" {
+		return uuid.Nil, "", fmt.Errorf("target %q: empty version", s)
+	}
+	return manifestID, version, nil
+}
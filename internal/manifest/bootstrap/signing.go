@@ -0,0 +1,158 @@
+package bootstrap
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"pluto-backend/internal/manifest/config"
+	"pluto-backend/internal/manifest/service"
+	"pluto-backend/internal/platform/keystore"
+	"pluto-backend/internal/platform/remotesign"
+)
+
+// remoteStartupTimeout — сколько ждать сервис подписи при старте, включая повторы
+const remoteStartupTimeout = 30 * time.Second
+
+var errNoSigningKey = errors.New("signing: no key configured: set signing.keystore.path or signing.remote.endpoint")
+
+// newKeyring собирает связку ключей из конфига; старый формат с одной парой
+// превращается в связку из одного активного ключа. С signing.remote подписывает
+// внешний сервис, а ключи из конфига остаются для проверки; с signing.keystore
+// ключи расшифровываются из хранилища. Открытая пара private/public_key_b64 —
+// переопределение для разработки и важнее хранилища
+func newKeyring(cfg config.SigningConfig) (*service.Keyring, error) {
+	if cfg.Remote.Endpoint != "" {
+		return newRemoteKeyring(cfg)
+	}
+	if cfg.Keystore.Path != "" && cfg.PrivateKeyB64 == "" {
+		return newKeystoreKeyring(cfg)
+	}
+
+	keys := cfg.Keys
+	if len(keys) == 0 {
+		if cfg.PrivateKeyB64 == "" {
+			return nil, errNoSigningKey
+		}
+		keys = []config.SigningKeyConfig{{
+			PrivateKeyB64: cfg.PrivateKeyB64,
+			PublicKeyB64:  cfg.PublicKeyB64,
+		}}
+	}
+
+	ring, err := keyringKeys(keys)
+	if err != nil {
+		return nil, err
+	}
+	return service.NewKeyring(ring, cfg.ActiveKid)
+}
+
+func newRemoteKeyring(cfg config.SigningConfig) (*service.Keyring, error) {
+	client, err := remotesign.NewClient(remotesign.Config{
+		Endpoint: cfg.Remote.Endpoint,
+		Token:    cfg.Remote.Token,
+		Timeout:  cfg.Remote.Timeout,
+		Retries:  cfg.Remote.Retries,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), remoteStartupTimeout)
+	defer cancel()
+	remote, err := service.NewRemoteSigner(ctx, client, cfg.Remote.Key)
+	if err != nil {
+		return nil, fmt.Errorf("signing.remote: %w", err)
+	}
+
+	ring, err := keyringKeys(cfg.Keys)
+	if err != nil {
+		return nil, err
+	}
+	return service.NewRemoteKeyring(remote, ring)
+}
+
+func newKeystoreKeyring(cfg config.SigningConfig) (*service.Keyring, error) {
+	ks, err := keystore.Load(cfg.Keystore.Path, cfg.Keystore.PassphraseFile)
+	if err != nil {
+		return nil, fmt.Errorf("signing.keystore: %w", err)
+	}
+	if cfg.Keystore.Key != "" {
+		if _, err := ks.Get(cfg.Keystore.Key); err != nil {
+			return nil, fmt.Errorf("signing.keystore: %w", err)
+		}
+	}
+
+	var ring []service.KeyringKey
+	for _, k := range ks.Keys {
+		if k.Algorithm != keystore.AlgEd25519 {
+			continue
+		}
+		priv, err := k.Ed25519()
+		if err != nil {
+			return nil, fmt.Errorf("signing.keystore: %w", err)
+		}
+		key := service.KeyringKey{
+			Public:    priv.Public().(ed25519.PublicKey),
+			NotBefore: k.NotBefore,
+			NotAfter:  k.NotAfter,
+		}
+		if cfg.Keystore.Key == "" || cfg.Keystore.Key == k.ID {
+			key.Private = priv
+		}
+		ring = append(ring, key)
+	}
+
+	extra, err := keyringKeys(cfg.Keys)
+	if err != nil {
+		return nil, err
+	}
+	return service.NewKeyring(append(ring, extra...), cfg.ActiveKid)
+}
+
+func keyringKeys(keys []config.SigningKeyConfig) ([]service.KeyringKey, error) {
+	ring := make([]service.KeyringKey, 0, len(keys))
+	for i, k := range keys {
+		var key service.KeyringKey
+
+		pub, err := base64.StdEncoding.DecodeString(k.PublicKeyB64)
+		if err != nil {
+			return nil, fmt.Errorf("signing.keys[%d]: invalid public_key_b64: %w", i, err)
+		}
+		if len(pub) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("signing.keys[%d]: public_key_b64 must be %d bytes, got %d", i, ed25519.PublicKeySize, len(pub))
+		}
+		key.Public = ed25519.PublicKey(pub)
+
+		if k.PrivateKeyB64 != "" {
+			priv, err := base64.StdEncoding.DecodeString(k.PrivateKeyB64)
+			if err != nil {
+				return nil, fmt.Errorf("signing.keys[%d]: invalid private_key_b64: %w", i, err)
+			}
+			if len(priv) != ed25519.PrivateKeySize {
+				return nil, fmt.Errorf("signing.keys[%d]: private_key_b64 must be %d bytes, got %d", i, ed25519.PrivateKeySize, len(priv))
+			}
+			key.Private = ed25519.PrivateKey(priv)
+		}
+
+		if key.NotBefore, err = parseKeyTime(k.NotBefore); err != nil {
+			return nil, fmt.Errorf("signing.keys[%d]: invalid not_before: %w", i, err)
+		}
+		if key.NotAfter, err = parseKeyTime(k.NotAfter); err != nil {
+			return nil, fmt.Errorf("signing.keys[%d]: invalid not_after: %w", i, err)
+		}
+
+		ring = append(ring, key)
+	}
+	return ring, nil
+}
+
+func parseKeyTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
@@ -0,0 +1,92 @@
+// Package canonical — подписываемые представления манифеста, собранные из тела запроса API.
+// Им пользуются и manifest-service при подписи, и cmd/pluto-manifest при подписи автором,
+// поэтому байты payload у них совпадают. Сам формат живёт в pkg/manifestsig
+package canonical
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"pluto-backend/internal/manifest/api/gen"
+	"pluto-backend/pkg/manifestsig"
+)
+
+// Current — схема, в которой подписываются новые версии; v1 только проверяется
+const Current = manifestsig.CanonicalV2
+
+// InitialVersion — версия, с которой публикуется новый манифест
+const InitialVersion = "1.0.0"
+
+// Payload формирует компактный отсортированный JSON в схеме canonicalVersion
+func Payload(
+	canonicalVersion int,
+	id uuid.UUID,
+	version string,
+	createdAt time.Time,
+	req gen.ManifestCreate,
+	scriptCode string,
+) ([]byte, error) {
+	m, err := Manifest(canonicalVersion, id, version, createdAt, req, scriptCode)
+	if err != nil {
+		return nil, err
+	}
+	// подпись автора входит в payload платформы — это и есть контрподпись
+	if req.AuthorSignature != nil {
+		m.AuthorSignature = &manifestsig.AuthorSignature{
+			Kid:       req.AuthorSignature.Kid,
+			Signature: req.AuthorSignature.Signature,
+		}
+	}
+	return manifestsig.Canonical(m)
+}
+
+// AuthorPayload — payload, который автор подписывает до публикации: без id и createdAt.
+// Совместимость сюда не входит: это параметры доставки, и они меняются без подписи автора
+func AuthorPayload(version string, req gen.ManifestCreate, scriptCode string) ([]byte, error) {
+	m, err := Manifest(Current, uuid.Nil, version, time.Time{}, req, scriptCode)
+	if err != nil {
+		return nil, err
+	}
+	return manifestsig.AuthorCanonical(m)
+}
+
+// Manifest — подписываемые поля запроса в форме manifestsig
+func Manifest(
+	canonicalVersion int,
+	id uuid.UUID,
+	version string,
+	createdAt time.Time,
+	req gen.ManifestCreate,
+	scriptCode string,
+) (manifestsig.Manifest, error) {
+	script, err := json.Marshal(map[string]string{"code": scriptCode})
+	if err != nil {
+		return manifestsig.Manifest{}, err
+	}
+	actions, err := json.Marshal(req.Actions)
+	if err != nil {
+		return manifestsig.Manifest{}, err
+	}
+
+	return manifestsig.Manifest{
+		CanonicalVersion: canonicalVersion,
+		Meta: manifestsig.Meta{
+			ID:      id.String(),
+			Version: version,
+			Author: manifestsig.Author{
+				Name:  req.Author.Name,
+				Email: req.Author.Email,
+			},
+			Category:  req.Category,
+			Icon:      req.Icon,
+			Tags:      req.Tags, // порядок не меняем
+			CreatedAt: createdAt,
+		},
+		UI:                  req.Ui, // raw-embed
+		Script:              script,
+		Actions:             actions,
+		Permissions:         req.Permissions, // порядок не меняем
+		LocalizationDigests: manifestsig.DigestLocalization(req.Localization),
+	}, nil
+}
@@ -0,0 +1,78 @@
+package canonical
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"pluto-backend/internal/manifest/api/gen"
+)
+
+// Те же эталоны, что в pkg/manifestsig: payload из тела запроса обязан совпадать
+// байт в байт с тем, что клиент соберёт из ответа API
+const (
+	vectorV1 = `{"actions":[],"meta":{"author":{"email":"ada@example.com","name":"Ada"},"category":"tools","icon":"star","id":"6f1c2a4e-8d3b-4f7a-9c1e-2b5d7a9e0f13","tags":["weather","daily"],"version":"1.2.0"},"permissions":["network","location"],"script":{"code":"main()"},"ui":{"type":"list"}}`
+
+	vectorV2 = `{"actions":[],"canonicalVersion":2,"localization":{"en":{"subtitle":"b65ecc9f4f5be72ff552e3c2f62ea434749689b87769789876e29954d9415cc8","title":"a0bba638124664064545edb48049c05385d1542084b2b79bb6ec0cfea6d636c9"},"pt":{"title":"214b5b846cf3925f87ea67310e74493e27d226b85e43eaa96c0a75a7a57b9c63"}},"meta":{"author":{"email":"ada@example.com","name":"Ada"},"category":"tools","createdAt":"2025-03-14T15:09:26Z","icon":"star","id":"6f1c2a4e-8d3b-4f7a-9c1e-2b5d7a9e0f13","tags":["weather","daily"],"version":"1.2.0"},"permissions":["network","location"],"script":{"code":"main()"},"ui":{"type":"list"}}`
+
+	vectorV2Countersigned = `{"actions":[],"authorSignature":{"kid":"author-1","signature":"c2ln"},"canonicalVersion":2,"localization":{"en":{"subtitle":"b65ecc9f4f5be72ff552e3c2f62ea434749689b87769789876e29954d9415cc8","title":"a0bba638124664064545edb48049c05385d1542084b2b79bb6ec0cfea6d636c9"},"pt":{"title":"214b5b846cf3925f87ea67310e74493e27d226b85e43eaa96c0a75a7a57b9c63"}},"meta":{"author":{"email":"ada@example.com","name":"Ada"},"category":"tools","createdAt":"2025-03-14T15:09:26Z","icon":"star","id":"6f1c2a4e-8d3b-4f7a-9c1e-2b5d7a9e0f13","tags":["weather","daily"],"version":"1.2.0"},"permissions":["network","location"],"script":{"code":"main()"},"ui":{"type":"list"}}`
+
+	vectorAuthor = `{"actions":[],"canonicalVersion":2,"localization":{"en":{"subtitle":"b65ecc9f4f5be72ff552e3c2f62ea434749689b87769789876e29954d9415cc8","title":"a0bba638124664064545edb48049c05385d1542084b2b79bb6ec0cfea6d636c9"},"pt":{"title":"214b5b846cf3925f87ea67310e74493e27d226b85e43eaa96c0a75a7a57b9c63"}},"meta":{"author":{"email":"ada@example.com","name":"Ada"},"category":"tools","icon":"star","tags":["weather","daily"],"version":"1.2.0"},"permissions":["network","location"],"script":{"code":"main()"},"ui":{"type":"list"}}`
+)
+
+func testRequest() gen.ManifestCreate {
+	actions := []gen.ManifestActionBase{}
+	return gen.ManifestCreate{
+		Actions:  &actions,
+		Author:   gen.Author{Name: "Ada", Email: "ada@example.com"},
+		Category: "tools",
+		Icon:     "star",
+		Localization: gen.ManifestLocalizationCreate{
+			"en": {"title": "Weather", "subtitle": "Daily forecast"},
+			"pt": {"title": "Tempo"},
+		},
+		Permissions: []string{"network", "location"},
+		Script:      json.RawMessage(`{"code":"ignored: the stored code is passed separately"}`),
+		Tags:        []string{"weather", "daily"},
+		Ui:          json.RawMessage(`{"type":"list"}`),
+	}
+}
+
+func TestPayloadVectors(t *testing.T) {
+	id := uuid.MustParse("6f1c2a4e-8d3b-4f7a-9c1e-2b5d7a9e0f13")
+	createdAt := time.Date(2025, 3, 14, 18, 9, 26, 0, time.FixedZone("MSK", 3*60*60))
+
+	countersigned := testRequest()
+	countersigned.AuthorSignature = &gen.AuthorSignature{Kid: "author-1", Signature: "c2ln"}
+
+	tests := []struct {
+		name    string
+		version int
+		req     gen.ManifestCreate
+		want    string
+	}{
+		{name: "v1", version: 1, req: testRequest(), want: vectorV1},
+		{name: "v2", version: Current, req: testRequest(), want: vectorV2},
+		{name: "v2 countersigned", version: Current, req: countersigned, want: vectorV2Countersigned},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Payload(tt.version, id, "1.2.0", createdAt, tt.req, "main()")
+			if err != nil {
+				t.Fatalf("Payload: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Payload =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+
+	got, err := AuthorPayload("1.2.0", countersigned, "main()")
+	if err != nil {
+		t.Fatalf("AuthorPayload: %v", err)
+	}
+	if string(got) != vectorAuthor {
+		t.Errorf("AuthorPayload =\n%s\nwant\n%s", got, vectorAuthor)
+	}
+}
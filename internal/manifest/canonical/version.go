@@ -0,0 +1,74 @@
+package canonical
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"pluto-backend/internal/manifest/api/gen"
+)
+
+// BumpVersion поднимает нужную часть версии вида MAJOR.MINOR.PATCH
+func BumpVersion(version string, bump gen.ManifestUpdateBump) (string, error) {
+	nums, err := parseVersion(version)
+	if err != nil {
+		return "", err
+	}
+
+	switch bump {
+	case gen.Major:
+		nums[0], nums[1], nums[2] = nums[0]+1, 0, 0
+	case gen.Minor:
+		nums[1], nums[2] = nums[1]+1, 0
+	case gen.Patch:
+		nums[2]++
+	default:
+		return "", fmt.Errorf("unknown bump %q", bump)
+	}
+
+	return fmt.Sprintf("%d.%d.%d", nums[0], nums[1], nums[2]), nil
+}
+
+// CompareVersions сравнивает версии вида MAJOR.MINOR.PATCH: -1, 0 или 1
+func CompareVersions(a, b string) (int, error) {
+	x, err := parseVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	y, err := parseVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	for i := range x {
+		switch {
+		case x[i] < y[i]:
+			return -1, nil
+		case x[i] > y[i]:
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// ValidVersion — строка вида MAJOR.MINOR.PATCH
+func ValidVersion(version string) bool {
+	_, err := parseVersion(version)
+	return err == nil
+}
+
+func parseVersion(version string) ([]int, error) {
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid version %q", version)
+	}
+
+	// только цифры и не больше 9 знаков: та же версия сравнивается в Postgres как int[]
+	nums := make([]int, 3)
+	for i, p := range parts {
+		if p == "" || len(p) > 9 || strings.Trim(p, "0123456789") != "" {
+			return nil, fmt.Errorf("invalid version %q", version)
+		}
+		nums[i], _ = strconv.Atoi(p)
+	}
+	return nums, nil
+}
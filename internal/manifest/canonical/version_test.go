@@ -0,0 +1,73 @@
+package canonical
+
+import (
+	"testing"
+
+	"pluto-backend/internal/manifest/api/gen"
+)
+
+func TestBumpVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		bump    gen.ManifestUpdateBump
+		want    string
+		wantErr bool
+	}{
+		{name: "patch", version: "1.2.3", bump: gen.Patch, want: "1.2.4"},
+		{name: "minor resets patch", version: "1.2.3", bump: gen.Minor, want: "1.3.0"},
+		{name: "major resets minor and patch", version: "1.2.3", bump: gen.Major, want: "2.0.0"},
+		{name: "no carry past nine", version: "0.9.9", bump: gen.Patch, want: "0.9.10"},
+		{name: "leading zeros dropped", version: "01.002.0003", bump: gen.Patch, want: "1.2.4"},
+		{name: "unknown bump", version: "1.0.0", bump: "huge", wantErr: true},
+		{name: "two parts", version: "1.0", bump: gen.Patch, wantErr: true},
+		{name: "four parts", version: "1.0.0.0", bump: gen.Patch, wantErr: true},
+		{name: "empty part", version: "1..0", bump: gen.Patch, wantErr: true},
+		{name: "prerelease", version: "1.0.0-beta", bump: gen.Patch, wantErr: true},
+		{name: "sign", version: "+1.0.0", bump: gen.Patch, wantErr: true},
+		{name: "too long for int[]", version: "1234567890.0.0", bump: gen.Patch, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BumpVersion(tt.version, tt.bump)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("BumpVersion(%q, %q) = %q, want error", tt.version, tt.bump, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BumpVersion(%q, %q): %v", tt.version, tt.bump, err)
+			}
+			if got != tt.want {
+				t.Errorf("BumpVersion(%q, %q) = %q, want %q", tt.version, tt.bump, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"1.0.0", "1.0.1", -1},
+		{"1.10.0", "1.9.0", 1},
+		{"2.0.0", "10.0.0", -1},
+		{"01.0.0", "1.0.0", 0},
+	}
+	for _, tt := range tests {
+		got, err := CompareVersions(tt.a, tt.b)
+		if err != nil {
+			t.Fatalf("CompareVersions(%q, %q): %v", tt.a, tt.b, err)
+		}
+		if got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+
+	if _, err := CompareVersions("1.0", "1.0.0"); err == nil {
+		t.Error("CompareVersions accepted an invalid version")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/spf13/viper"
 	"strings"
 	"sync"
+	"time"
 )
 
 type Config struct {
@@ -12,6 +13,7 @@ type Config struct {
 	Logging  LoggingConfig  `mapstructure:"logging"`
 	TLS      TLSConfig      `mapstructure:"tls"`
 	Signing  SigningConfig  `mapstructure:"signing"`
+	Catalog  CatalogConfig  `mapstructure:"catalog"`
 }
 
 type TLSConfig struct {
@@ -31,9 +33,54 @@ type LoggingConfig struct {
 	Level string `mapstructure:"level"`
 }
 
+// SigningConfig — связка ключей подписи манифестов. Одиночная пара private/public_key_b64
+// поддерживается для старых конфигов и используется, только если keys пуст; в репозитории
+// она не хранится и задаётся только для разработки (PLUTO_SIGNING_PRIVATE_KEY_B64,
+// PLUTO_SIGNING_PUBLIC_KEY_B64) — тогда она важнее keystore.path.
+// С remote.endpoint подписывает внешний сервис, а keys только проверяют старые подписи.
+// С keystore.path ключи берутся из зашифрованного хранилища, а keys добавляются к ним
 type SigningConfig struct {
+	PrivateKeyB64 string             `mapstructure:"private_key_b64"`
+	PublicKeyB64  string             `mapstructure:"public_key_b64"`
+	ActiveKid     string             `mapstructure:"active_kid"`
+	Keys          []SigningKeyConfig `mapstructure:"keys"`
+	Remote        RemoteSignerConfig `mapstructure:"remote"`
+	Keystore      KeystoreConfig     `mapstructure:"keystore"`
+}
+
+// KeystoreConfig — ключи Ed25519 из зашифрованного хранилища (internal/platform/keystore).
+// Пароль берётся из PLUTO_KEYSTORE_PASSPHRASE, иначе из passphrase_file. Приватная часть
+// остаётся только у ключа key; пустой key — у всех, и активный выбирает active_kid
+type KeystoreConfig struct {
+	Path           string `mapstructure:"path"`
+	PassphraseFile string `mapstructure:"passphrase_file"`
+	Key            string `mapstructure:"key"` // id ключа в хранилище
+}
+
+// RemoteSignerConfig — внешний сервис подписи (cmd/pluto-signer); пустой endpoint — подписываем сами
+type RemoteSignerConfig struct {
+	Endpoint string        `mapstructure:"endpoint"` // unix:///path/to.sock или http://host:port
+	Key      string        `mapstructure:"key"`
+	Token    string        `mapstructure:"token"`
+	Timeout  time.Duration `mapstructure:"timeout"` // на попытку
+	Retries  int           `mapstructure:"retries"`
+}
+
+// SigningKeyConfig — ключ связки; у выведенных из ротации ключей private_key_b64 не задаётся,
+// а not_after ограничивает, до какого момента им ещё доверяют
+type SigningKeyConfig struct {
 	PrivateKeyB64 string `mapstructure:"private_key_b64"`
 	PublicKeyB64  string `mapstructure:"public_key_b64"`
+	NotBefore     string `mapstructure:"not_before"` // RFC 3339
+	NotAfter      string `mapstructure:"not_after"`  // RFC 3339
+}
+
+// CatalogConfig — сроки действия подписанных snapshot, timestamp и списка отзыва;
+// пустые значения — 168h, 1h и 24h
+type CatalogConfig struct {
+	SnapshotTTL   time.Duration `mapstructure:"snapshot_ttl"`
+	TimestampTTL  time.Duration `mapstructure:"timestamp_ttl"`
+	RevocationTTL time.Duration `mapstructure:"revocation_ttl"`
 }
 
 func loadConfig(path string) Config {
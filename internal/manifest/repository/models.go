@@ -5,23 +5,45 @@
 package repository
 
 import (
+	"database/sql"
 	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type AuthorKey struct {
+	Kid         string
+	AuthorEmail string
+	PublicKey   []byte
+	CreatedAt   time.Time
+}
+
+type CatalogState struct {
+	Singleton bool
+	Version   int64
+	UpdatedAt time.Time
+}
+
 type Manifest struct {
-	ID            uuid.UUID
-	Version       string
-	Icon          string
-	Category      string
-	Tags          []string
-	AuthorName    string
-	AuthorEmail   string
-	CreatedAt     time.Time
-	MetaCreatedAt time.Time
-	Signature     string
+	ID               uuid.UUID
+	Version          string
+	Icon             string
+	Category         string
+	Tags             []string
+	AuthorName       string
+	AuthorEmail      string
+	CreatedAt        time.Time
+	MetaCreatedAt    time.Time
+	Signature        string
+	SignatureKid     sql.NullString
+	CanonicalVersion int16
+	AuthorKid        sql.NullString
+	AuthorSignature  sql.NullString
+	MinAppVersion    sql.NullString
+	MaxAppVersion    sql.NullString
+	Os               []string
+	ComponentTypes   []string
 }
 
 type ManifestContent struct {
@@ -38,3 +60,67 @@ type ManifestLocalization struct {
 	Key        string
 	Value      string
 }
+
+type ManifestVersion struct {
+	ManifestID         uuid.UUID
+	Version            string
+	Icon               string
+	Category           string
+	Tags               []string
+	AuthorName         string
+	AuthorEmail        string
+	Ui                 json.RawMessage
+	Script             string
+	Actions            json.RawMessage
+	Permissions        []string
+	Localization       json.RawMessage
+	Signature          string
+	CreatedAt          time.Time
+	SignatureKid       sql.NullString
+	SignatureJws       sql.NullString
+	CanonicalVersion   int16
+	AuthorKid          sql.NullString
+	AuthorSignature    sql.NullString
+	PermissionsAdded   []string
+	PermissionsRemoved []string
+}
+
+type Permission struct {
+	Name           string
+	Risk           string
+	RequiresReview bool
+	CreatedAt      time.Time
+}
+
+type PermissionLocalization struct {
+	Permission  string
+	Locale      string
+	Title       string
+	Description string
+}
+
+type Revocation struct {
+	Sequence   int64
+	ManifestID uuid.UUID
+	Version    sql.NullString
+	Reason     string
+	RevokedAt  time.Time
+}
+
+type TransparencyLog struct {
+	LeafIndex    int64
+	LeafHash     []byte
+	LeafData     []byte
+	ManifestID   uuid.UUID
+	Version      string
+	SignatureKid sql.NullString
+	CreatedAt    time.Time
+}
+
+type UiComponent struct {
+	Type             string
+	PropsSchema      json.RawMessage
+	MinClientVersion string
+	Description      string
+	UpdatedAt        time.Time
+}
@@ -11,13 +11,50 @@ import (
 )
 
 type Querier interface {
+	AppendLogEntry(ctx context.Context, arg AppendLogEntryParams) (int64, error)
+	CountManifests(ctx context.Context, arg CountManifestsParams) (int64, error)
+	CountVersionsForResign(ctx context.Context, arg CountVersionsForResignParams) (int64, error)
+	CreateAuthorKey(ctx context.Context, arg CreateAuthorKeyParams) (AuthorKey, error)
 	CreateLocalizations(ctx context.Context, arg CreateLocalizationsParams) error
 	CreateManifest(ctx context.Context, arg CreateManifestParams) (uuid.UUID, error)
 	CreateManifestContent(ctx context.Context, arg CreateManifestContentParams) error
-	GetManifest(ctx context.Context, arg GetManifestParams) (GetManifestRow, error)
+	CreateManifestVersion(ctx context.Context, arg CreateManifestVersionParams) error
+	CreateRevocation(ctx context.Context, arg CreateRevocationParams) (Revocation, error)
+	DeleteLocalizations(ctx context.Context, manifestID uuid.UUID) error
+	GetAuthorKey(ctx context.Context, kid string) (AuthorKey, error)
+	GetCatalogVersion(ctx context.Context) (int64, error)
+	GetLogLeafIndex(ctx context.Context, leafHash []byte) (int64, error)
+	GetManifest(ctx context.Context, manifestID uuid.UUID) (GetManifestRow, error)
+	GetManifestForUpdate(ctx context.Context, manifestID uuid.UUID) (GetManifestForUpdateRow, error)
+	GetManifestRevocation(ctx context.Context, arg GetManifestRevocationParams) (Revocation, error)
+	GetManifestVersion(ctx context.Context, arg GetManifestVersionParams) (GetManifestVersionRow, error)
+	GetRevocationSequence(ctx context.Context) (int64, error)
+	ListAuthorKeys(ctx context.Context, authorEmail string) ([]AuthorKey, error)
+	ListCatalogManifests(ctx context.Context) ([]ListCatalogManifestsRow, error)
+	ListLocales(ctx context.Context) ([]string, error)
+	ListLocalizations(ctx context.Context, manifestID uuid.UUID) ([]ListLocalizationsRow, error)
+	ListLogEntries(ctx context.Context, arg ListLogEntriesParams) ([]ListLogEntriesRow, error)
+	ListLogLeafHashes(ctx context.Context, fromIndex int64) ([]ListLogLeafHashesRow, error)
+	ListManifestVersions(ctx context.Context, manifestID uuid.UUID) ([]ListManifestVersionsRow, error)
 	ListManifests(ctx context.Context, arg ListManifestsParams) ([]ListManifestsRow, error)
-	SearchManifests(ctx context.Context, arg SearchManifestsParams) ([]SearchManifestsRow, error)
+	ListManifestsByTitle(ctx context.Context, arg ListManifestsByTitleParams) ([]ListManifestsByTitleRow, error)
+	ListManifestsByTitleOffset(ctx context.Context, arg ListManifestsByTitleOffsetParams) ([]ListManifestsByTitleOffsetRow, error)
+	ListManifestsOffset(ctx context.Context, arg ListManifestsOffsetParams) ([]ListManifestsOffsetRow, error)
+	ListManifestsOldest(ctx context.Context, arg ListManifestsOldestParams) ([]ListManifestsOldestRow, error)
+	ListManifestsOldestOffset(ctx context.Context, arg ListManifestsOldestOffsetParams) ([]ListManifestsOldestOffsetRow, error)
+	ListPermissions(ctx context.Context) ([]ListPermissionsRow, error)
+	ListRevocations(ctx context.Context) ([]Revocation, error)
+	ListUiComponents(ctx context.Context) ([]UiComponent, error)
+	ListVersionsForResign(ctx context.Context, arg ListVersionsForResignParams) ([]ListVersionsForResignRow, error)
+	LockRevocations(ctx context.Context) error
+	LockTransparencyLog(ctx context.Context) error
 	SearchManifestsFTS(ctx context.Context, arg SearchManifestsFTSParams) ([]SearchManifestsFTSRow, error)
+	SetManifestCompatibility(ctx context.Context, arg SetManifestCompatibilityParams) error
+	SetManifestComponentTypes(ctx context.Context, arg SetManifestComponentTypesParams) error
+	UpdateManifest(ctx context.Context, arg UpdateManifestParams) error
+	UpdateManifestContent(ctx context.Context, arg UpdateManifestContentParams) error
+	UpdateManifestSignature(ctx context.Context, arg UpdateManifestSignatureParams) (int64, error)
+	UpdateVersionSignature(ctx context.Context, arg UpdateVersionSignatureParams) error
 }
 
 var _ Querier = (*Queries)(nil)
@@ -1,4 +1,6 @@
 -- name: ListManifests :many
+-- сортировка newest: ORDER BY совпадает с idx_manifest_created_at_id, страница
+-- читается по индексу и останавливается на LIMIT
 SELECT m.id,
        m.version,
        m.icon,
@@ -8,17 +10,159 @@ SELECT m.id,
        m.author_email,
        m.created_at,
        m.meta_created_at,
-       -- 🔽 отдаём **всю** локализацию для запрошенной локали
-       (SELECT jsonb_object_agg(l.key, l.value)
-        FROM manifest_localizations AS l
-        WHERE l.manifest_id = m.id
-          AND l.locale = $3::text) AS localization
+       -- 🔽 отдаём локализации **всех** локалей: выбор и откат по ключам делает сервис
+       (SELECT jsonb_object_agg(x.locale, x.strings)
+        FROM (SELECT l.locale, jsonb_object_agg(l.key, l.value) AS strings
+              FROM manifest_localizations AS l
+              WHERE l.manifest_id = m.id
+              GROUP BY l.locale) AS x) AS localizations,
+       -- ключ title нужен только сортировке по title
+       ''::text AS sort_title
 FROM manifest AS m
-ORDER BY m.created_at DESC
-LIMIT $1 OFFSET $2;
+         JOIN manifest_content AS c ON c.manifest_id = m.id
+WHERE (sqlc.narg(category)::text IS NULL OR m.category = sqlc.narg(category)::text)
+  AND (sqlc.narg(tags_any)::text[] IS NULL OR m.tags && sqlc.narg(tags_any)::text[])
+  AND (sqlc.narg(tags_all)::text[] IS NULL OR m.tags @> sqlc.narg(tags_all)::text[])
+  AND (sqlc.narg(exclude_permissions)::text[] IS NULL
+    OR NOT c.permissions && sqlc.narg(exclude_permissions)::text[])
+  AND (sqlc.narg(author)::text IS NULL
+    OR lower(m.author_name) = lower(sqlc.narg(author)::text)
+    OR lower(m.author_email) = lower(sqlc.narg(author)::text))
+  AND (sqlc.narg(created_after)::timestamptz IS NULL OR m.created_at >= sqlc.narg(created_after)::timestamptz)
+  AND (sqlc.narg(created_before)::timestamptz IS NULL OR m.created_at < sqlc.narg(created_before)::timestamptz)
+  -- несовместимые с заявленными возможностями клиента манифесты не показываем
+  AND manifest_compatible(m.id,
+                          sqlc.narg(client_app_version)::text,
+                          sqlc.narg(client_os)::text,
+                          sqlc.narg(client_components)::text[],
+                          sqlc.narg(client_permissions)::text[])
+  -- keyset: строки строго «после» курсора
+  AND (sqlc.narg(cursor_id)::uuid IS NULL
+    OR (m.created_at, m.id) < (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::uuid))
+ORDER BY m.created_at DESC, m.id DESC
+LIMIT sqlc.arg('limit');
 
+-- name: ListManifestsByTitle :many
+-- сортировка title: ключ зависит от Accept-Language и в индексе не хранится, поэтому
+-- страница — top-N сортировка отфильтрованных строк; заголовки по цепочке локалей
+-- читаются из idx_manifest_localizations_title без обращения к таблице
+WITH listed AS (SELECT m.id,
+                       m.version,
+                       m.icon,
+                       m.category,
+                       m.tags,
+                       m.author_name,
+                       m.author_email,
+                       m.created_at,
+                       m.meta_created_at,
+                       -- ключ сортировки title: первый заголовок по цепочке отката локалей
+                       coalesce((SELECT l.value
+                                 FROM manifest_localizations AS l
+                                 WHERE l.manifest_id = m.id
+                                   AND l.key = 'title'
+                                   AND l.locale = ANY (@locales::text[])
+                                 ORDER BY array_position(@locales::text[], l.locale)
+                                 LIMIT 1), '')::text AS sort_title
+                FROM manifest AS m
+                         JOIN manifest_content AS c ON c.manifest_id = m.id
+                WHERE (sqlc.narg(category)::text IS NULL OR m.category = sqlc.narg(category)::text)
+                  AND (sqlc.narg(tags_any)::text[] IS NULL OR m.tags && sqlc.narg(tags_any)::text[])
+                  AND (sqlc.narg(tags_all)::text[] IS NULL OR m.tags @> sqlc.narg(tags_all)::text[])
+                  AND (sqlc.narg(exclude_permissions)::text[] IS NULL
+                    OR NOT c.permissions && sqlc.narg(exclude_permissions)::text[])
+                  AND (sqlc.narg(author)::text IS NULL
+                    OR lower(m.author_name) = lower(sqlc.narg(author)::text)
+                    OR lower(m.author_email) = lower(sqlc.narg(author)::text))
+                  AND (sqlc.narg(created_after)::timestamptz IS NULL OR m.created_at >= sqlc.narg(created_after)::timestamptz)
+                  AND (sqlc.narg(created_before)::timestamptz IS NULL OR m.created_at < sqlc.narg(created_before)::timestamptz)
+                  -- несовместимые с заявленными возможностями клиента манифесты не показываем
+                  AND manifest_compatible(m.id,
+                                          sqlc.narg(client_app_version)::text,
+                                          sqlc.narg(client_os)::text,
+                                          sqlc.narg(client_components)::text[],
+                                          sqlc.narg(client_permissions)::text[]))
+SELECT id,
+       version,
+       icon,
+       category,
+       tags,
+       author_name,
+       author_email,
+       created_at,
+       meta_created_at,
+       -- 🔽 отдаём локализации **всех** локалей: выбор и откат по ключам делает сервис
+       (SELECT jsonb_object_agg(x.locale, x.strings)
+        FROM (SELECT l.locale, jsonb_object_agg(l.key, l.value) AS strings
+              FROM manifest_localizations AS l
+              WHERE l.manifest_id = listed.id
+              GROUP BY l.locale) AS x) AS localizations,
+       sort_title
+FROM listed
+-- keyset: строки строго «после» курсора
+WHERE sqlc.narg(cursor_id)::uuid IS NULL
+   OR (sort_title, id) > (sqlc.narg(cursor_title)::text, sqlc.narg(cursor_id)::uuid)
+ORDER BY sort_title, id
+LIMIT sqlc.arg('limit');
 
--- name: SearchManifests :many
+-- name: ListManifestsByTitleOffset :many
+-- страница по offset для старых клиентов; курсорные страницы OFFSET не используют
+WITH listed AS (SELECT m.id,
+                       m.version,
+                       m.icon,
+                       m.category,
+                       m.tags,
+                       m.author_name,
+                       m.author_email,
+                       m.created_at,
+                       m.meta_created_at,
+                       -- ключ сортировки title: первый заголовок по цепочке отката локалей
+                       coalesce((SELECT l.value
+                                 FROM manifest_localizations AS l
+                                 WHERE l.manifest_id = m.id
+                                   AND l.key = 'title'
+                                   AND l.locale = ANY (@locales::text[])
+                                 ORDER BY array_position(@locales::text[], l.locale)
+                                 LIMIT 1), '')::text AS sort_title
+                FROM manifest AS m
+                         JOIN manifest_content AS c ON c.manifest_id = m.id
+                WHERE (sqlc.narg(category)::text IS NULL OR m.category = sqlc.narg(category)::text)
+                  AND (sqlc.narg(tags_any)::text[] IS NULL OR m.tags && sqlc.narg(tags_any)::text[])
+                  AND (sqlc.narg(tags_all)::text[] IS NULL OR m.tags @> sqlc.narg(tags_all)::text[])
+                  AND (sqlc.narg(exclude_permissions)::text[] IS NULL
+                    OR NOT c.permissions && sqlc.narg(exclude_permissions)::text[])
+                  AND (sqlc.narg(author)::text IS NULL
+                    OR lower(m.author_name) = lower(sqlc.narg(author)::text)
+                    OR lower(m.author_email) = lower(sqlc.narg(author)::text))
+                  AND (sqlc.narg(created_after)::timestamptz IS NULL OR m.created_at >= sqlc.narg(created_after)::timestamptz)
+                  AND (sqlc.narg(created_before)::timestamptz IS NULL OR m.created_at < sqlc.narg(created_before)::timestamptz)
+                  -- несовместимые с заявленными возможностями клиента манифесты не показываем
+                  AND manifest_compatible(m.id,
+                                          sqlc.narg(client_app_version)::text,
+                                          sqlc.narg(client_os)::text,
+                                          sqlc.narg(client_components)::text[],
+                                          sqlc.narg(client_permissions)::text[]))
+SELECT id,
+       version,
+       icon,
+       category,
+       tags,
+       author_name,
+       author_email,
+       created_at,
+       meta_created_at,
+       -- 🔽 отдаём локализации **всех** локалей: выбор и откат по ключам делает сервис
+       (SELECT jsonb_object_agg(x.locale, x.strings)
+        FROM (SELECT l.locale, jsonb_object_agg(l.key, l.value) AS strings
+              FROM manifest_localizations AS l
+              WHERE l.manifest_id = listed.id
+              GROUP BY l.locale) AS x) AS localizations,
+       sort_title
+FROM listed
+ORDER BY sort_title, id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListManifestsOffset :many
+-- страница по offset для старых клиентов; курсорные страницы OFFSET не используют
 SELECT m.id,
        m.version,
        m.icon,
@@ -27,31 +171,138 @@ SELECT m.id,
        m.author_name,
        m.author_email,
        m.created_at,
-       m.meta_created_at
-FROM manifest m
-         LEFT JOIN manifest_localizations t
-                   ON t.manifest_id = m.id
-                       AND t.locale = sqlc.arg(locale)
-                       AND t.key = 'title'
-         LEFT JOIN manifest_localizations d
-                   ON d.manifest_id = m.id
-                       AND d.locale = sqlc.arg(locale)
-                       AND d.key = 'description'
-WHERE (
-          -- empty search string => return everything
-          (sqlc.arg(search)::text = '')
-              -- full-text on title+description
-              OR to_tsvector('english',
-                             coalesce(t.value, '') || ' ' || coalesce(d.value, '')
-                 ) @@ plainto_tsquery('english', sqlc.arg(search)::text)
-              -- category ilike
-              OR m.category ILIKE '%' || sqlc.arg(search)::text || '%'
-              -- any tag matches
-              OR EXISTS (SELECT 1
-                         FROM unnest(m.tags) AS tag
-                         WHERE tag ILIKE '%' || sqlc.arg(search)::text || '%')
-          )
-ORDER BY m.created_at DESC;
+       m.meta_created_at,
+       -- 🔽 отдаём локализации **всех** локалей: выбор и откат по ключам делает сервис
+       (SELECT jsonb_object_agg(x.locale, x.strings)
+        FROM (SELECT l.locale, jsonb_object_agg(l.key, l.value) AS strings
+              FROM manifest_localizations AS l
+              WHERE l.manifest_id = m.id
+              GROUP BY l.locale) AS x) AS localizations,
+       -- ключ title нужен только сортировке по title
+       ''::text AS sort_title
+FROM manifest AS m
+         JOIN manifest_content AS c ON c.manifest_id = m.id
+WHERE (sqlc.narg(category)::text IS NULL OR m.category = sqlc.narg(category)::text)
+  AND (sqlc.narg(tags_any)::text[] IS NULL OR m.tags && sqlc.narg(tags_any)::text[])
+  AND (sqlc.narg(tags_all)::text[] IS NULL OR m.tags @> sqlc.narg(tags_all)::text[])
+  AND (sqlc.narg(exclude_permissions)::text[] IS NULL
+    OR NOT c.permissions && sqlc.narg(exclude_permissions)::text[])
+  AND (sqlc.narg(author)::text IS NULL
+    OR lower(m.author_name) = lower(sqlc.narg(author)::text)
+    OR lower(m.author_email) = lower(sqlc.narg(author)::text))
+  AND (sqlc.narg(created_after)::timestamptz IS NULL OR m.created_at >= sqlc.narg(created_after)::timestamptz)
+  AND (sqlc.narg(created_before)::timestamptz IS NULL OR m.created_at < sqlc.narg(created_before)::timestamptz)
+  -- несовместимые с заявленными возможностями клиента манифесты не показываем
+  AND manifest_compatible(m.id,
+                          sqlc.narg(client_app_version)::text,
+                          sqlc.narg(client_os)::text,
+                          sqlc.narg(client_components)::text[],
+                          sqlc.narg(client_permissions)::text[])
+ORDER BY m.created_at DESC, m.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListManifestsOldest :many
+-- сортировка oldest: тот же idx_manifest_created_at_id, прочитанный в обратном порядке
+SELECT m.id,
+       m.version,
+       m.icon,
+       m.category,
+       m.tags,
+       m.author_name,
+       m.author_email,
+       m.created_at,
+       m.meta_created_at,
+       -- 🔽 отдаём локализации **всех** локалей: выбор и откат по ключам делает сервис
+       (SELECT jsonb_object_agg(x.locale, x.strings)
+        FROM (SELECT l.locale, jsonb_object_agg(l.key, l.value) AS strings
+              FROM manifest_localizations AS l
+              WHERE l.manifest_id = m.id
+              GROUP BY l.locale) AS x) AS localizations,
+       -- ключ title нужен только сортировке по title
+       ''::text AS sort_title
+FROM manifest AS m
+         JOIN manifest_content AS c ON c.manifest_id = m.id
+WHERE (sqlc.narg(category)::text IS NULL OR m.category = sqlc.narg(category)::text)
+  AND (sqlc.narg(tags_any)::text[] IS NULL OR m.tags && sqlc.narg(tags_any)::text[])
+  AND (sqlc.narg(tags_all)::text[] IS NULL OR m.tags @> sqlc.narg(tags_all)::text[])
+  AND (sqlc.narg(exclude_permissions)::text[] IS NULL
+    OR NOT c.permissions && sqlc.narg(exclude_permissions)::text[])
+  AND (sqlc.narg(author)::text IS NULL
+    OR lower(m.author_name) = lower(sqlc.narg(author)::text)
+    OR lower(m.author_email) = lower(sqlc.narg(author)::text))
+  AND (sqlc.narg(created_after)::timestamptz IS NULL OR m.created_at >= sqlc.narg(created_after)::timestamptz)
+  AND (sqlc.narg(created_before)::timestamptz IS NULL OR m.created_at < sqlc.narg(created_before)::timestamptz)
+  -- несовместимые с заявленными возможностями клиента манифесты не показываем
+  AND manifest_compatible(m.id,
+                          sqlc.narg(client_app_version)::text,
+                          sqlc.narg(client_os)::text,
+                          sqlc.narg(client_components)::text[],
+                          sqlc.narg(client_permissions)::text[])
+  -- keyset: строки строго «после» курсора
+  AND (sqlc.narg(cursor_id)::uuid IS NULL
+    OR (m.created_at, m.id) > (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::uuid))
+ORDER BY m.created_at, m.id
+LIMIT sqlc.arg('limit');
+
+-- name: ListManifestsOldestOffset :many
+-- страница по offset для старых клиентов; курсорные страницы OFFSET не используют
+SELECT m.id,
+       m.version,
+       m.icon,
+       m.category,
+       m.tags,
+       m.author_name,
+       m.author_email,
+       m.created_at,
+       m.meta_created_at,
+       -- 🔽 отдаём локализации **всех** локалей: выбор и откат по ключам делает сервис
+       (SELECT jsonb_object_agg(x.locale, x.strings)
+        FROM (SELECT l.locale, jsonb_object_agg(l.key, l.value) AS strings
+              FROM manifest_localizations AS l
+              WHERE l.manifest_id = m.id
+              GROUP BY l.locale) AS x) AS localizations,
+       -- ключ title нужен только сортировке по title
+       ''::text AS sort_title
+FROM manifest AS m
+         JOIN manifest_content AS c ON c.manifest_id = m.id
+WHERE (sqlc.narg(category)::text IS NULL OR m.category = sqlc.narg(category)::text)
+  AND (sqlc.narg(tags_any)::text[] IS NULL OR m.tags && sqlc.narg(tags_any)::text[])
+  AND (sqlc.narg(tags_all)::text[] IS NULL OR m.tags @> sqlc.narg(tags_all)::text[])
+  AND (sqlc.narg(exclude_permissions)::text[] IS NULL
+    OR NOT c.permissions && sqlc.narg(exclude_permissions)::text[])
+  AND (sqlc.narg(author)::text IS NULL
+    OR lower(m.author_name) = lower(sqlc.narg(author)::text)
+    OR lower(m.author_email) = lower(sqlc.narg(author)::text))
+  AND (sqlc.narg(created_after)::timestamptz IS NULL OR m.created_at >= sqlc.narg(created_after)::timestamptz)
+  AND (sqlc.narg(created_before)::timestamptz IS NULL OR m.created_at < sqlc.narg(created_before)::timestamptz)
+  -- несовместимые с заявленными возможностями клиента манифесты не показываем
+  AND manifest_compatible(m.id,
+                          sqlc.narg(client_app_version)::text,
+                          sqlc.narg(client_os)::text,
+                          sqlc.narg(client_components)::text[],
+                          sqlc.narg(client_permissions)::text[])
+ORDER BY m.created_at, m.id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountManifests :one
+SELECT count(*)
+FROM manifest AS m
+         JOIN manifest_content AS c ON c.manifest_id = m.id
+WHERE (sqlc.narg(category)::text IS NULL OR m.category = sqlc.narg(category)::text)
+  AND (sqlc.narg(tags_any)::text[] IS NULL OR m.tags && sqlc.narg(tags_any)::text[])
+  AND (sqlc.narg(tags_all)::text[] IS NULL OR m.tags @> sqlc.narg(tags_all)::text[])
+  AND (sqlc.narg(exclude_permissions)::text[] IS NULL
+    OR NOT c.permissions && sqlc.narg(exclude_permissions)::text[])
+  AND (sqlc.narg(author)::text IS NULL
+    OR lower(m.author_name) = lower(sqlc.narg(author)::text)
+    OR lower(m.author_email) = lower(sqlc.narg(author)::text))
+  AND (sqlc.narg(created_after)::timestamptz IS NULL OR m.created_at >= sqlc.narg(created_after)::timestamptz)
+  AND (sqlc.narg(created_before)::timestamptz IS NULL OR m.created_at < sqlc.narg(created_before)::timestamptz)
+  AND manifest_compatible(m.id,
+                          sqlc.narg(client_app_version)::text,
+                          sqlc.narg(client_os)::text,
+                          sqlc.narg(client_components)::text[],
+                          sqlc.narg(client_permissions)::text[]);
 
 
 -- name: SearchManifestsFTS :many
@@ -64,29 +315,40 @@ SELECT m.id,
        m.author_email,
        m.created_at,
        m.meta_created_at,
-       (SELECT jsonb_object_agg(l.key, l.value)
-        FROM manifest_localizations AS l
-        WHERE l.manifest_id = m.id
-          AND l.locale = sqlc.arg(locale)::text) AS localization
+       (SELECT jsonb_object_agg(x.locale, x.strings)
+        FROM (SELECT l.locale, jsonb_object_agg(l.key, l.value) AS strings
+              FROM manifest_localizations AS l
+              WHERE l.manifest_id = m.id
+              GROUP BY l.locale) AS x) AS localizations
 FROM manifest AS m
 WHERE to_tsvector(sqlc.arg(config)::regconfig,
-                  (SELECT string_agg(l.value, ' ')
-                   FROM manifest_localizations AS l
-                   WHERE l.manifest_id = m.id
-                     AND l.locale = sqlc.arg(locale)::text
-                     AND l.key IN ('title', 'description')) || ' ' ||
+                  coalesce((SELECT string_agg(l.value, ' ')
+                            FROM manifest_localizations AS l
+                            WHERE l.manifest_id = m.id
+                              AND l.locale = ANY (sqlc.arg(locales)::text[])
+                              AND l.key IN ('title', 'description')), '') || ' ' ||
                   m.category || ' ' ||
                   array_to_string(m.tags, ' ')
       )
           @@ plainto_tsquery(sqlc.arg(config)::regconfig, sqlc.arg(query)::text)
+  AND manifest_compatible(m.id,
+                          sqlc.narg(client_app_version)::text,
+                          sqlc.narg(client_os)::text,
+                          sqlc.narg(client_components)::text[],
+                          sqlc.narg(client_permissions)::text[])
 ORDER BY m.created_at DESC;
 
 -- name: GetManifest :one
-WITH localization AS (SELECT ml.manifest_id,
-                             json_object_agg(ml.key, ml.value) AS localization
-                      FROM manifest_localizations ml
-                      WHERE ml.locale = sqlc.arg(locale)::text
-                      GROUP BY ml.manifest_id)
+-- локализации всех локалей: {"en": {...}, "pt-BR": {...}}
+WITH localization AS (SELECT x.manifest_id,
+                             jsonb_object_agg(x.locale, x.strings) AS localizations
+                      FROM (SELECT ml.manifest_id,
+                                   ml.locale,
+                                   jsonb_object_agg(ml.key, ml.value) AS strings
+                            FROM manifest_localizations ml
+                            WHERE ml.manifest_id = sqlc.arg(manifest_id)::uuid
+                            GROUP BY ml.manifest_id, ml.locale) AS x
+                      GROUP BY x.manifest_id)
 SELECT m.id,
        m.version,
        m.icon,
@@ -97,13 +359,25 @@ SELECT m.id,
        m.created_at,
        m.meta_created_at,
        m.signature,
+       m.signature_kid,
+       mv.signature_jws,
+       m.canonical_version,
+       m.author_kid,
+       m.author_signature,
+       m.min_app_version,
+       m.max_app_version,
+       m.os,
+       m.component_types,
        mc.ui AS U_I,
        mc.script,
        mc.actions,
        mc.permissions,
-       l.localization
+       mv.permissions_added,
+       mv.permissions_removed,
+       l.localizations
 FROM manifest m
          LEFT JOIN manifest_content mc ON mc.manifest_id = m.id
+         LEFT JOIN manifest_versions mv ON mv.manifest_id = m.id AND mv.version = m.version
          LEFT JOIN localization l ON l.manifest_id = m.id
 WHERE m.id = sqlc.arg(manifest_id)::uuid;
 
@@ -117,7 +391,11 @@ INSERT INTO manifest (id,
                       author_email,
                       created_at,
                       meta_created_at,
-                      signature)
+                      signature,
+                      signature_kid,
+                      canonical_version,
+                      author_kid,
+                      author_signature)
 VALUES (sqlc.arg(id),
         sqlc.arg(version),
         sqlc.arg(icon),
@@ -125,9 +403,13 @@ VALUES (sqlc.arg(id),
         sqlc.arg(tags),
         sqlc.arg(author_name),
         sqlc.arg(author_email),
-        now(),
-        now(),
-        sqlc.arg(signature))
+        sqlc.arg(created_at),
+        sqlc.arg(created_at),
+        sqlc.arg(signature),
+        sqlc.arg(signature_kid),
+        sqlc.arg(canonical_version),
+        sqlc.arg(author_kid),
+        sqlc.arg(author_signature))
 RETURNING id;
 
 -- name: CreateManifestContent :exec
@@ -150,4 +432,382 @@ INSERT INTO manifest_localizations (manifest_id,
 SELECT sqlc.arg(manifest_id),
        unnest(sqlc.arg(locales)::text[]),
        unnest(sqlc.arg(keys)::text[]),
-       unnest(sqlc.arg(values)::text[]);
\ No newline at end of file
+       unnest(sqlc.arg(values)::text[]);
+
+-- name: GetManifestForUpdate :one
+SELECT m.id,
+       m.version,
+       m.icon,
+       m.category,
+       m.tags,
+       m.author_name,
+       m.author_email,
+       m.created_at,
+       m.meta_created_at,
+       m.signature,
+       mc.ui,
+       mc.script,
+       mc.actions,
+       mc.permissions
+FROM manifest m
+         JOIN manifest_content mc ON mc.manifest_id = m.id
+WHERE m.id = sqlc.arg(manifest_id)::uuid
+    FOR UPDATE OF m;
+
+-- name: ListLocalizations :many
+SELECT locale,
+       key,
+       value
+FROM manifest_localizations
+WHERE manifest_id = sqlc.arg(manifest_id)
+ORDER BY locale, key;
+
+-- name: ListLocales :many
+-- все локали каталога: из них matcher строит цепочку для сортировки и поиска
+SELECT DISTINCT locale
+FROM manifest_localizations
+ORDER BY locale;
+
+-- name: UpdateManifest :exec
+UPDATE manifest
+SET version           = sqlc.arg(version),
+    icon              = sqlc.arg(icon),
+    category          = sqlc.arg(category),
+    tags              = sqlc.arg(tags),
+    author_name       = sqlc.arg(author_name),
+    author_email      = sqlc.arg(author_email),
+    signature         = sqlc.arg(signature),
+    signature_kid     = sqlc.arg(signature_kid),
+    canonical_version = sqlc.arg(canonical_version),
+    author_kid        = sqlc.arg(author_kid),
+    author_signature  = sqlc.arg(author_signature)
+WHERE id = sqlc.arg(id);
+
+-- name: UpdateManifestContent :exec
+UPDATE manifest_content
+SET ui          = sqlc.arg(ui),
+    script      = sqlc.arg(script),
+    actions     = sqlc.arg(actions),
+    permissions = sqlc.arg(permissions)
+WHERE manifest_id = sqlc.arg(manifest_id);
+
+-- name: SetManifestCompatibility :exec
+UPDATE manifest
+SET min_app_version = sqlc.narg(min_app_version),
+    max_app_version = sqlc.narg(max_app_version),
+    os              = sqlc.arg(os)
+WHERE id = sqlc.arg(id);
+
+-- name: SetManifestComponentTypes :exec
+UPDATE manifest
+SET component_types = sqlc.arg(component_types)
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteLocalizations :exec
+DELETE
+FROM manifest_localizations
+WHERE manifest_id = sqlc.arg(manifest_id);
+
+-- name: CreateManifestVersion :exec
+INSERT INTO manifest_versions (manifest_id,
+                               version,
+                               icon,
+                               category,
+                               tags,
+                               author_name,
+                               author_email,
+                               ui,
+                               script,
+                               actions,
+                               permissions,
+                               permissions_added,
+                               permissions_removed,
+                               localization,
+                               signature,
+                               signature_kid,
+                               signature_jws,
+                               canonical_version,
+                               author_kid,
+                               author_signature)
+VALUES (sqlc.arg(manifest_id),
+        sqlc.arg(version),
+        sqlc.arg(icon),
+        sqlc.arg(category),
+        sqlc.arg(tags),
+        sqlc.arg(author_name),
+        sqlc.arg(author_email),
+        sqlc.arg(ui),
+        sqlc.arg(script),
+        sqlc.arg(actions),
+        sqlc.arg(permissions),
+        sqlc.arg(permissions_added),
+        sqlc.arg(permissions_removed),
+        sqlc.arg(localization),
+        sqlc.arg(signature),
+        sqlc.arg(signature_kid),
+        sqlc.arg(signature_jws),
+        sqlc.arg(canonical_version),
+        sqlc.arg(author_kid),
+        sqlc.arg(author_signature));
+
+-- name: ListManifestVersions :many
+SELECT mv.version,
+       mv.signature,
+       mv.signature_kid,
+       mv.canonical_version,
+       mv.permissions_added,
+       mv.permissions_removed,
+       mv.created_at
+FROM manifest_versions mv
+WHERE mv.manifest_id = sqlc.arg(manifest_id)::uuid
+ORDER BY mv.created_at DESC;
+
+-- name: GetManifestVersion :one
+SELECT m.id,
+       mv.version,
+       mv.icon,
+       mv.category,
+       mv.tags,
+       mv.author_name,
+       mv.author_email,
+       m.created_at,
+       m.meta_created_at,
+       mv.created_at AS version_created_at,
+       mv.signature,
+       mv.signature_kid,
+       mv.signature_jws,
+       mv.canonical_version,
+       mv.author_kid,
+       mv.author_signature,
+       mv.ui,
+       mv.script,
+       mv.actions,
+       mv.permissions,
+       mv.permissions_added,
+       mv.permissions_removed,
+       mv.localization
+FROM manifest_versions mv
+         JOIN manifest m ON m.id = mv.manifest_id
+WHERE mv.manifest_id = sqlc.arg(manifest_id)::uuid
+  AND mv.version = sqlc.arg(version)::text;
+
+-- name: CountVersionsForResign :one
+SELECT count(*)
+FROM manifest_versions mv
+WHERE NOT sqlc.arg(only_stale)::bool
+   OR mv.signature_kid IS DISTINCT FROM sqlc.arg(kid)::text
+   OR mv.signature_jws IS NULL
+   OR mv.canonical_version <> sqlc.arg(canonical_version)::smallint;
+
+-- name: ListVersionsForResign :many
+-- обход по первичному ключу (manifest_id, version); only_stale оставляет версии,
+-- подписанные не ключом kid, без JWS или не в схеме canonical_version, поэтому прерванная
+-- переподпись продолжается с того же места
+SELECT mv.manifest_id,
+       mv.version,
+       mv.icon,
+       mv.category,
+       mv.tags,
+       mv.author_name,
+       mv.author_email,
+       mv.ui,
+       mv.script,
+       mv.actions,
+       mv.permissions,
+       mv.localization,
+       mv.signature,
+       mv.signature_kid,
+       mv.canonical_version,
+       mv.author_kid,
+       mv.author_signature,
+       m.created_at
+FROM manifest_versions mv
+         JOIN manifest m ON m.id = mv.manifest_id
+WHERE (mv.manifest_id, mv.version) > (sqlc.arg(after_id)::uuid, sqlc.arg(after_version)::text)
+  AND (NOT sqlc.arg(only_stale)::bool
+    OR mv.signature_kid IS DISTINCT FROM sqlc.arg(kid)::text
+    OR mv.signature_jws IS NULL
+    OR mv.canonical_version <> sqlc.arg(canonical_version)::smallint)
+ORDER BY mv.manifest_id, mv.version
+LIMIT sqlc.arg(batch_size);
+
+-- name: UpdateVersionSignature :exec
+-- триггер неизменяемости пропускает смену подписи: содержимое релиза не меняется
+UPDATE manifest_versions
+SET signature         = sqlc.arg(signature),
+    signature_kid     = sqlc.arg(signature_kid),
+    signature_jws     = sqlc.arg(signature_jws),
+    canonical_version = sqlc.arg(canonical_version)
+WHERE manifest_id = sqlc.arg(manifest_id)
+  AND version = sqlc.arg(version);
+
+-- name: UpdateManifestSignature :execrows
+-- переподписывает текущую версию; если манифест успели обновить, строка не меняется
+UPDATE manifest
+SET signature         = sqlc.arg(signature),
+    signature_kid     = sqlc.arg(signature_kid),
+    canonical_version = sqlc.arg(canonical_version)
+WHERE id = sqlc.arg(id)
+  AND version = sqlc.arg(version);
+
+-- name: CreateAuthorKey :one
+INSERT INTO author_keys (kid,
+                         author_email,
+                         public_key)
+VALUES (sqlc.arg(kid),
+        sqlc.arg(author_email),
+        sqlc.arg(public_key))
+RETURNING kid, author_email, public_key, created_at;
+
+-- name: GetAuthorKey :one
+SELECT kid,
+       author_email,
+       public_key,
+       created_at
+FROM author_keys
+WHERE kid = sqlc.arg(kid);
+
+-- name: ListAuthorKeys :many
+SELECT kid,
+       author_email,
+       public_key,
+       created_at
+FROM author_keys
+WHERE lower(author_email) = lower(sqlc.arg(author_email)::text)
+ORDER BY created_at, kid;
+
+-- name: GetCatalogVersion :one
+SELECT version
+FROM catalog_state;
+
+-- name: ListCatalogManifests :many
+-- текущие версии всех манифестов с содержимым, из которого пересобирается подписанный payload
+SELECT m.id,
+       m.version,
+       m.icon,
+       m.category,
+       m.tags,
+       m.author_name,
+       m.author_email,
+       m.created_at,
+       m.canonical_version,
+       m.author_kid,
+       m.author_signature,
+       mc.ui,
+       mc.script,
+       mc.actions,
+       mc.permissions,
+       (SELECT jsonb_object_agg(x.locale, x.strings)
+        FROM (SELECT l.locale, jsonb_object_agg(l.key, l.value) AS strings
+              FROM manifest_localizations AS l
+              WHERE l.manifest_id = m.id
+              GROUP BY l.locale) AS x) AS localizations
+FROM manifest m
+         JOIN manifest_content mc ON mc.manifest_id = m.id
+ORDER BY m.id;
+
+-- name: LockTransparencyLog :exec
+-- до конца транзакции: листья получают индексы по порядку и без пропусков, чтение не блокируется
+LOCK TABLE transparency_log IN EXCLUSIVE MODE;
+
+-- name: AppendLogEntry :one
+INSERT INTO transparency_log (leaf_index,
+                              leaf_hash,
+                              leaf_data,
+                              manifest_id,
+                              version,
+                              signature_kid)
+SELECT coalesce(max(leaf_index) + 1, 0),
+       sqlc.arg(leaf_hash),
+       sqlc.arg(leaf_data),
+       sqlc.arg(manifest_id),
+       sqlc.arg(version),
+       sqlc.arg(signature_kid)
+FROM transparency_log
+RETURNING leaf_index;
+
+-- name: ListLogLeafHashes :many
+SELECT leaf_index,
+       leaf_hash
+FROM transparency_log
+WHERE leaf_index >= sqlc.arg(from_index)
+ORDER BY leaf_index;
+
+-- name: GetLogLeafIndex :one
+SELECT leaf_index
+FROM transparency_log
+WHERE leaf_hash = sqlc.arg(leaf_hash)
+ORDER BY leaf_index
+LIMIT 1;
+
+-- name: ListLogEntries :many
+SELECT leaf_index,
+       leaf_data,
+       created_at
+FROM transparency_log
+WHERE leaf_index >= sqlc.arg(start_index)
+  AND leaf_index < sqlc.arg(end_index)
+ORDER BY leaf_index;
+
+-- name: LockRevocations :exec
+-- sequence — max+1, поэтому отзывы выдаются строго по очереди до коммита
+LOCK TABLE revocations IN EXCLUSIVE MODE;
+
+-- name: CreateRevocation :one
+INSERT INTO revocations (sequence,
+                         manifest_id,
+                         version,
+                         reason)
+SELECT coalesce(max(sequence) + 1, 1),
+       sqlc.arg(manifest_id),
+       sqlc.narg(version),
+       sqlc.arg(reason)
+FROM revocations
+RETURNING sequence, manifest_id, version, reason, revoked_at;
+
+-- name: ListRevocations :many
+SELECT sequence,
+       manifest_id,
+       version,
+       reason,
+       revoked_at
+FROM revocations
+ORDER BY sequence;
+
+-- name: GetRevocationSequence :one
+SELECT coalesce(max(sequence), 0)::bigint AS sequence
+FROM revocations;
+
+-- name: GetManifestRevocation :one
+-- отзыв всего манифеста важнее отзыва отдельной версии
+SELECT sequence,
+       manifest_id,
+       version,
+       reason,
+       revoked_at
+FROM revocations
+WHERE manifest_id = sqlc.arg(manifest_id)
+  AND (version IS NULL OR version = sqlc.arg(version)::text)
+ORDER BY version NULLS FIRST
+LIMIT 1;
+
+-- name: ListUiComponents :many
+SELECT type,
+       props_schema,
+       min_client_version,
+       description,
+       updated_at
+FROM ui_components
+ORDER BY type;
+
+-- name: ListPermissions :many
+-- тексты всех локалей: {"en": {"title": …, "description": …}, "ru": {…}}
+SELECT p.name,
+       p.risk,
+       p.requires_review,
+       coalesce((SELECT jsonb_object_agg(pl.locale,
+                                         jsonb_build_object('title', pl.title, 'description', pl.description))
+                 FROM permission_localizations AS pl
+                 WHERE pl.permission = p.name), '{}')::jsonb AS localizations
+FROM permissions AS p
+ORDER BY p.name;
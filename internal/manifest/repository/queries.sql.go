@@ -16,6 +16,148 @@ import (
 	"github.com/sqlc-dev/pqtype"
 )
 
+const appendLogEntry = `-- name: AppendLogEntry :one
+INSERT INTO transparency_log (leaf_index,
+                              leaf_hash,
+                              leaf_data,
+                              manifest_id,
+                              version,
+                              signature_kid)
+SELECT coalesce(max(leaf_index) + 1, 0),
+       $1,
+       $2,
+       $3,
+       $4,
+       $5
+FROM transparency_log
+RETURNING leaf_index
+`
+
+type AppendLogEntryParams struct {
+	LeafHash     []byte
+	LeafData     []byte
+	ManifestID   uuid.UUID
+	Version      string
+	SignatureKid sql.NullString
+}
+
+func (q *Queries) AppendLogEntry(ctx context.Context, arg AppendLogEntryParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, appendLogEntry,
+		arg.LeafHash,
+		arg.LeafData,
+		arg.ManifestID,
+		arg.Version,
+		arg.SignatureKid,
+	)
+	var leaf_index int64
+	err := row.Scan(&leaf_index)
+	return leaf_index, err
+}
+
+const countManifests = `-- name: CountManifests :one
+SELECT count(*)
+FROM manifest AS m
+         JOIN manifest_content AS c ON c.manifest_id = m.id
+WHERE ($1::text IS NULL OR m.category = $1::text)
+  AND ($2::text[] IS NULL OR m.tags && $2::text[])
+  AND ($3::text[] IS NULL OR m.tags @> $3::text[])
+  AND ($4::text[] IS NULL
+    OR NOT c.permissions && $4::text[])
+  AND ($5::text IS NULL
+    OR lower(m.author_name) = lower($5::text)
+    OR lower(m.author_email) = lower($5::text))
+  AND ($6::timestamptz IS NULL OR m.created_at >= $6::timestamptz)
+  AND ($7::timestamptz IS NULL OR m.created_at < $7::timestamptz)
+  AND manifest_compatible(m.id,
+                          $8::text,
+                          $9::text,
+                          $10::text[],
+                          $11::text[])
+`
+
+type CountManifestsParams struct {
+	Category           sql.NullString
+	TagsAny            []string
+	TagsAll            []string
+	ExcludePermissions []string
+	Author             sql.NullString
+	CreatedAfter       sql.NullTime
+	CreatedBefore      sql.NullTime
+	ClientAppVersion   sql.NullString
+	ClientOs           sql.NullString
+	ClientComponents   []string
+	ClientPermissions  []string
+}
+
+func (q *Queries) CountManifests(ctx context.Context, arg CountManifestsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countManifests,
+		arg.Category,
+		pq.Array(arg.TagsAny),
+		pq.Array(arg.TagsAll),
+		pq.Array(arg.ExcludePermissions),
+		arg.Author,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.ClientAppVersion,
+		arg.ClientOs,
+		pq.Array(arg.ClientComponents),
+		pq.Array(arg.ClientPermissions),
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countVersionsForResign = `-- name: CountVersionsForResign :one
+SELECT count(*)
+FROM manifest_versions mv
+WHERE NOT $1::bool
+   OR mv.signature_kid IS DISTINCT FROM $2::text
+   OR mv.signature_jws IS NULL
+   OR mv.canonical_version <> $3::smallint
+`
+
+type CountVersionsForResignParams struct {
+	OnlyStale        bool
+	Kid              string
+	CanonicalVersion int16
+}
+
+func (q *Queries) CountVersionsForResign(ctx context.Context, arg CountVersionsForResignParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countVersionsForResign, arg.OnlyStale, arg.Kid, arg.CanonicalVersion)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createAuthorKey = `-- name: CreateAuthorKey :one
+INSERT INTO author_keys (kid,
+                         author_email,
+                         public_key)
+VALUES ($1,
+        $2,
+        $3)
+RETURNING kid, author_email, public_key, created_at
+`
+
+type CreateAuthorKeyParams struct {
+	Kid         string
+	AuthorEmail string
+	PublicKey   []byte
+}
+
+func (q *Queries) CreateAuthorKey(ctx context.Context, arg CreateAuthorKeyParams) (AuthorKey, error) {
+	row := q.db.QueryRowContext(ctx, createAuthorKey, arg.Kid, arg.AuthorEmail, arg.PublicKey)
+	var i AuthorKey
+	err := row.Scan(
+		&i.Kid,
+		&i.AuthorEmail,
+		&i.PublicKey,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const createLocalizations = `-- name: CreateLocalizations :exec
 INSERT INTO manifest_localizations (manifest_id,
                                     locale,
@@ -54,7 +196,11 @@ INSERT INTO manifest (id,
                       author_email,
                       created_at,
                       meta_created_at,
-                      signature)
+                      signature,
+                      signature_kid,
+                      canonical_version,
+                      author_kid,
+                      author_signature)
 VALUES ($1,
         $2,
         $3,
@@ -62,21 +208,30 @@ VALUES ($1,
         $5,
         $6,
         $7,
-        now(),
-        now(),
-        $8)
+        $8,
+        $8,
+        $9,
+        $10,
+        $11,
+        $12,
+        $13)
 RETURNING id
 `
 
 type CreateManifestParams struct {
-	ID          uuid.UUID
-	Version     string
-	Icon        string
-	Category    string
-	Tags        []string
-	AuthorName  string
-	AuthorEmail string
-	Signature   string
+	ID               uuid.UUID
+	Version          string
+	Icon             string
+	Category         string
+	Tags             []string
+	AuthorName       string
+	AuthorEmail      string
+	CreatedAt        time.Time
+	Signature        string
+	SignatureKid     sql.NullString
+	CanonicalVersion int16
+	AuthorKid        sql.NullString
+	AuthorSignature  sql.NullString
 }
 
 func (q *Queries) CreateManifest(ctx context.Context, arg CreateManifestParams) (uuid.UUID, error) {
@@ -88,7 +243,12 @@ func (q *Queries) CreateManifest(ctx context.Context, arg CreateManifestParams)
 		pq.Array(arg.Tags),
 		arg.AuthorName,
 		arg.AuthorEmail,
+		arg.CreatedAt,
 		arg.Signature,
+		arg.SignatureKid,
+		arg.CanonicalVersion,
+		arg.AuthorKid,
+		arg.AuthorSignature,
 	)
 	var id uuid.UUID
 	err := row.Scan(&id)
@@ -127,12 +287,200 @@ func (q *Queries) CreateManifestContent(ctx context.Context, arg CreateManifestC
 	return err
 }
 
+const createManifestVersion = `-- name: CreateManifestVersion :exec
+INSERT INTO manifest_versions (manifest_id,
+                               version,
+                               icon,
+                               category,
+                               tags,
+                               author_name,
+                               author_email,
+                               ui,
+                               script,
+                               actions,
+                               permissions,
+                               permissions_added,
+                               permissions_removed,
+                               localization,
+                               signature,
+                               signature_kid,
+                               signature_jws,
+                               canonical_version,
+                               author_kid,
+                               author_signature)
+VALUES ($1,
+        $2,
+        $3,
+        $4,
+        $5,
+        $6,
+        $7,
+        $8,
+        $9,
+        $10,
+        $11,
+        $12,
+        $13,
+        $14,
+        $15,
+        $16,
+        $17,
+        $18,
+        $19,
+        $20)
+`
+
+type CreateManifestVersionParams struct {
+	ManifestID         uuid.UUID
+	Version            string
+	Icon               string
+	Category           string
+	Tags               []string
+	AuthorName         string
+	AuthorEmail        string
+	Ui                 json.RawMessage
+	Script             string
+	Actions            json.RawMessage
+	Permissions        []string
+	PermissionsAdded   []string
+	PermissionsRemoved []string
+	Localization       json.RawMessage
+	Signature          string
+	SignatureKid       sql.NullString
+	SignatureJws       sql.NullString
+	CanonicalVersion   int16
+	AuthorKid          sql.NullString
+	AuthorSignature    sql.NullString
+}
+
+func (q *Queries) CreateManifestVersion(ctx context.Context, arg CreateManifestVersionParams) error {
+	_, err := q.db.ExecContext(ctx, createManifestVersion,
+		arg.ManifestID,
+		arg.Version,
+		arg.Icon,
+		arg.Category,
+		pq.Array(arg.Tags),
+		arg.AuthorName,
+		arg.AuthorEmail,
+		arg.Ui,
+		arg.Script,
+		arg.Actions,
+		pq.Array(arg.Permissions),
+		pq.Array(arg.PermissionsAdded),
+		pq.Array(arg.PermissionsRemoved),
+		arg.Localization,
+		arg.Signature,
+		arg.SignatureKid,
+		arg.SignatureJws,
+		arg.CanonicalVersion,
+		arg.AuthorKid,
+		arg.AuthorSignature,
+	)
+	return err
+}
+
+const createRevocation = `-- name: CreateRevocation :one
+INSERT INTO revocations (sequence,
+                         manifest_id,
+                         version,
+                         reason)
+SELECT coalesce(max(sequence) + 1, 1),
+       $1,
+       $2,
+       $3
+FROM revocations
+RETURNING sequence, manifest_id, version, reason, revoked_at
+`
+
+type CreateRevocationParams struct {
+	ManifestID uuid.UUID
+	Version    sql.NullString
+	Reason     string
+}
+
+func (q *Queries) CreateRevocation(ctx context.Context, arg CreateRevocationParams) (Revocation, error) {
+	row := q.db.QueryRowContext(ctx, createRevocation, arg.ManifestID, arg.Version, arg.Reason)
+	var i Revocation
+	err := row.Scan(
+		&i.Sequence,
+		&i.ManifestID,
+		&i.Version,
+		&i.Reason,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const deleteLocalizations = `-- name: DeleteLocalizations :exec
+DELETE
+FROM manifest_localizations
+WHERE manifest_id = $1
+`
+
+func (q *Queries) DeleteLocalizations(ctx context.Context, manifestID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteLocalizations, manifestID)
+	return err
+}
+
+const getAuthorKey = `-- name: GetAuthorKey :one
+SELECT kid,
+       author_email,
+       public_key,
+       created_at
+FROM author_keys
+WHERE kid = $1
+`
+
+func (q *Queries) GetAuthorKey(ctx context.Context, kid string) (AuthorKey, error) {
+	row := q.db.QueryRowContext(ctx, getAuthorKey, kid)
+	var i AuthorKey
+	err := row.Scan(
+		&i.Kid,
+		&i.AuthorEmail,
+		&i.PublicKey,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getCatalogVersion = `-- name: GetCatalogVersion :one
+SELECT version
+FROM catalog_state
+`
+
+func (q *Queries) GetCatalogVersion(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getCatalogVersion)
+	var version int64
+	err := row.Scan(&version)
+	return version, err
+}
+
+const getLogLeafIndex = `-- name: GetLogLeafIndex :one
+SELECT leaf_index
+FROM transparency_log
+WHERE leaf_hash = $1
+ORDER BY leaf_index
+LIMIT 1
+`
+
+func (q *Queries) GetLogLeafIndex(ctx context.Context, leafHash []byte) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getLogLeafIndex, leafHash)
+	var leaf_index int64
+	err := row.Scan(&leaf_index)
+	return leaf_index, err
+}
+
 const getManifest = `-- name: GetManifest :one
-WITH localization AS (SELECT ml.manifest_id,
-                             json_object_agg(ml.key, ml.value) AS localization
-                      FROM manifest_localizations ml
-                      WHERE ml.locale = $2::text
-                      GROUP BY ml.manifest_id)
+-- локализации всех локалей: {"en": {...}, "pt-BR": {...}}
+WITH localization AS (SELECT x.manifest_id,
+                             jsonb_object_agg(x.locale, x.strings) AS localizations
+                      FROM (SELECT ml.manifest_id,
+                                   ml.locale,
+                                   jsonb_object_agg(ml.key, ml.value) AS strings
+                            FROM manifest_localizations ml
+                            WHERE ml.manifest_id = $1::uuid
+                            GROUP BY ml.manifest_id, ml.locale) AS x
+                      GROUP BY x.manifest_id)
 SELECT m.id,
        m.version,
        m.icon,
@@ -143,42 +491,60 @@ SELECT m.id,
        m.created_at,
        m.meta_created_at,
        m.signature,
+       m.signature_kid,
+       mv.signature_jws,
+       m.canonical_version,
+       m.author_kid,
+       m.author_signature,
+       m.min_app_version,
+       m.max_app_version,
+       m.os,
+       m.component_types,
        mc.ui AS U_I,
        mc.script,
        mc.actions,
        mc.permissions,
-       l.localization
+       mv.permissions_added,
+       mv.permissions_removed,
+       l.localizations
 FROM manifest m
          LEFT JOIN manifest_content mc ON mc.manifest_id = m.id
+         LEFT JOIN manifest_versions mv ON mv.manifest_id = m.id AND mv.version = m.version
          LEFT JOIN localization l ON l.manifest_id = m.id
 WHERE m.id = $1::uuid
 `
 
-type GetManifestParams struct {
-	ManifestID uuid.UUID
-	Locale     string
-}
-
 type GetManifestRow struct {
-	ID            uuid.UUID
-	Version       string
-	Icon          string
-	Category      string
-	Tags          []string
-	AuthorName    string
-	AuthorEmail   string
-	CreatedAt     time.Time
-	MetaCreatedAt time.Time
-	Signature     string
-	UI            pqtype.NullRawMessage
-	Script        sql.NullString
-	Actions       pqtype.NullRawMessage
-	Permissions   []string
-	Localization  pqtype.NullRawMessage
+	ID                 uuid.UUID
+	Version            string
+	Icon               string
+	Category           string
+	Tags               []string
+	AuthorName         string
+	AuthorEmail        string
+	CreatedAt          time.Time
+	MetaCreatedAt      time.Time
+	Signature          string
+	SignatureKid       sql.NullString
+	SignatureJws       sql.NullString
+	CanonicalVersion   int16
+	AuthorKid          sql.NullString
+	AuthorSignature    sql.NullString
+	MinAppVersion      sql.NullString
+	MaxAppVersion      sql.NullString
+	Os                 []string
+	ComponentTypes     []string
+	UI                 pqtype.NullRawMessage
+	Script             sql.NullString
+	Actions            pqtype.NullRawMessage
+	Permissions        []string
+	PermissionsAdded   []string
+	PermissionsRemoved []string
+	Localizations      pqtype.NullRawMessage
 }
 
-func (q *Queries) GetManifest(ctx context.Context, arg GetManifestParams) (GetManifestRow, error) {
-	row := q.db.QueryRowContext(ctx, getManifest, arg.ManifestID, arg.Locale)
+func (q *Queries) GetManifest(ctx context.Context, manifestID uuid.UUID) (GetManifestRow, error) {
+	row := q.db.QueryRowContext(ctx, getManifest, manifestID)
 	var i GetManifestRow
 	err := row.Scan(
 		&i.ID,
@@ -191,16 +557,27 @@ func (q *Queries) GetManifest(ctx context.Context, arg GetManifestParams) (GetMa
 		&i.CreatedAt,
 		&i.MetaCreatedAt,
 		&i.Signature,
+		&i.SignatureKid,
+		&i.SignatureJws,
+		&i.CanonicalVersion,
+		&i.AuthorKid,
+		&i.AuthorSignature,
+		&i.MinAppVersion,
+		&i.MaxAppVersion,
+		pq.Array(&i.Os),
+		pq.Array(&i.ComponentTypes),
 		&i.UI,
 		&i.Script,
 		&i.Actions,
 		pq.Array(&i.Permissions),
-		&i.Localization,
+		pq.Array(&i.PermissionsAdded),
+		pq.Array(&i.PermissionsRemoved),
+		&i.Localizations,
 	)
 	return i, err
 }
 
-const listManifests = `-- name: ListManifests :many
+const getManifestForUpdate = `-- name: GetManifestForUpdate :one
 SELECT m.id,
        m.version,
        m.icon,
@@ -210,23 +587,18 @@ SELECT m.id,
        m.author_email,
        m.created_at,
        m.meta_created_at,
-       -- 🔽 отдаём **всю** локализацию для запрошенной локали
-       (SELECT jsonb_object_agg(l.key, l.value)
-        FROM manifest_localizations AS l
-        WHERE l.manifest_id = m.id
-          AND l.locale = $3::text) AS localization
-FROM manifest AS m
-ORDER BY m.created_at DESC
-LIMIT $1 OFFSET $2
+       m.signature,
+       mc.ui,
+       mc.script,
+       mc.actions,
+       mc.permissions
+FROM manifest m
+         JOIN manifest_content mc ON mc.manifest_id = m.id
+WHERE m.id = $1::uuid
+    FOR UPDATE OF m
 `
 
-type ListManifestsParams struct {
-	Limit   int64
-	Offset  int64
-	Column3 string
-}
-
-type ListManifestsRow struct {
+type GetManifestForUpdateRow struct {
 	ID            uuid.UUID
 	Version       string
 	Icon          string
@@ -236,29 +608,195 @@ type ListManifestsRow struct {
 	AuthorEmail   string
 	CreatedAt     time.Time
 	MetaCreatedAt time.Time
-	Localization  json.RawMessage
+	Signature     string
+	Ui            json.RawMessage
+	Script        string
+	Actions       json.RawMessage
+	Permissions   []string
 }
 
-func (q *Queries) ListManifests(ctx context.Context, arg ListManifestsParams) ([]ListManifestsRow, error) {
-	rows, err := q.db.QueryContext(ctx, listManifests, arg.Limit, arg.Offset, arg.Column3)
+func (q *Queries) GetManifestForUpdate(ctx context.Context, manifestID uuid.UUID) (GetManifestForUpdateRow, error) {
+	row := q.db.QueryRowContext(ctx, getManifestForUpdate, manifestID)
+	var i GetManifestForUpdateRow
+	err := row.Scan(
+		&i.ID,
+		&i.Version,
+		&i.Icon,
+		&i.Category,
+		pq.Array(&i.Tags),
+		&i.AuthorName,
+		&i.AuthorEmail,
+		&i.CreatedAt,
+		&i.MetaCreatedAt,
+		&i.Signature,
+		&i.Ui,
+		&i.Script,
+		&i.Actions,
+		pq.Array(&i.Permissions),
+	)
+	return i, err
+}
+
+const getManifestRevocation = `-- name: GetManifestRevocation :one
+-- отзыв всего манифеста важнее отзыва отдельной версии
+SELECT sequence,
+       manifest_id,
+       version,
+       reason,
+       revoked_at
+FROM revocations
+WHERE manifest_id = $1
+  AND (version IS NULL OR version = $2::text)
+ORDER BY version NULLS FIRST
+LIMIT 1
+`
+
+type GetManifestRevocationParams struct {
+	ManifestID uuid.UUID
+	Version    string
+}
+
+func (q *Queries) GetManifestRevocation(ctx context.Context, arg GetManifestRevocationParams) (Revocation, error) {
+	row := q.db.QueryRowContext(ctx, getManifestRevocation, arg.ManifestID, arg.Version)
+	var i Revocation
+	err := row.Scan(
+		&i.Sequence,
+		&i.ManifestID,
+		&i.Version,
+		&i.Reason,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const getManifestVersion = `-- name: GetManifestVersion :one
+SELECT m.id,
+       mv.version,
+       mv.icon,
+       mv.category,
+       mv.tags,
+       mv.author_name,
+       mv.author_email,
+       m.created_at,
+       m.meta_created_at,
+       mv.created_at AS version_created_at,
+       mv.signature,
+       mv.signature_kid,
+       mv.signature_jws,
+       mv.canonical_version,
+       mv.author_kid,
+       mv.author_signature,
+       mv.ui,
+       mv.script,
+       mv.actions,
+       mv.permissions,
+       mv.permissions_added,
+       mv.permissions_removed,
+       mv.localization
+FROM manifest_versions mv
+         JOIN manifest m ON m.id = mv.manifest_id
+WHERE mv.manifest_id = $1::uuid
+  AND mv.version = $2::text
+`
+
+type GetManifestVersionParams struct {
+	ManifestID uuid.UUID
+	Version    string
+}
+
+type GetManifestVersionRow struct {
+	ID                 uuid.UUID
+	Version            string
+	Icon               string
+	Category           string
+	Tags               []string
+	AuthorName         string
+	AuthorEmail        string
+	CreatedAt          time.Time
+	MetaCreatedAt      time.Time
+	VersionCreatedAt   time.Time
+	Signature          string
+	SignatureKid       sql.NullString
+	SignatureJws       sql.NullString
+	CanonicalVersion   int16
+	AuthorKid          sql.NullString
+	AuthorSignature    sql.NullString
+	Ui                 json.RawMessage
+	Script             string
+	Actions            json.RawMessage
+	Permissions        []string
+	PermissionsAdded   []string
+	PermissionsRemoved []string
+	Localization       json.RawMessage
+}
+
+func (q *Queries) GetManifestVersion(ctx context.Context, arg GetManifestVersionParams) (GetManifestVersionRow, error) {
+	row := q.db.QueryRowContext(ctx, getManifestVersion, arg.ManifestID, arg.Version)
+	var i GetManifestVersionRow
+	err := row.Scan(
+		&i.ID,
+		&i.Version,
+		&i.Icon,
+		&i.Category,
+		pq.Array(&i.Tags),
+		&i.AuthorName,
+		&i.AuthorEmail,
+		&i.CreatedAt,
+		&i.MetaCreatedAt,
+		&i.VersionCreatedAt,
+		&i.Signature,
+		&i.SignatureKid,
+		&i.SignatureJws,
+		&i.CanonicalVersion,
+		&i.AuthorKid,
+		&i.AuthorSignature,
+		&i.Ui,
+		&i.Script,
+		&i.Actions,
+		pq.Array(&i.Permissions),
+		pq.Array(&i.PermissionsAdded),
+		pq.Array(&i.PermissionsRemoved),
+		&i.Localization,
+	)
+	return i, err
+}
+
+const getRevocationSequence = `-- name: GetRevocationSequence :one
+SELECT coalesce(max(sequence), 0)::bigint AS sequence
+FROM revocations
+`
+
+func (q *Queries) GetRevocationSequence(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getRevocationSequence)
+	var sequence int64
+	err := row.Scan(&sequence)
+	return sequence, err
+}
+
+const listAuthorKeys = `-- name: ListAuthorKeys :many
+SELECT kid,
+       author_email,
+       public_key,
+       created_at
+FROM author_keys
+WHERE lower(author_email) = lower($1::text)
+ORDER BY created_at, kid
+`
+
+func (q *Queries) ListAuthorKeys(ctx context.Context, authorEmail string) ([]AuthorKey, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorKeys, authorEmail)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListManifestsRow
+	var items []AuthorKey
 	for rows.Next() {
-		var i ListManifestsRow
+		var i AuthorKey
 		if err := rows.Scan(
-			&i.ID,
-			&i.Version,
-			&i.Icon,
-			&i.Category,
-			pq.Array(&i.Tags),
-			&i.AuthorName,
+			&i.Kid,
 			&i.AuthorEmail,
+			&i.PublicKey,
 			&i.CreatedAt,
-			&i.MetaCreatedAt,
-			&i.Localization,
 		); err != nil {
 			return nil, err
 		}
@@ -273,7 +811,8 @@ func (q *Queries) ListManifests(ctx context.Context, arg ListManifestsParams) ([
 	return items, nil
 }
 
-const searchManifests = `-- name: SearchManifests :many
+const listCatalogManifests = `-- name: ListCatalogManifests :many
+-- текущие версии всех манифестов с содержимым, из которого пересобирается подписанный payload
 SELECT m.id,
        m.version,
        m.icon,
@@ -282,59 +821,51 @@ SELECT m.id,
        m.author_name,
        m.author_email,
        m.created_at,
-       m.meta_created_at
+       m.canonical_version,
+       m.author_kid,
+       m.author_signature,
+       mc.ui,
+       mc.script,
+       mc.actions,
+       mc.permissions,
+       (SELECT jsonb_object_agg(x.locale, x.strings)
+        FROM (SELECT l.locale, jsonb_object_agg(l.key, l.value) AS strings
+              FROM manifest_localizations AS l
+              WHERE l.manifest_id = m.id
+              GROUP BY l.locale) AS x) AS localizations
 FROM manifest m
-         LEFT JOIN manifest_localizations t
-                   ON t.manifest_id = m.id
-                       AND t.locale = $1
-                       AND t.key = 'title'
-         LEFT JOIN manifest_localizations d
-                   ON d.manifest_id = m.id
-                       AND d.locale = $1
-                       AND d.key = 'description'
-WHERE (
-          -- empty search string => return everything
-          ($2::text = '')
-              -- full-text on title+description
-              OR to_tsvector('english',
-                             coalesce(t.value, '') || ' ' || coalesce(d.value, '')
-                 ) @@ plainto_tsquery('english', $2::text)
-              -- category ilike
-              OR m.category ILIKE '%' || $2::text || '%'
-              -- any tag matches
-              OR EXISTS (SELECT 1
-                         FROM unnest(m.tags) AS tag
-                         WHERE tag ILIKE '%' || $2::text || '%')
-          )
-ORDER BY m.created_at DESC
+         JOIN manifest_content mc ON mc.manifest_id = m.id
+ORDER BY m.id
 `
 
-type SearchManifestsParams struct {
-	Locale string
-	Search string
-}
-
-type SearchManifestsRow struct {
-	ID            uuid.UUID
-	Version       string
-	Icon          string
-	Category      string
-	Tags          []string
-	AuthorName    string
-	AuthorEmail   string
-	CreatedAt     time.Time
-	MetaCreatedAt time.Time
+type ListCatalogManifestsRow struct {
+	ID               uuid.UUID
+	Version          string
+	Icon             string
+	Category         string
+	Tags             []string
+	AuthorName       string
+	AuthorEmail      string
+	CreatedAt        time.Time
+	CanonicalVersion int16
+	AuthorKid        sql.NullString
+	AuthorSignature  sql.NullString
+	Ui               json.RawMessage
+	Script           string
+	Actions          json.RawMessage
+	Permissions      []string
+	Localizations    pqtype.NullRawMessage
 }
 
-func (q *Queries) SearchManifests(ctx context.Context, arg SearchManifestsParams) ([]SearchManifestsRow, error) {
-	rows, err := q.db.QueryContext(ctx, searchManifests, arg.Locale, arg.Search)
+func (q *Queries) ListCatalogManifests(ctx context.Context) ([]ListCatalogManifestsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCatalogManifests)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []SearchManifestsRow
+	var items []ListCatalogManifestsRow
 	for rows.Next() {
-		var i SearchManifestsRow
+		var i ListCatalogManifestsRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Version,
@@ -344,7 +875,14 @@ func (q *Queries) SearchManifests(ctx context.Context, arg SearchManifestsParams
 			&i.AuthorName,
 			&i.AuthorEmail,
 			&i.CreatedAt,
-			&i.MetaCreatedAt,
+			&i.CanonicalVersion,
+			&i.AuthorKid,
+			&i.AuthorSignature,
+			&i.Ui,
+			&i.Script,
+			&i.Actions,
+			pq.Array(&i.Permissions),
+			&i.Localizations,
 		); err != nil {
 			return nil, err
 		}
@@ -359,65 +897,961 @@ func (q *Queries) SearchManifests(ctx context.Context, arg SearchManifestsParams
 	return items, nil
 }
 
-const searchManifestsFTS = `-- name: SearchManifestsFTS :many
-SELECT m.id,
-       m.version,
-       m.icon,
-       m.category,
-       m.tags,
-       m.author_name,
-       m.author_email,
-       m.created_at,
-       m.meta_created_at,
-       (SELECT jsonb_object_agg(l.key, l.value)
-        FROM manifest_localizations AS l
-        WHERE l.manifest_id = m.id
-          AND l.locale = $1::text) AS localization
-FROM manifest AS m
-WHERE to_tsvector($2::regconfig,
-                  (SELECT string_agg(l.value, ' ')
-                   FROM manifest_localizations AS l
-                   WHERE l.manifest_id = m.id
-                     AND l.locale = $1::text
-                     AND l.key IN ('title', 'description')) || ' ' ||
-                  m.category || ' ' ||
-                  array_to_string(m.tags, ' ')
-      )
-          @@ plainto_tsquery($2::regconfig, $3::text)
-ORDER BY m.created_at DESC
+const listLocalizations = `-- name: ListLocalizations :many
+SELECT locale,
+       key,
+       value
+FROM manifest_localizations
+WHERE manifest_id = $1
+ORDER BY locale, key
 `
 
-type SearchManifestsFTSParams struct {
+type ListLocalizationsRow struct {
 	Locale string
-	Config interface{}
-	Query  string
-}
-
-type SearchManifestsFTSRow struct {
-	ID            uuid.UUID
-	Version       string
-	Icon          string
-	Category      string
-	Tags          []string
-	AuthorName    string
-	AuthorEmail   string
-	CreatedAt     time.Time
-	MetaCreatedAt time.Time
-	Localization  json.RawMessage
+	Key    string
+	Value  string
 }
 
-func (q *Queries) SearchManifestsFTS(ctx context.Context, arg SearchManifestsFTSParams) ([]SearchManifestsFTSRow, error) {
-	rows, err := q.db.QueryContext(ctx, searchManifestsFTS, arg.Locale, arg.Config, arg.Query)
+func (q *Queries) ListLocalizations(ctx context.Context, manifestID uuid.UUID) ([]ListLocalizationsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listLocalizations, manifestID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []SearchManifestsFTSRow
+	var items []ListLocalizationsRow
 	for rows.Next() {
-		var i SearchManifestsFTSRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.Version,
+		var i ListLocalizationsRow
+		if err := rows.Scan(&i.Locale, &i.Key, &i.Value); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLocales = `-- name: ListLocales :many
+-- все локали каталога: из них matcher строит цепочку для сортировки и поиска
+SELECT DISTINCT locale
+FROM manifest_localizations
+ORDER BY locale
+`
+
+func (q *Queries) ListLocales(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listLocales)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var locale string
+		if err := rows.Scan(&locale); err != nil {
+			return nil, err
+		}
+		items = append(items, locale)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLogEntries = `-- name: ListLogEntries :many
+SELECT leaf_index,
+       leaf_data,
+       created_at
+FROM transparency_log
+WHERE leaf_index >= $1
+  AND leaf_index < $2
+ORDER BY leaf_index
+`
+
+type ListLogEntriesParams struct {
+	StartIndex int64
+	EndIndex   int64
+}
+
+type ListLogEntriesRow struct {
+	LeafIndex int64
+	LeafData  []byte
+	CreatedAt time.Time
+}
+
+func (q *Queries) ListLogEntries(ctx context.Context, arg ListLogEntriesParams) ([]ListLogEntriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listLogEntries, arg.StartIndex, arg.EndIndex)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListLogEntriesRow
+	for rows.Next() {
+		var i ListLogEntriesRow
+		if err := rows.Scan(&i.LeafIndex, &i.LeafData, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLogLeafHashes = `-- name: ListLogLeafHashes :many
+SELECT leaf_index,
+       leaf_hash
+FROM transparency_log
+WHERE leaf_index >= $1
+ORDER BY leaf_index
+`
+
+type ListLogLeafHashesRow struct {
+	LeafIndex int64
+	LeafHash  []byte
+}
+
+func (q *Queries) ListLogLeafHashes(ctx context.Context, fromIndex int64) ([]ListLogLeafHashesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listLogLeafHashes, fromIndex)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListLogLeafHashesRow
+	for rows.Next() {
+		var i ListLogLeafHashesRow
+		if err := rows.Scan(&i.LeafIndex, &i.LeafHash); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listManifestVersions = `-- name: ListManifestVersions :many
+SELECT mv.version,
+       mv.signature,
+       mv.signature_kid,
+       mv.canonical_version,
+       mv.permissions_added,
+       mv.permissions_removed,
+       mv.created_at
+FROM manifest_versions mv
+WHERE mv.manifest_id = $1::uuid
+ORDER BY mv.created_at DESC
+`
+
+type ListManifestVersionsRow struct {
+	Version            string
+	Signature          string
+	SignatureKid       sql.NullString
+	CanonicalVersion   int16
+	PermissionsAdded   []string
+	PermissionsRemoved []string
+	CreatedAt          time.Time
+}
+
+func (q *Queries) ListManifestVersions(ctx context.Context, manifestID uuid.UUID) ([]ListManifestVersionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listManifestVersions, manifestID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListManifestVersionsRow
+	for rows.Next() {
+		var i ListManifestVersionsRow
+		if err := rows.Scan(
+			&i.Version,
+			&i.Signature,
+			&i.SignatureKid,
+			&i.CanonicalVersion,
+			pq.Array(&i.PermissionsAdded),
+			pq.Array(&i.PermissionsRemoved),
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listManifests = `-- name: ListManifests :many
+-- сортировка newest: ORDER BY совпадает с idx_manifest_created_at_id, страница
+-- читается по индексу и останавливается на LIMIT
+SELECT m.id,
+       m.version,
+       m.icon,
+       m.category,
+       m.tags,
+       m.author_name,
+       m.author_email,
+       m.created_at,
+       m.meta_created_at,
+       -- 🔽 отдаём локализации **всех** локалей: выбор и откат по ключам делает сервис
+       (SELECT jsonb_object_agg(x.locale, x.strings)
+        FROM (SELECT l.locale, jsonb_object_agg(l.key, l.value) AS strings
+              FROM manifest_localizations AS l
+              WHERE l.manifest_id = m.id
+              GROUP BY l.locale) AS x) AS localizations,
+       -- ключ title нужен только сортировке по title
+       ''::text AS sort_title
+FROM manifest AS m
+         JOIN manifest_content AS c ON c.manifest_id = m.id
+WHERE ($1::text IS NULL OR m.category = $1::text)
+  AND ($2::text[] IS NULL OR m.tags && $2::text[])
+  AND ($3::text[] IS NULL OR m.tags @> $3::text[])
+  AND ($4::text[] IS NULL
+    OR NOT c.permissions && $4::text[])
+  AND ($5::text IS NULL
+    OR lower(m.author_name) = lower($5::text)
+    OR lower(m.author_email) = lower($5::text))
+  AND ($6::timestamptz IS NULL OR m.created_at >= $6::timestamptz)
+  AND ($7::timestamptz IS NULL OR m.created_at < $7::timestamptz)
+  -- несовместимые с заявленными возможностями клиента манифесты не показываем
+  AND manifest_compatible(m.id,
+                          $8::text,
+                          $9::text,
+                          $10::text[],
+                          $11::text[])
+  -- keyset: строки строго «после» курсора
+  AND ($12::uuid IS NULL
+    OR (m.created_at, m.id) < ($13::timestamptz, $12::uuid))
+ORDER BY m.created_at DESC, m.id DESC
+LIMIT $14
+`
+
+type ListManifestsParams struct {
+	Category           sql.NullString
+	TagsAny            []string
+	TagsAll            []string
+	ExcludePermissions []string
+	Author             sql.NullString
+	CreatedAfter       sql.NullTime
+	CreatedBefore      sql.NullTime
+	ClientAppVersion   sql.NullString
+	ClientOs           sql.NullString
+	ClientComponents   []string
+	ClientPermissions  []string
+	CursorID           uuid.NullUUID
+	CursorCreatedAt    sql.NullTime
+	Limit              int64
+}
+
+type ListManifestsRow struct {
+	ID            uuid.UUID
+	Version       string
+	Icon          string
+	Category      string
+	Tags          []string
+	AuthorName    string
+	AuthorEmail   string
+	CreatedAt     time.Time
+	MetaCreatedAt time.Time
+	Localizations json.RawMessage
+	SortTitle     string
+}
+
+func (q *Queries) ListManifests(ctx context.Context, arg ListManifestsParams) ([]ListManifestsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listManifests,
+		arg.Category,
+		pq.Array(arg.TagsAny),
+		pq.Array(arg.TagsAll),
+		pq.Array(arg.ExcludePermissions),
+		arg.Author,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.ClientAppVersion,
+		arg.ClientOs,
+		pq.Array(arg.ClientComponents),
+		pq.Array(arg.ClientPermissions),
+		arg.CursorID,
+		arg.CursorCreatedAt,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListManifestsRow
+	for rows.Next() {
+		var i ListManifestsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Version,
+			&i.Icon,
+			&i.Category,
+			pq.Array(&i.Tags),
+			&i.AuthorName,
+			&i.AuthorEmail,
+			&i.CreatedAt,
+			&i.MetaCreatedAt,
+			&i.Localizations,
+			&i.SortTitle,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listManifestsByTitle = `-- name: ListManifestsByTitle :many
+-- сортировка title: ключ зависит от Accept-Language и в индексе не хранится, поэтому
+-- страница — top-N сортировка отфильтрованных строк; заголовки по цепочке локалей
+-- читаются из idx_manifest_localizations_title без обращения к таблице
+WITH listed AS (SELECT m.id,
+                       m.version,
+                       m.icon,
+                       m.category,
+                       m.tags,
+                       m.author_name,
+                       m.author_email,
+                       m.created_at,
+                       m.meta_created_at,
+                       -- ключ сортировки title: первый заголовок по цепочке отката локалей
+                       coalesce((SELECT l.value
+                                 FROM manifest_localizations AS l
+                                 WHERE l.manifest_id = m.id
+                                   AND l.key = 'title'
+                                   AND l.locale = ANY ($1::text[])
+                                 ORDER BY array_position($1::text[], l.locale)
+                                 LIMIT 1), '')::text AS sort_title
+                FROM manifest AS m
+                         JOIN manifest_content AS c ON c.manifest_id = m.id
+                WHERE ($2::text IS NULL OR m.category = $2::text)
+                  AND ($3::text[] IS NULL OR m.tags && $3::text[])
+                  AND ($4::text[] IS NULL OR m.tags @> $4::text[])
+                  AND ($5::text[] IS NULL
+                    OR NOT c.permissions && $5::text[])
+                  AND ($6::text IS NULL
+                    OR lower(m.author_name) = lower($6::text)
+                    OR lower(m.author_email) = lower($6::text))
+                  AND ($7::timestamptz IS NULL OR m.created_at >= $7::timestamptz)
+                  AND ($8::timestamptz IS NULL OR m.created_at < $8::timestamptz)
+                  -- несовместимые с заявленными возможностями клиента манифесты не показываем
+                  AND manifest_compatible(m.id,
+                                          $9::text,
+                                          $10::text,
+                                          $11::text[],
+                                          $12::text[]))
+SELECT id,
+       version,
+       icon,
+       category,
+       tags,
+       author_name,
+       author_email,
+       created_at,
+       meta_created_at,
+       -- 🔽 отдаём локализации **всех** локалей: выбор и откат по ключам делает сервис
+       (SELECT jsonb_object_agg(x.locale, x.strings)
+        FROM (SELECT l.locale, jsonb_object_agg(l.key, l.value) AS strings
+              FROM manifest_localizations AS l
+              WHERE l.manifest_id = listed.id
+              GROUP BY l.locale) AS x) AS localizations,
+       sort_title
+FROM listed
+-- keyset: строки строго «после» курсора
+WHERE $13::uuid IS NULL
+   OR (sort_title, id) > ($14::text, $13::uuid)
+ORDER BY sort_title, id
+LIMIT $15
+`
+
+type ListManifestsByTitleParams struct {
+	Locales            []string
+	Category           sql.NullString
+	TagsAny            []string
+	TagsAll            []string
+	ExcludePermissions []string
+	Author             sql.NullString
+	CreatedAfter       sql.NullTime
+	CreatedBefore      sql.NullTime
+	ClientAppVersion   sql.NullString
+	ClientOs           sql.NullString
+	ClientComponents   []string
+	ClientPermissions  []string
+	CursorID           uuid.NullUUID
+	CursorTitle        sql.NullString
+	Limit              int64
+}
+
+type ListManifestsByTitleRow struct {
+	ID            uuid.UUID
+	Version       string
+	Icon          string
+	Category      string
+	Tags          []string
+	AuthorName    string
+	AuthorEmail   string
+	CreatedAt     time.Time
+	MetaCreatedAt time.Time
+	Localizations json.RawMessage
+	SortTitle     string
+}
+
+func (q *Queries) ListManifestsByTitle(ctx context.Context, arg ListManifestsByTitleParams) ([]ListManifestsByTitleRow, error) {
+	rows, err := q.db.QueryContext(ctx, listManifestsByTitle,
+		pq.Array(arg.Locales),
+		arg.Category,
+		pq.Array(arg.TagsAny),
+		pq.Array(arg.TagsAll),
+		pq.Array(arg.ExcludePermissions),
+		arg.Author,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.ClientAppVersion,
+		arg.ClientOs,
+		pq.Array(arg.ClientComponents),
+		pq.Array(arg.ClientPermissions),
+		arg.CursorID,
+		arg.CursorTitle,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListManifestsByTitleRow
+	for rows.Next() {
+		var i ListManifestsByTitleRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Version,
+			&i.Icon,
+			&i.Category,
+			pq.Array(&i.Tags),
+			&i.AuthorName,
+			&i.AuthorEmail,
+			&i.CreatedAt,
+			&i.MetaCreatedAt,
+			&i.Localizations,
+			&i.SortTitle,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listManifestsByTitleOffset = `-- name: ListManifestsByTitleOffset :many
+-- страница по offset для старых клиентов; курсорные страницы OFFSET не используют
+WITH listed AS (SELECT m.id,
+                       m.version,
+                       m.icon,
+                       m.category,
+                       m.tags,
+                       m.author_name,
+                       m.author_email,
+                       m.created_at,
+                       m.meta_created_at,
+                       -- ключ сортировки title: первый заголовок по цепочке отката локалей
+                       coalesce((SELECT l.value
+                                 FROM manifest_localizations AS l
+                                 WHERE l.manifest_id = m.id
+                                   AND l.key = 'title'
+                                   AND l.locale = ANY ($1::text[])
+                                 ORDER BY array_position($1::text[], l.locale)
+                                 LIMIT 1), '')::text AS sort_title
+                FROM manifest AS m
+                         JOIN manifest_content AS c ON c.manifest_id = m.id
+                WHERE ($2::text IS NULL OR m.category = $2::text)
+                  AND ($3::text[] IS NULL OR m.tags && $3::text[])
+                  AND ($4::text[] IS NULL OR m.tags @> $4::text[])
+                  AND ($5::text[] IS NULL
+                    OR NOT c.permissions && $5::text[])
+                  AND ($6::text IS NULL
+                    OR lower(m.author_name) = lower($6::text)
+                    OR lower(m.author_email) = lower($6::text))
+                  AND ($7::timestamptz IS NULL OR m.created_at >= $7::timestamptz)
+                  AND ($8::timestamptz IS NULL OR m.created_at < $8::timestamptz)
+                  -- несовместимые с заявленными возможностями клиента манифесты не показываем
+                  AND manifest_compatible(m.id,
+                                          $9::text,
+                                          $10::text,
+                                          $11::text[],
+                                          $12::text[]))
+SELECT id,
+       version,
+       icon,
+       category,
+       tags,
+       author_name,
+       author_email,
+       created_at,
+       meta_created_at,
+       -- 🔽 отдаём локализации **всех** локалей: выбор и откат по ключам делает сервис
+       (SELECT jsonb_object_agg(x.locale, x.strings)
+        FROM (SELECT l.locale, jsonb_object_agg(l.key, l.value) AS strings
+              FROM manifest_localizations AS l
+              WHERE l.manifest_id = listed.id
+              GROUP BY l.locale) AS x) AS localizations,
+       sort_title
+FROM listed
+ORDER BY sort_title, id
+LIMIT $13 OFFSET $14
+`
+
+type ListManifestsByTitleOffsetParams struct {
+	Locales            []string
+	Category           sql.NullString
+	TagsAny            []string
+	TagsAll            []string
+	ExcludePermissions []string
+	Author             sql.NullString
+	CreatedAfter       sql.NullTime
+	CreatedBefore      sql.NullTime
+	ClientAppVersion   sql.NullString
+	ClientOs           sql.NullString
+	ClientComponents   []string
+	ClientPermissions  []string
+	Limit              int64
+	Offset             int64
+}
+
+type ListManifestsByTitleOffsetRow struct {
+	ID            uuid.UUID
+	Version       string
+	Icon          string
+	Category      string
+	Tags          []string
+	AuthorName    string
+	AuthorEmail   string
+	CreatedAt     time.Time
+	MetaCreatedAt time.Time
+	Localizations json.RawMessage
+	SortTitle     string
+}
+
+func (q *Queries) ListManifestsByTitleOffset(ctx context.Context, arg ListManifestsByTitleOffsetParams) ([]ListManifestsByTitleOffsetRow, error) {
+	rows, err := q.db.QueryContext(ctx, listManifestsByTitleOffset,
+		pq.Array(arg.Locales),
+		arg.Category,
+		pq.Array(arg.TagsAny),
+		pq.Array(arg.TagsAll),
+		pq.Array(arg.ExcludePermissions),
+		arg.Author,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.ClientAppVersion,
+		arg.ClientOs,
+		pq.Array(arg.ClientComponents),
+		pq.Array(arg.ClientPermissions),
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListManifestsByTitleOffsetRow
+	for rows.Next() {
+		var i ListManifestsByTitleOffsetRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Version,
+			&i.Icon,
+			&i.Category,
+			pq.Array(&i.Tags),
+			&i.AuthorName,
+			&i.AuthorEmail,
+			&i.CreatedAt,
+			&i.MetaCreatedAt,
+			&i.Localizations,
+			&i.SortTitle,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listManifestsOffset = `-- name: ListManifestsOffset :many
+-- страница по offset для старых клиентов; курсорные страницы OFFSET не используют
+SELECT m.id,
+       m.version,
+       m.icon,
+       m.category,
+       m.tags,
+       m.author_name,
+       m.author_email,
+       m.created_at,
+       m.meta_created_at,
+       -- 🔽 отдаём локализации **всех** локалей: выбор и откат по ключам делает сервис
+       (SELECT jsonb_object_agg(x.locale, x.strings)
+        FROM (SELECT l.locale, jsonb_object_agg(l.key, l.value) AS strings
+              FROM manifest_localizations AS l
+              WHERE l.manifest_id = m.id
+              GROUP BY l.locale) AS x) AS localizations,
+       -- ключ title нужен только сортировке по title
+       ''::text AS sort_title
+FROM manifest AS m
+         JOIN manifest_content AS c ON c.manifest_id = m.id
+WHERE ($1::text IS NULL OR m.category = $1::text)
+  AND ($2::text[] IS NULL OR m.tags && $2::text[])
+  AND ($3::text[] IS NULL OR m.tags @> $3::text[])
+  AND ($4::text[] IS NULL
+    OR NOT c.permissions && $4::text[])
+  AND ($5::text IS NULL
+    OR lower(m.author_name) = lower($5::text)
+    OR lower(m.author_email) = lower($5::text))
+  AND ($6::timestamptz IS NULL OR m.created_at >= $6::timestamptz)
+  AND ($7::timestamptz IS NULL OR m.created_at < $7::timestamptz)
+  -- несовместимые с заявленными возможностями клиента манифесты не показываем
+  AND manifest_compatible(m.id,
+                          $8::text,
+                          $9::text,
+                          $10::text[],
+                          $11::text[])
+ORDER BY m.created_at DESC, m.id DESC
+LIMIT $12 OFFSET $13
+`
+
+type ListManifestsOffsetParams struct {
+	Category           sql.NullString
+	TagsAny            []string
+	TagsAll            []string
+	ExcludePermissions []string
+	Author             sql.NullString
+	CreatedAfter       sql.NullTime
+	CreatedBefore      sql.NullTime
+	ClientAppVersion   sql.NullString
+	ClientOs           sql.NullString
+	ClientComponents   []string
+	ClientPermissions  []string
+	Limit              int64
+	Offset             int64
+}
+
+type ListManifestsOffsetRow struct {
+	ID            uuid.UUID
+	Version       string
+	Icon          string
+	Category      string
+	Tags          []string
+	AuthorName    string
+	AuthorEmail   string
+	CreatedAt     time.Time
+	MetaCreatedAt time.Time
+	Localizations json.RawMessage
+	SortTitle     string
+}
+
+func (q *Queries) ListManifestsOffset(ctx context.Context, arg ListManifestsOffsetParams) ([]ListManifestsOffsetRow, error) {
+	rows, err := q.db.QueryContext(ctx, listManifestsOffset,
+		arg.Category,
+		pq.Array(arg.TagsAny),
+		pq.Array(arg.TagsAll),
+		pq.Array(arg.ExcludePermissions),
+		arg.Author,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.ClientAppVersion,
+		arg.ClientOs,
+		pq.Array(arg.ClientComponents),
+		pq.Array(arg.ClientPermissions),
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListManifestsOffsetRow
+	for rows.Next() {
+		var i ListManifestsOffsetRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Version,
+			&i.Icon,
+			&i.Category,
+			pq.Array(&i.Tags),
+			&i.AuthorName,
+			&i.AuthorEmail,
+			&i.CreatedAt,
+			&i.MetaCreatedAt,
+			&i.Localizations,
+			&i.SortTitle,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listManifestsOldest = `-- name: ListManifestsOldest :many
+-- сортировка oldest: тот же idx_manifest_created_at_id, прочитанный в обратном порядке
+SELECT m.id,
+       m.version,
+       m.icon,
+       m.category,
+       m.tags,
+       m.author_name,
+       m.author_email,
+       m.created_at,
+       m.meta_created_at,
+       -- 🔽 отдаём локализации **всех** локалей: выбор и откат по ключам делает сервис
+       (SELECT jsonb_object_agg(x.locale, x.strings)
+        FROM (SELECT l.locale, jsonb_object_agg(l.key, l.value) AS strings
+              FROM manifest_localizations AS l
+              WHERE l.manifest_id = m.id
+              GROUP BY l.locale) AS x) AS localizations,
+       -- ключ title нужен только сортировке по title
+       ''::text AS sort_title
+FROM manifest AS m
+         JOIN manifest_content AS c ON c.manifest_id = m.id
+WHERE ($1::text IS NULL OR m.category = $1::text)
+  AND ($2::text[] IS NULL OR m.tags && $2::text[])
+  AND ($3::text[] IS NULL OR m.tags @> $3::text[])
+  AND ($4::text[] IS NULL
+    OR NOT c.permissions && $4::text[])
+  AND ($5::text IS NULL
+    OR lower(m.author_name) = lower($5::text)
+    OR lower(m.author_email) = lower($5::text))
+  AND ($6::timestamptz IS NULL OR m.created_at >= $6::timestamptz)
+  AND ($7::timestamptz IS NULL OR m.created_at < $7::timestamptz)
+  -- несовместимые с заявленными возможностями клиента манифесты не показываем
+  AND manifest_compatible(m.id,
+                          $8::text,
+                          $9::text,
+                          $10::text[],
+                          $11::text[])
+  -- keyset: строки строго «после» курсора
+  AND ($12::uuid IS NULL
+    OR (m.created_at, m.id) > ($13::timestamptz, $12::uuid))
+ORDER BY m.created_at, m.id
+LIMIT $14
+`
+
+type ListManifestsOldestParams struct {
+	Category           sql.NullString
+	TagsAny            []string
+	TagsAll            []string
+	ExcludePermissions []string
+	Author             sql.NullString
+	CreatedAfter       sql.NullTime
+	CreatedBefore      sql.NullTime
+	ClientAppVersion   sql.NullString
+	ClientOs           sql.NullString
+	ClientComponents   []string
+	ClientPermissions  []string
+	CursorID           uuid.NullUUID
+	CursorCreatedAt    sql.NullTime
+	Limit              int64
+}
+
+type ListManifestsOldestRow struct {
+	ID            uuid.UUID
+	Version       string
+	Icon          string
+	Category      string
+	Tags          []string
+	AuthorName    string
+	AuthorEmail   string
+	CreatedAt     time.Time
+	MetaCreatedAt time.Time
+	Localizations json.RawMessage
+	SortTitle     string
+}
+
+func (q *Queries) ListManifestsOldest(ctx context.Context, arg ListManifestsOldestParams) ([]ListManifestsOldestRow, error) {
+	rows, err := q.db.QueryContext(ctx, listManifestsOldest,
+		arg.Category,
+		pq.Array(arg.TagsAny),
+		pq.Array(arg.TagsAll),
+		pq.Array(arg.ExcludePermissions),
+		arg.Author,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.ClientAppVersion,
+		arg.ClientOs,
+		pq.Array(arg.ClientComponents),
+		pq.Array(arg.ClientPermissions),
+		arg.CursorID,
+		arg.CursorCreatedAt,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListManifestsOldestRow
+	for rows.Next() {
+		var i ListManifestsOldestRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Version,
+			&i.Icon,
+			&i.Category,
+			pq.Array(&i.Tags),
+			&i.AuthorName,
+			&i.AuthorEmail,
+			&i.CreatedAt,
+			&i.MetaCreatedAt,
+			&i.Localizations,
+			&i.SortTitle,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listManifestsOldestOffset = `-- name: ListManifestsOldestOffset :many
+-- страница по offset для старых клиентов; курсорные страницы OFFSET не используют
+SELECT m.id,
+       m.version,
+       m.icon,
+       m.category,
+       m.tags,
+       m.author_name,
+       m.author_email,
+       m.created_at,
+       m.meta_created_at,
+       -- 🔽 отдаём локализации **всех** локалей: выбор и откат по ключам делает сервис
+       (SELECT jsonb_object_agg(x.locale, x.strings)
+        FROM (SELECT l.locale, jsonb_object_agg(l.key, l.value) AS strings
+              FROM manifest_localizations AS l
+              WHERE l.manifest_id = m.id
+              GROUP BY l.locale) AS x) AS localizations,
+       -- ключ title нужен только сортировке по title
+       ''::text AS sort_title
+FROM manifest AS m
+         JOIN manifest_content AS c ON c.manifest_id = m.id
+WHERE ($1::text IS NULL OR m.category = $1::text)
+  AND ($2::text[] IS NULL OR m.tags && $2::text[])
+  AND ($3::text[] IS NULL OR m.tags @> $3::text[])
+  AND ($4::text[] IS NULL
+    OR NOT c.permissions && $4::text[])
+  AND ($5::text IS NULL
+    OR lower(m.author_name) = lower($5::text)
+    OR lower(m.author_email) = lower($5::text))
+  AND ($6::timestamptz IS NULL OR m.created_at >= $6::timestamptz)
+  AND ($7::timestamptz IS NULL OR m.created_at < $7::timestamptz)
+  -- несовместимые с заявленными возможностями клиента манифесты не показываем
+  AND manifest_compatible(m.id,
+                          $8::text,
+                          $9::text,
+                          $10::text[],
+                          $11::text[])
+ORDER BY m.created_at, m.id
+LIMIT $12 OFFSET $13
+`
+
+type ListManifestsOldestOffsetParams struct {
+	Category           sql.NullString
+	TagsAny            []string
+	TagsAll            []string
+	ExcludePermissions []string
+	Author             sql.NullString
+	CreatedAfter       sql.NullTime
+	CreatedBefore      sql.NullTime
+	ClientAppVersion   sql.NullString
+	ClientOs           sql.NullString
+	ClientComponents   []string
+	ClientPermissions  []string
+	Limit              int64
+	Offset             int64
+}
+
+type ListManifestsOldestOffsetRow struct {
+	ID            uuid.UUID
+	Version       string
+	Icon          string
+	Category      string
+	Tags          []string
+	AuthorName    string
+	AuthorEmail   string
+	CreatedAt     time.Time
+	MetaCreatedAt time.Time
+	Localizations json.RawMessage
+	SortTitle     string
+}
+
+func (q *Queries) ListManifestsOldestOffset(ctx context.Context, arg ListManifestsOldestOffsetParams) ([]ListManifestsOldestOffsetRow, error) {
+	rows, err := q.db.QueryContext(ctx, listManifestsOldestOffset,
+		arg.Category,
+		pq.Array(arg.TagsAny),
+		pq.Array(arg.TagsAll),
+		pq.Array(arg.ExcludePermissions),
+		arg.Author,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.ClientAppVersion,
+		arg.ClientOs,
+		pq.Array(arg.ClientComponents),
+		pq.Array(arg.ClientPermissions),
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListManifestsOldestOffsetRow
+	for rows.Next() {
+		var i ListManifestsOldestOffsetRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Version,
 			&i.Icon,
 			&i.Category,
 			pq.Array(&i.Tags),
@@ -425,7 +1859,365 @@ func (q *Queries) SearchManifestsFTS(ctx context.Context, arg SearchManifestsFTS
 			&i.AuthorEmail,
 			&i.CreatedAt,
 			&i.MetaCreatedAt,
+			&i.Localizations,
+			&i.SortTitle,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPermissions = `-- name: ListPermissions :many
+-- тексты всех локалей: {"en": {"title": …, "description": …}, "ru": {…}}
+SELECT p.name,
+       p.risk,
+       p.requires_review,
+       coalesce((SELECT jsonb_object_agg(pl.locale,
+                                         jsonb_build_object('title', pl.title, 'description', pl.description))
+                 FROM permission_localizations AS pl
+                 WHERE pl.permission = p.name), '{}')::jsonb AS localizations
+FROM permissions AS p
+ORDER BY p.name
+`
+
+type ListPermissionsRow struct {
+	Name           string
+	Risk           string
+	RequiresReview bool
+	Localizations  json.RawMessage
+}
+
+func (q *Queries) ListPermissions(ctx context.Context) ([]ListPermissionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPermissions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPermissionsRow
+	for rows.Next() {
+		var i ListPermissionsRow
+		if err := rows.Scan(
+			&i.Name,
+			&i.Risk,
+			&i.RequiresReview,
+			&i.Localizations,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRevocations = `-- name: ListRevocations :many
+SELECT sequence,
+       manifest_id,
+       version,
+       reason,
+       revoked_at
+FROM revocations
+ORDER BY sequence
+`
+
+func (q *Queries) ListRevocations(ctx context.Context) ([]Revocation, error) {
+	rows, err := q.db.QueryContext(ctx, listRevocations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Revocation
+	for rows.Next() {
+		var i Revocation
+		if err := rows.Scan(
+			&i.Sequence,
+			&i.ManifestID,
+			&i.Version,
+			&i.Reason,
+			&i.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUiComponents = `-- name: ListUiComponents :many
+SELECT type,
+       props_schema,
+       min_client_version,
+       description,
+       updated_at
+FROM ui_components
+ORDER BY type
+`
+
+func (q *Queries) ListUiComponents(ctx context.Context) ([]UiComponent, error) {
+	rows, err := q.db.QueryContext(ctx, listUiComponents)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UiComponent
+	for rows.Next() {
+		var i UiComponent
+		if err := rows.Scan(
+			&i.Type,
+			&i.PropsSchema,
+			&i.MinClientVersion,
+			&i.Description,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listVersionsForResign = `-- name: ListVersionsForResign :many
+-- обход по первичному ключу (manifest_id, version); only_stale оставляет версии,
+-- подписанные не ключом kid, без JWS или не в схеме canonical_version, поэтому прерванная
+-- переподпись продолжается с того же места
+SELECT mv.manifest_id,
+       mv.version,
+       mv.icon,
+       mv.category,
+       mv.tags,
+       mv.author_name,
+       mv.author_email,
+       mv.ui,
+       mv.script,
+       mv.actions,
+       mv.permissions,
+       mv.localization,
+       mv.signature,
+       mv.signature_kid,
+       mv.canonical_version,
+       mv.author_kid,
+       mv.author_signature,
+       m.created_at
+FROM manifest_versions mv
+         JOIN manifest m ON m.id = mv.manifest_id
+WHERE (mv.manifest_id, mv.version) > ($1::uuid, $2::text)
+  AND (NOT $3::bool
+    OR mv.signature_kid IS DISTINCT FROM $4::text
+    OR mv.signature_jws IS NULL
+    OR mv.canonical_version <> $5::smallint)
+ORDER BY mv.manifest_id, mv.version
+LIMIT $6
+`
+
+type ListVersionsForResignParams struct {
+	AfterID          uuid.UUID
+	AfterVersion     string
+	OnlyStale        bool
+	Kid              string
+	CanonicalVersion int16
+	BatchSize        int64
+}
+
+type ListVersionsForResignRow struct {
+	ManifestID       uuid.UUID
+	Version          string
+	Icon             string
+	Category         string
+	Tags             []string
+	AuthorName       string
+	AuthorEmail      string
+	Ui               json.RawMessage
+	Script           string
+	Actions          json.RawMessage
+	Permissions      []string
+	Localization     json.RawMessage
+	Signature        string
+	SignatureKid     sql.NullString
+	CanonicalVersion int16
+	AuthorKid        sql.NullString
+	AuthorSignature  sql.NullString
+	CreatedAt        time.Time
+}
+
+func (q *Queries) ListVersionsForResign(ctx context.Context, arg ListVersionsForResignParams) ([]ListVersionsForResignRow, error) {
+	rows, err := q.db.QueryContext(ctx, listVersionsForResign,
+		arg.AfterID,
+		arg.AfterVersion,
+		arg.OnlyStale,
+		arg.Kid,
+		arg.CanonicalVersion,
+		arg.BatchSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListVersionsForResignRow
+	for rows.Next() {
+		var i ListVersionsForResignRow
+		if err := rows.Scan(
+			&i.ManifestID,
+			&i.Version,
+			&i.Icon,
+			&i.Category,
+			pq.Array(&i.Tags),
+			&i.AuthorName,
+			&i.AuthorEmail,
+			&i.Ui,
+			&i.Script,
+			&i.Actions,
+			pq.Array(&i.Permissions),
 			&i.Localization,
+			&i.Signature,
+			&i.SignatureKid,
+			&i.CanonicalVersion,
+			&i.AuthorKid,
+			&i.AuthorSignature,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockRevocations = `-- name: LockRevocations :exec
+-- sequence — max+1, поэтому отзывы выдаются строго по очереди до коммита
+LOCK TABLE revocations IN EXCLUSIVE MODE
+`
+
+func (q *Queries) LockRevocations(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, lockRevocations)
+	return err
+}
+
+const lockTransparencyLog = `-- name: LockTransparencyLog :exec
+-- до конца транзакции: листья получают индексы по порядку и без пропусков, чтение не блокируется
+LOCK TABLE transparency_log IN EXCLUSIVE MODE
+`
+
+func (q *Queries) LockTransparencyLog(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, lockTransparencyLog)
+	return err
+}
+
+const searchManifestsFTS = `-- name: SearchManifestsFTS :many
+SELECT m.id,
+       m.version,
+       m.icon,
+       m.category,
+       m.tags,
+       m.author_name,
+       m.author_email,
+       m.created_at,
+       m.meta_created_at,
+       (SELECT jsonb_object_agg(x.locale, x.strings)
+        FROM (SELECT l.locale, jsonb_object_agg(l.key, l.value) AS strings
+              FROM manifest_localizations AS l
+              WHERE l.manifest_id = m.id
+              GROUP BY l.locale) AS x) AS localizations
+FROM manifest AS m
+WHERE to_tsvector($2::regconfig,
+                  coalesce((SELECT string_agg(l.value, ' ')
+                            FROM manifest_localizations AS l
+                            WHERE l.manifest_id = m.id
+                              AND l.locale = ANY ($1::text[])
+                              AND l.key IN ('title', 'description')), '') || ' ' ||
+                  m.category || ' ' ||
+                  array_to_string(m.tags, ' ')
+      )
+          @@ plainto_tsquery($2::regconfig, $3::text)
+  AND manifest_compatible(m.id,
+                          $4::text,
+                          $5::text,
+                          $6::text[],
+                          $7::text[])
+ORDER BY m.created_at DESC
+`
+
+type SearchManifestsFTSParams struct {
+	Locales           []string
+	Config            interface{}
+	Query             string
+	ClientAppVersion  sql.NullString
+	ClientOs          sql.NullString
+	ClientComponents  []string
+	ClientPermissions []string
+}
+
+type SearchManifestsFTSRow struct {
+	ID            uuid.UUID
+	Version       string
+	Icon          string
+	Category      string
+	Tags          []string
+	AuthorName    string
+	AuthorEmail   string
+	CreatedAt     time.Time
+	MetaCreatedAt time.Time
+	Localizations json.RawMessage
+}
+
+func (q *Queries) SearchManifestsFTS(ctx context.Context, arg SearchManifestsFTSParams) ([]SearchManifestsFTSRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchManifestsFTS,
+		pq.Array(arg.Locales),
+		arg.Config,
+		arg.Query,
+		arg.ClientAppVersion,
+		arg.ClientOs,
+		pq.Array(arg.ClientComponents),
+		pq.Array(arg.ClientPermissions),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchManifestsFTSRow
+	for rows.Next() {
+		var i SearchManifestsFTSRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Version,
+			&i.Icon,
+			&i.Category,
+			pq.Array(&i.Tags),
+			&i.AuthorName,
+			&i.AuthorEmail,
+			&i.CreatedAt,
+			&i.MetaCreatedAt,
+			&i.Localizations,
 		); err != nil {
 			return nil, err
 		}
@@ -439,3 +2231,185 @@ func (q *Queries) SearchManifestsFTS(ctx context.Context, arg SearchManifestsFTS
 	}
 	return items, nil
 }
+
+const setManifestCompatibility = `-- name: SetManifestCompatibility :exec
+UPDATE manifest
+SET min_app_version = $1,
+    max_app_version = $2,
+    os              = $3
+WHERE id = $4
+`
+
+type SetManifestCompatibilityParams struct {
+	MinAppVersion sql.NullString
+	MaxAppVersion sql.NullString
+	Os            []string
+	ID            uuid.UUID
+}
+
+func (q *Queries) SetManifestCompatibility(ctx context.Context, arg SetManifestCompatibilityParams) error {
+	_, err := q.db.ExecContext(ctx, setManifestCompatibility,
+		arg.MinAppVersion,
+		arg.MaxAppVersion,
+		pq.Array(arg.Os),
+		arg.ID,
+	)
+	return err
+}
+
+const setManifestComponentTypes = `-- name: SetManifestComponentTypes :exec
+UPDATE manifest
+SET component_types = $1
+WHERE id = $2
+`
+
+type SetManifestComponentTypesParams struct {
+	ComponentTypes []string
+	ID             uuid.UUID
+}
+
+func (q *Queries) SetManifestComponentTypes(ctx context.Context, arg SetManifestComponentTypesParams) error {
+	_, err := q.db.ExecContext(ctx, setManifestComponentTypes, pq.Array(arg.ComponentTypes), arg.ID)
+	return err
+}
+
+const updateManifest = `-- name: UpdateManifest :exec
+UPDATE manifest
+SET version           = $1,
+    icon              = $2,
+    category          = $3,
+    tags              = $4,
+    author_name       = $5,
+    author_email      = $6,
+    signature         = $7,
+    signature_kid     = $8,
+    canonical_version = $9,
+    author_kid        = $10,
+    author_signature  = $11
+WHERE id = $12
+`
+
+type UpdateManifestParams struct {
+	Version          string
+	Icon             string
+	Category         string
+	Tags             []string
+	AuthorName       string
+	AuthorEmail      string
+	Signature        string
+	SignatureKid     sql.NullString
+	CanonicalVersion int16
+	AuthorKid        sql.NullString
+	AuthorSignature  sql.NullString
+	ID               uuid.UUID
+}
+
+func (q *Queries) UpdateManifest(ctx context.Context, arg UpdateManifestParams) error {
+	_, err := q.db.ExecContext(ctx, updateManifest,
+		arg.Version,
+		arg.Icon,
+		arg.Category,
+		pq.Array(arg.Tags),
+		arg.AuthorName,
+		arg.AuthorEmail,
+		arg.Signature,
+		arg.SignatureKid,
+		arg.CanonicalVersion,
+		arg.AuthorKid,
+		arg.AuthorSignature,
+		arg.ID,
+	)
+	return err
+}
+
+const updateManifestContent = `-- name: UpdateManifestContent :exec
+UPDATE manifest_content
+SET ui          = $1,
+    script      = $2,
+    actions     = $3,
+    permissions = $4
+WHERE manifest_id = $5
+`
+
+type UpdateManifestContentParams struct {
+	Ui          json.RawMessage
+	Script      string
+	Actions     json.RawMessage
+	Permissions []string
+	ManifestID  uuid.UUID
+}
+
+func (q *Queries) UpdateManifestContent(ctx context.Context, arg UpdateManifestContentParams) error {
+	_, err := q.db.ExecContext(ctx, updateManifestContent,
+		arg.Ui,
+		arg.Script,
+		arg.Actions,
+		pq.Array(arg.Permissions),
+		arg.ManifestID,
+	)
+	return err
+}
+
+const updateManifestSignature = `-- name: UpdateManifestSignature :execrows
+-- переподписывает текущую версию; если манифест успели обновить, строка не меняется
+UPDATE manifest
+SET signature         = $1,
+    signature_kid     = $2,
+    canonical_version = $3
+WHERE id = $4
+  AND version = $5
+`
+
+type UpdateManifestSignatureParams struct {
+	Signature        string
+	SignatureKid     sql.NullString
+	CanonicalVersion int16
+	ID               uuid.UUID
+	Version          string
+}
+
+func (q *Queries) UpdateManifestSignature(ctx context.Context, arg UpdateManifestSignatureParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateManifestSignature,
+		arg.Signature,
+		arg.SignatureKid,
+		arg.CanonicalVersion,
+		arg.ID,
+		arg.Version,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateVersionSignature = `-- name: UpdateVersionSignature :exec
+-- триггер неизменяемости пропускает смену подписи: содержимое релиза не меняется
+UPDATE manifest_versions
+SET signature         = $1,
+    signature_kid     = $2,
+    signature_jws     = $3,
+    canonical_version = $4
+WHERE manifest_id = $5
+  AND version = $6
+`
+
+type UpdateVersionSignatureParams struct {
+	Signature        string
+	SignatureKid     sql.NullString
+	SignatureJws     sql.NullString
+	CanonicalVersion int16
+	ManifestID       uuid.UUID
+	Version          string
+}
+
+func (q *Queries) UpdateVersionSignature(ctx context.Context, arg UpdateVersionSignatureParams) error {
+	_, err := q.db.ExecContext(ctx, updateVersionSignature,
+		arg.Signature,
+		arg.SignatureKid,
+		arg.SignatureJws,
+		arg.CanonicalVersion,
+		arg.ManifestID,
+		arg.Version,
+	)
+	return err
+}
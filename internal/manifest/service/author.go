@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"pluto-backend/internal/manifest/api/gen"
+	"pluto-backend/internal/manifest/canonical"
+	"pluto-backend/internal/manifest/repository"
+	"pluto-backend/internal/platform/jwk"
+	"pluto-backend/pkg/manifestsig"
+)
+
+// RegisterAuthorKey регистрирует ещё один ключ автора (POST /api/authors/keys). proof доказывает
+// владение ключом, endorsement — что ключ добавляет сам автор: его заверяет один из уже
+// зарегистрированных. Первый ключ так не принимается: запрос не аутентифицирован, и любой
+// занял бы чужой email раньше владельца. Первый ключ одобряет оператор (ApproveAuthorKey)
+func (s *Service) RegisterAuthorKey(ctx context.Context, req gen.AuthorKeyCreate) (repository.AuthorKey, error) {
+	return s.registerAuthorKey(ctx, req, false)
+}
+
+// ApproveAuthorKey регистрирует ключ по решению оператора, который сам убедился, что email
+// принадлежит автору (manifest-author-key). endorsement не обязателен — так же восстанавливается
+// доступ автора, потерявшего все ключи; proof по-прежнему нужен
+func (s *Service) ApproveAuthorKey(ctx context.Context, req gen.AuthorKeyCreate) (repository.AuthorKey, error) {
+	return s.registerAuthorKey(ctx, req, true)
+}
+
+func (s *Service) registerAuthorKey(ctx context.Context, req gen.AuthorKeyCreate, approved bool) (repository.AuthorKey, error) {
+	email := strings.TrimSpace(req.Email)
+	if email == "" {
+		return repository.AuthorKey{}, invalid("/email", "email is required")
+	}
+	pub, err := manifestsig.ParsePublicKey(req.PublicKey)
+	if err != nil {
+		return repository.AuthorKey{}, invalid("/publicKey", err.Error())
+	}
+	kid := jwk.Ed25519Thumbprint(pub)
+	proof := manifestsig.AuthorKeyProof(email, kid)
+
+	if err := manifestsig.VerifyPayload(pub, proof, req.Proof); err != nil {
+		return repository.AuthorKey{}, invalid("/proof", "proof must be a signature of the key registration string by this key")
+	}
+
+	existing, err := s.repo.ListAuthorKeys(ctx, email)
+	if err != nil {
+		return repository.AuthorKey{}, err
+	}
+	if len(existing) == 0 && !approved {
+		return repository.AuthorKey{}, ErrAuthorKeyApproval
+	}
+	if req.Endorsement == nil && !approved {
+		return repository.AuthorKey{}, invalid("/endorsement", "author already has keys; a new key must be endorsed by one of them")
+	}
+	if req.Endorsement != nil {
+		endorser, ok := findAuthorKey(existing, req.Endorsement.Kid)
+		if !ok {
+			return repository.AuthorKey{}, invalid("/endorsement/kid", "key is not registered for this author")
+		}
+		if err := manifestsig.VerifyPayload(ed25519.PublicKey(endorser.PublicKey), proof, req.Endorsement.Signature); err != nil {
+			return repository.AuthorKey{}, invalid("/endorsement/signature", "endorsement does not match the key registration string")
+		}
+	}
+
+	key, err := s.repo.CreateAuthorKey(ctx, repository.CreateAuthorKeyParams{
+		Kid:         kid,
+		AuthorEmail: email,
+		PublicKey:   pub,
+	})
+	if isUniqueViolation(err) {
+		return repository.AuthorKey{}, ErrAuthorKeyExists
+	}
+	return key, err
+}
+
+// ListAuthorKeys — ключи автора по email без учёта регистра, старые первыми
+func (s *Service) ListAuthorKeys(ctx context.Context, email string) ([]repository.AuthorKey, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return nil, invalidParam("email", "email is required")
+	}
+	return s.repo.ListAuthorKeys(ctx, email)
+}
+
+// verifyAuthorSignature проверяет authorSignature версии ключами её автора.
+// Автор, зарегистрировавший хотя бы один ключ, больше не может публиковать без подписи
+func verifyAuthorSignature(
+	ctx context.Context,
+	q repository.Querier,
+	version string,
+	m gen.ManifestCreate,
+	scriptCode string,
+) error {
+	keys, err := q.ListAuthorKeys(ctx, m.Author.Email)
+	if err != nil {
+		return err
+	}
+	if m.AuthorSignature == nil {
+		if len(keys) > 0 {
+			return invalid("/authorSignature", "author has registered keys; manifest must be signed by the author")
+		}
+		return nil
+	}
+
+	key, ok := findAuthorKey(keys, m.AuthorSignature.Kid)
+	if !ok {
+		return invalid("/authorSignature/kid", "key is not registered for author "+m.Author.Email)
+	}
+	payload, err := canonical.AuthorPayload(version, m, scriptCode)
+	if err != nil {
+		return err
+	}
+	if err := manifestsig.VerifyPayload(ed25519.PublicKey(key.PublicKey), payload, m.AuthorSignature.Signature); err != nil {
+		return invalid("/authorSignature/signature", "author signature does not match manifest version "+version)
+	}
+	return nil
+}
+
+// checkAuthorTransfer не даёт снять с манифеста автора, зарегистрировавшего ключи:
+// иначе сменой email в PATCH можно было бы обойти обязательную подпись автора
+func checkAuthorTransfer(ctx context.Context, q repository.Querier, cur, next gen.ManifestCreate) error {
+	if strings.EqualFold(cur.Author.Email, next.Author.Email) {
+		return nil
+	}
+	keys, err := q.ListAuthorKeys(ctx, cur.Author.Email)
+	if err != nil {
+		return err
+	}
+	if len(keys) > 0 {
+		return invalid("/author/email", "manifest of an author with registered keys cannot be transferred")
+	}
+	return nil
+}
+
+// verifyAuthor — проверка authorSignature присланного клиентом манифеста (POST /api/manifests/verify)
+func (s *Service) verifyAuthor(ctx context.Context, m manifestsig.Manifest) error {
+	key, err := s.repo.GetAuthorKey(ctx, m.AuthorSignature.Kid)
+	if errors.Is(err, sql.ErrNoRows) {
+		return manifestsig.ErrUnknownKey
+	}
+	if err != nil {
+		return err
+	}
+	// ключ другого автора не подтверждает авторство этого манифеста
+	if !strings.EqualFold(key.AuthorEmail, m.Meta.Author.Email) {
+		return manifestsig.ErrUnknownKey
+	}
+	return manifestsig.VerifyAuthor(ed25519.PublicKey(key.PublicKey), m)
+}
+
+func findAuthorKey(keys []repository.AuthorKey, kid string) (repository.AuthorKey, bool) {
+	for _, k := range keys {
+		if k.Kid == kid {
+			return k, true
+		}
+	}
+	return repository.AuthorKey{}, false
+}
+
+// authorColumns раскладывает подпись автора в nullable-колонки
+func authorColumns(sig *gen.AuthorSignature) (kid, signature sql.NullString) {
+	if sig == nil {
+		return sql.NullString{}, sql.NullString{}
+	}
+	return nullString(sig.Kid), nullString(sig.Signature)
+}
+
+// storedAuthorSignature — обратное authorColumns
+func storedAuthorSignature(kid, signature sql.NullString) *gen.AuthorSignature {
+	if !kid.Valid {
+		return nil
+	}
+	return &gen.AuthorSignature{Kid: kid.String, Signature: signature.String}
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"pluto-backend/internal/manifest/api/gen"
+	"pluto-backend/internal/manifest/canonical"
+	"pluto-backend/internal/manifest/repository"
+	"pluto-backend/pkg/manifestsig"
+)
+
+// Сроки действия метаданных каталога по умолчанию: snapshot меняется только вместе
+// с каталогом, timestamp короткий — по нему клиент замечает, что его «заморозили»
+const (
+	defaultSnapshotTTL  = 7 * 24 * time.Hour
+	defaultTimestampTTL = time.Hour
+)
+
+// catalogCache хранит последние подписанные документы: snapshot пересобирается, когда
+// меняется версия каталога или прошла половина срока его действия, timestamp — по сроку.
+// Байты отдаются клиентам как есть, поэтому хеш snapshot в timestamp с ними совпадает
+type catalogCache struct {
+	mu sync.Mutex
+
+	snapshotTTL  time.Duration
+	timestampTTL time.Duration
+
+	version        int64
+	snapshot       []byte
+	snapshotIssued time.Time
+	// generation растёт с каждой пересборкой snapshot; timestamp ссылается на конкретную
+	generation      int64
+	timestamp       []byte
+	timestampIssued time.Time
+	timestampOf     int64
+}
+
+// SetCatalogExpiry задаёт сроки действия snapshot и timestamp; нулевое значение оставляет умолчание
+func (s *Service) SetCatalogExpiry(snapshot, timestamp time.Duration) {
+	s.catalog.mu.Lock()
+	defer s.catalog.mu.Unlock()
+	if snapshot > 0 {
+		s.catalog.snapshotTTL = snapshot
+	}
+	if timestamp > 0 {
+		s.catalog.timestampTTL = timestamp
+	}
+}
+
+// CatalogSnapshot — подписанный snapshot: текущая версия и хеш подписанного payload
+// каждого манифеста. Фильтр совместимости к нему намеренно не применяется: snapshot один
+// на всех клиентов и описывает каталог, а не выдачу. Манифест, скрытый от клиента после
+// смены его возможностей, иначе выглядел бы удалённым, и manifestsig.CheckRollback отверг бы snapshot
+func (s *Service) CatalogSnapshot(ctx context.Context) ([]byte, error) {
+	s.catalog.mu.Lock()
+	defer s.catalog.mu.Unlock()
+
+	if err := s.refreshSnapshot(ctx, time.Now()); err != nil {
+		return nil, err
+	}
+	return s.catalog.snapshot, nil
+}
+
+// CatalogTimestamp — подписанный timestamp, ссылающийся на актуальный snapshot
+func (s *Service) CatalogTimestamp(ctx context.Context) ([]byte, error) {
+	s.catalog.mu.Lock()
+	defer s.catalog.mu.Unlock()
+
+	now := time.Now()
+	if err := s.refreshSnapshot(ctx, now); err != nil {
+		return nil, err
+	}
+	c := &s.catalog
+	if c.timestamp != nil && c.timestampOf == c.generation && now.Before(c.timestampIssued.Add(c.timestampTTL/2)) {
+		return c.timestamp, nil
+	}
+
+	issued := now.UTC().Truncate(time.Second)
+	ts := manifestsig.Timestamp{
+		Type: manifestsig.TypeTimestamp,
+		// номер timestamp растёт с каждой переподписью, даже если snapshot прежний
+		Version: issued.Unix(),
+		Expires: issued.Add(c.timestampTTL),
+		Snapshot: manifestsig.MetaFile{
+			Version: c.version,
+			Length:  len(c.snapshot),
+			Hashes:  manifestsig.Hashes(c.snapshot),
+		},
+	}
+	signed, err := s.signMetadata(ts)
+	if err != nil {
+		return nil, err
+	}
+	c.timestamp, c.timestampIssued, c.timestampOf = signed, issued, c.generation
+	return signed, nil
+}
+
+// refreshSnapshot пересобирает snapshot, если каталог изменился или срок подходит к концу.
+// Вызывается под catalog.mu
+func (s *Service) refreshSnapshot(ctx context.Context, now time.Time) error {
+	c := &s.catalog
+	version, err := s.repo.GetCatalogVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if c.snapshot != nil && c.version == version && now.Before(c.snapshotIssued.Add(c.snapshotTTL/2)) {
+		return nil
+	}
+
+	// версия и список читаются из одного снимка базы, иначе snapshot с номером N
+	// мог бы описывать каталог N+1
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	q := s.rawRepo.WithTx(tx)
+	version, err = q.GetCatalogVersion(ctx)
+	if err != nil {
+		return err
+	}
+	rows, err := q.ListCatalogManifests(ctx)
+	if err != nil {
+		return err
+	}
+
+	issued := now.UTC().Truncate(time.Second)
+	snapshot := manifestsig.Snapshot{
+		Type:      manifestsig.TypeSnapshot,
+		Version:   version,
+		Expires:   issued.Add(c.snapshotTTL),
+		Manifests: make(map[string]manifestsig.ManifestFile, len(rows)),
+	}
+	for _, row := range rows {
+		m, err := catalogManifest(row)
+		if err != nil {
+			return fmt.Errorf("manifest %s: %w", row.ID, err)
+		}
+		payload, err := canonical.Payload(int(row.CanonicalVersion), row.ID, row.Version, row.CreatedAt, m, row.Script)
+		if err != nil {
+			return fmt.Errorf("manifest %s: %w", row.ID, err)
+		}
+		snapshot.Manifests[row.ID.String()] = manifestsig.ManifestFile{
+			Version: row.Version,
+			Length:  len(payload),
+			Hashes:  manifestsig.Hashes(payload),
+		}
+	}
+
+	signed, err := s.signMetadata(snapshot)
+	if err != nil {
+		return err
+	}
+	c.version, c.snapshot, c.snapshotIssued = version, signed, issued
+	c.generation++
+	return nil
+}
+
+// signMetadata подписывает канонический JSON документа активным ключом и упаковывает в конверт
+func (s *Service) signMetadata(signed any) ([]byte, error) {
+	payload, err := manifestsig.MetadataPayload(signed)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := s.signer.Sign(payload)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(manifestsig.SignedMetadata[any]{
+		Signed:     signed,
+		Signatures: []manifestsig.MetadataSignature{{KeyID: s.signer.KeyID(), Sig: sig}},
+	})
+}
+
+// catalogManifest восстанавливает текущую версию манифеста из строки каталога
+func catalogManifest(row repository.ListCatalogManifestsRow) (gen.ManifestCreate, error) {
+	var actions *[]gen.ManifestActionBase
+	if err := json.Unmarshal(row.Actions, &actions); err != nil {
+		return gen.ManifestCreate{}, err
+	}
+	var localization gen.ManifestLocalizationCreate
+	if row.Localizations.Valid {
+		if err := json.Unmarshal(row.Localizations.RawMessage, &localization); err != nil {
+			return gen.ManifestCreate{}, err
+		}
+	}
+
+	return gen.ManifestCreate{
+		Actions: actions,
+		Author: gen.Author{
+			Name:  row.AuthorName,
+			Email: row.AuthorEmail,
+		},
+		Category:     row.Category,
+		Icon:         row.Icon,
+		Localization: localization,
+		Permissions:  row.Permissions,
+		Tags:         row.Tags,
+		Ui:           row.Ui,
+
+		AuthorSignature: storedAuthorSignature(row.AuthorKid, row.AuthorSignature),
+	}, nil
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"pluto-backend/internal/manifest/api/gen"
+	"pluto-backend/internal/manifest/canonical"
+	"pluto-backend/internal/manifest/repository"
+	"pluto-backend/internal/manifest/validation"
+)
+
+// ClientCapabilities — что умеет клиент: версия приложения, ОС, типы компонентов UI
+// и разрешения. Пустое поле — клиент о нём не сообщил, и по нему выдача не ограничивается
+type ClientCapabilities struct {
+	AppVersion  string
+	OS          string
+	Components  []string
+	Permissions []string
+}
+
+// Declared — клиент сообщил хоть что-то о своих возможностях
+func (c ClientCapabilities) Declared() bool {
+	return c.AppVersion != "" || c.OS != "" || c.Components != nil || c.Permissions != nil
+}
+
+// checkCapabilities отклоняет версию приложения, которую нельзя сравнить
+func checkCapabilities(c ClientCapabilities) error {
+	if c.AppVersion != "" && !canonical.ValidVersion(c.AppVersion) {
+		return invalidParam("X-Client-Capabilities", "app_version must be MAJOR.MINOR.PATCH")
+	}
+	return nil
+}
+
+// Incompatibility — почему манифест не подходит клиенту; пустой список — подходит.
+// Те же правила, что у manifest_compatible в выдаче, но с объяснением для GetManifestById
+func (s *Service) Incompatibility(ctx context.Context, m repository.GetManifestRow, c ClientCapabilities) ([]string, error) {
+	if err := checkCapabilities(c); err != nil {
+		return nil, err
+	}
+
+	var reasons []string
+	if c.AppVersion != "" {
+		if m.MinAppVersion.Valid {
+			if cmp, err := canonical.CompareVersions(c.AppVersion, m.MinAppVersion.String); err == nil && cmp < 0 {
+				reasons = append(reasons, fmt.Sprintf("requires app version %s or later", m.MinAppVersion.String))
+			}
+		}
+		if m.MaxAppVersion.Valid {
+			if cmp, err := canonical.CompareVersions(c.AppVersion, m.MaxAppVersion.String); err == nil && cmp > 0 {
+				reasons = append(reasons, fmt.Sprintf("requires app version %s or earlier", m.MaxAppVersion.String))
+			}
+		}
+		components, err := s.repo.ListUiComponents(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, comp := range components {
+			if !slices.Contains(m.ComponentTypes, comp.Type) {
+				continue
+			}
+			if cmp, err := canonical.CompareVersions(c.AppVersion, comp.MinClientVersion); err == nil && cmp < 0 {
+				reasons = append(reasons, fmt.Sprintf("component %q requires app version %s or later", comp.Type, comp.MinClientVersion))
+			}
+		}
+	}
+	if c.OS != "" && len(m.Os) > 0 && !slices.Contains(m.Os, c.OS) {
+		reasons = append(reasons, fmt.Sprintf("not available for %s", c.OS))
+	}
+	if c.Components != nil {
+		for _, t := range m.ComponentTypes {
+			if !slices.Contains(c.Components, t) {
+				reasons = append(reasons, fmt.Sprintf("uses unsupported component %q", t))
+			}
+		}
+	}
+	if c.Permissions != nil {
+		for _, p := range m.Permissions {
+			if !slices.Contains(c.Permissions, p) {
+				reasons = append(reasons, fmt.Sprintf("requires unsupported permission %q", p))
+			}
+		}
+	}
+	return reasons, nil
+}
+
+// setCompatibility сохраняет ограничения совместимости манифеста; nil — снять все
+func setCompatibility(ctx context.Context, q repository.Querier, id uuid.UUID, c *gen.ManifestCompatibility) error {
+	params := repository.SetManifestCompatibilityParams{ID: id, Os: []string{}}
+	if c != nil {
+		if c.MinAppVersion != nil {
+			params.MinAppVersion = nullString(*c.MinAppVersion)
+		}
+		if c.MaxAppVersion != nil {
+			params.MaxAppVersion = nullString(*c.MaxAppVersion)
+		}
+		if c.Os != nil {
+			params.Os = *c.Os
+		}
+	}
+	return q.SetManifestCompatibility(ctx, params)
+}
+
+// setComponentTypes запоминает типы компонентов ui для проверки совместимости в выдаче
+func setComponentTypes(ctx context.Context, q repository.Querier, id uuid.UUID, ui gen.ManifestUiBase) error {
+	return q.SetManifestComponentTypes(ctx, repository.SetManifestComponentTypesParams{
+		ComponentTypes: validation.ComponentTypes(ui),
+		ID:             id,
+	})
+}
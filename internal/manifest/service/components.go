@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"pluto-backend/internal/manifest/repository"
+	"pluto-backend/internal/manifest/validation"
+)
+
+// ListComponents — реестр компонентов UI в порядке type
+func (s *Service) ListComponents(ctx context.Context) ([]repository.UiComponent, error) {
+	return s.repo.ListUiComponents(ctx)
+}
+
+// componentRegistry собирает реестр для проверки props. Реестр меняется только миграциями,
+// поэтому читается там же, где проверяется манифест, без кеша
+func componentRegistry(ctx context.Context, q repository.Querier) (validation.Registry, error) {
+	rows, err := q.ListUiComponents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reg := make(validation.Registry, len(rows))
+	for _, row := range rows {
+		schema, err := validation.ParsePropsSchema(row.PropsSchema)
+		if err != nil {
+			return nil, fmt.Errorf("ui component %q: props schema: %w", row.Type, err)
+		}
+		reg[row.Type] = schema
+	}
+	return reg, nil
+}
+
+// checkComponents сверяет ui.components с реестром
+func checkComponents(ctx context.Context, q repository.Querier, ui []byte) error {
+	reg, err := componentRegistry(ctx, q)
+	if err != nil {
+		return err
+	}
+	return validation.Components(ui, reg)
+}
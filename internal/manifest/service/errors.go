@@ -0,0 +1,51 @@
+package service
+
+import (
+	"database/sql"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+	"pluto-backend/internal/manifest/validation"
+)
+
+// Предопределённые ошибки, чтобы handlers могли распознать
+var (
+	ErrManifestNotFound = errors.New("manifest not found")
+	ErrVersionNotFound  = errors.New("manifest version not found")
+	ErrVersionConflict  = errors.New("manifest version already exists")
+	ErrUnknownKey       = errors.New("signing key is unknown or no longer trusted")
+	ErrAuthorKeyExists  = errors.New("author key already registered")
+	// первый ключ email нельзя зарегистрировать без подтверждения владения email
+	ErrAuthorKeyApproval = errors.New("first key of an author must be approved by an operator")
+	ErrLogEntryNotFound  = errors.New("transparency log entry not found")
+	ErrAlreadyRevoked    = errors.New("manifest or version already revoked")
+)
+
+// ValidationError — запрос не прошёл проверку; правила, общие с cmd/pluto-manifest,
+// живут в internal/manifest/validation
+type ValidationError = validation.Error
+
+// ValidationErrors — несколько нарушений сразу, например связность ui
+type ValidationErrors = validation.Errors
+
+func invalid(pointer, message string) error {
+	return validation.Invalid(pointer, message)
+}
+
+func invalidParam(name, message string) error {
+	return validation.InvalidParam(name, message)
+}
+
+// notFound подменяет sql.ErrNoRows доменной ошибкой
+func notFound(err error, target error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return target
+	}
+	return err
+}
+
+// isUniqueViolation — нарушение уникального ключа в Postgres (23505)
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
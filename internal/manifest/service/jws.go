@@ -0,0 +1,41 @@
+package service
+
+import (
+	"encoding/base64"
+
+	"pluto-backend/pkg/manifestsig"
+)
+
+// sign подписывает payload активным ключом в обеих формах: подписью Ed25519 (signature)
+// и detached JWS (signatureJws). Обе выпускаются только при публикации и переподписи,
+// сохраняются в версии и вносятся в журнал; GET отдаёт сохранённые и ключ не использует
+func (s *Service) sign(payload []byte) (signature, jws string, err error) {
+	signature, err = s.signer.Sign(payload)
+	if err != nil {
+		return "", "", err
+	}
+	jws, err = s.detachedJWS(payload)
+	if err != nil {
+		return "", "", err
+	}
+	return signature, jws, nil
+}
+
+// detachedJWS — protected..signature с alg=EdDSA, kid активного ключа и b64=false:
+// подписывается BASE64URL(header) || '.' || payload без перекодирования payload
+func (s *Service) detachedJWS(payload []byte) (string, error) {
+	protected, err := manifestsig.EncodeJWSHeader(manifestsig.NewJWSHeader(s.signer.KeyID()))
+	if err != nil {
+		return "", err
+	}
+
+	sigB64, err := s.signer.Sign(manifestsig.JWSSigningInput(protected, payload))
+	if err != nil {
+		return "", err
+	}
+	sig, err := base64.StdEncoding.DecodeString(sigB64)
+	if err != nil {
+		return "", err
+	}
+	return manifestsig.DetachedJWS(protected, sig), nil
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	"pluto-backend/internal/platform/jwk"
+)
+
+// KeyringKey — ключ связки. Приватная часть нужна только активному ключу:
+// выведенные из ротации ключи остаются лишь для проверки старых подписей
+type KeyringKey struct {
+	Private   ed25519.PrivateKey
+	Public    ed25519.PublicKey
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// Keyring — связка ключей Ed25519 с kid (RFC 7638). Подписывает активным ключом,
+// проверяет любым, чьё окно доверия включает текущий момент
+type Keyring struct {
+	active activeSigner
+	keys   []PublicKey
+	now    func() time.Time
+}
+
+// activeSigner — ключ, которым связка подписывает: локальный или во внешнем сервисе
+type activeSigner interface {
+	Sign(data []byte) (string, error)
+	KeyID() string
+	GetPublicKey() (string, error)
+}
+
+// NewKeyring собирает связку; activeKid пуст — активным считается единственный ключ с приватной частью.
+// Активный ключ должен быть в своём окне доверия: подписи ключом вне окна никто не проверит
+func NewKeyring(keys []KeyringKey, activeKid string) (*Keyring, error) {
+	pubs, err := keyringPublicKeys(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	var withPrivate []KeyringKey
+	for i, k := range keys {
+		if len(k.Private) == 0 {
+			continue
+		}
+		if !k.Public.Equal(k.Private.Public()) {
+			return nil, fmt.Errorf("key %s: private key does not match public key", pubs[i].Kid)
+		}
+		if activeKid == "" || activeKid == pubs[i].Kid {
+			withPrivate = append(withPrivate, k)
+		}
+	}
+
+	switch {
+	case len(withPrivate) == 0 && activeKid != "":
+		return nil, fmt.Errorf("active key %s not found or has no private key", activeKid)
+	case len(withPrivate) == 0:
+		return nil, errors.New("keyring has no key with a private part")
+	case len(withPrivate) > 1:
+		return nil, errors.New("several keys have private parts: set signing.active_kid")
+	}
+
+	kr := &Keyring{active: NewEd25519Signer(withPrivate[0].Private, withPrivate[0].Public), keys: pubs, now: time.Now}
+	kr.publishActiveFirst()
+	if err := kr.checkActiveWindow(); err != nil {
+		return nil, err
+	}
+	return kr, nil
+}
+
+// NewRemoteKeyring собирает связку, где подписывает внешний сервис, а keys лишь
+// продолжают проверять старые подписи; приватных частей у них быть не должно
+func NewRemoteKeyring(remote *RemoteSigner, keys []KeyringKey) (*Keyring, error) {
+	pubs, err := keyringPublicKeys(keys)
+	if err != nil {
+		return nil, err
+	}
+	for i, k := range keys {
+		if len(k.Private) > 0 {
+			return nil, fmt.Errorf("key %s: private keys are not allowed with a remote signer", pubs[i].Kid)
+		}
+	}
+
+	kr := &Keyring{active: remote, keys: pubs, now: time.Now}
+	if !slices.ContainsFunc(pubs, func(pk PublicKey) bool { return pk.Kid == remote.KeyID() }) {
+		kr.keys = append(kr.keys, PublicKey{Kid: remote.KeyID(), Key: remote.pub})
+	}
+	kr.publishActiveFirst()
+	if err := kr.checkActiveWindow(); err != nil {
+		return nil, err
+	}
+	return kr, nil
+}
+
+// keyringPublicKeys проверяет публичные части и вычисляет kid; порядок совпадает с keys
+func keyringPublicKeys(keys []KeyringKey) ([]PublicKey, error) {
+	seen := make(map[string]bool, len(keys))
+	pubs := make([]PublicKey, 0, len(keys))
+	for _, k := range keys {
+		if len(k.Public) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("public key must be %d bytes", ed25519.PublicKeySize)
+		}
+		kid := jwk.Ed25519Thumbprint(k.Public)
+		if seen[kid] {
+			return nil, fmt.Errorf("duplicate key %s", kid)
+		}
+		seen[kid] = true
+		pubs = append(pubs, PublicKey{Kid: kid, Key: k.Public, NotBefore: k.NotBefore, NotAfter: k.NotAfter})
+	}
+	return pubs, nil
+}
+
+// publishActiveFirst помечает активный ключ и ставит его первым
+func (k *Keyring) publishActiveFirst() {
+	for i := range k.keys {
+		if k.keys[i].Kid == k.active.KeyID() {
+			k.keys[i].Active = true
+			k.keys[0], k.keys[i] = k.keys[i], k.keys[0]
+		}
+	}
+}
+
+// checkActiveWindow — активный ключ (первый после publishActiveFirst) доверен сейчас
+func (k *Keyring) checkActiveWindow() error {
+	active := k.keys[0]
+	if trustedAt(active, k.now()) {
+		return nil
+	}
+	return fmt.Errorf("active key %s is outside its validity window %s", active.Kid, validityWindow(active))
+}
+
+// Sign подписывает активным ключом; после его notAfter связка перестаёт подписывать,
+// а не выпускает подписи, которые уже не проверить
+func (k *Keyring) Sign(data []byte) (string, error) {
+	if err := k.checkActiveWindow(); err != nil {
+		return "", err
+	}
+	return k.active.Sign(data)
+}
+
+func (k *Keyring) KeyID() string {
+	return k.active.KeyID()
+}
+
+func (k *Keyring) GetPublicKey() (string, error) {
+	return k.active.GetPublicKey()
+}
+
+func (k *Keyring) PublicKeys() []PublicKey {
+	now := k.now()
+	out := make([]PublicKey, 0, len(k.keys))
+	for _, pk := range k.keys {
+		if trustedAt(pk, now) {
+			out = append(out, pk)
+		}
+	}
+	return out
+}
+
+func (k *Keyring) Verify(data []byte, sigB64 string) (bool, error) {
+	sig, err := base64.StdEncoding.DecodeString(sigB64)
+	if err != nil {
+		return false, err
+	}
+	for _, pk := range k.PublicKeys() {
+		if ed25519.Verify(pk.Key, data, sig) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (k *Keyring) VerifyWithKey(kid string, data []byte, sigB64 string) (bool, error) {
+	for _, pk := range k.PublicKeys() {
+		if pk.Kid != kid {
+			continue
+		}
+		sig, err := base64.StdEncoding.DecodeString(sigB64)
+		if err != nil {
+			return false, err
+		}
+		return ed25519.Verify(pk.Key, data, sig), nil
+	}
+	return false, ErrUnknownKey
+}
+
+func validityWindow(pk PublicKey) string {
+	bound := func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+	return "[" + bound(pk.NotBefore) + ", " + bound(pk.NotAfter) + ")"
+}
+
+func trustedAt(pk PublicKey, now time.Time) bool {
+	if !pk.NotBefore.IsZero() && now.Before(pk.NotBefore) {
+		return false
+	}
+	if !pk.NotAfter.IsZero() && !now.Before(pk.NotAfter) {
+		return false
+	}
+	return true
+}
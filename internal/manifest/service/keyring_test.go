@@ -0,0 +1,67 @@
+package service
+
+import (
+	"crypto/ed25519"
+	"testing"
+	"time"
+)
+
+func testKeyringKey(seed byte, notBefore, notAfter time.Time, withPrivate bool) KeyringKey {
+	s := make([]byte, ed25519.SeedSize)
+	for i := range s {
+		s[i] = seed
+	}
+	priv := ed25519.NewKeyFromSeed(s)
+	k := KeyringKey{Public: priv.Public().(ed25519.PublicKey), NotBefore: notBefore, NotAfter: notAfter}
+	if withPrivate {
+		k.Private = priv
+	}
+	return k
+}
+
+func TestNewKeyringActiveWindow(t *testing.T) {
+	now := time.Now()
+	hour := time.Hour
+	tests := []struct {
+		name    string
+		keys    []KeyringKey
+		wantErr bool
+	}{
+		{name: "unbounded", keys: []KeyringKey{testKeyringKey(1, time.Time{}, time.Time{}, true)}},
+		{name: "inside window", keys: []KeyringKey{testKeyringKey(1, now.Add(-hour), now.Add(hour), true)}},
+		{name: "not yet valid", keys: []KeyringKey{testKeyringKey(1, now.Add(hour), time.Time{}, true)}, wantErr: true},
+		{name: "expired", keys: []KeyringKey{testKeyringKey(1, time.Time{}, now.Add(-hour), true)}, wantErr: true},
+		{
+			// выведенный из оборота ключ без приватной части окну не мешает
+			name: "expired retired key",
+			keys: []KeyringKey{
+				testKeyringKey(1, time.Time{}, time.Time{}, true),
+				testKeyringKey(2, now.Add(-2*hour), now.Add(-hour), false),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewKeyring(tt.keys, "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewKeyring err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKeyringStopsSigningAfterNotAfter(t *testing.T) {
+	notAfter := time.Now().Add(time.Hour)
+	kr, err := NewKeyring([]KeyringKey{testKeyringKey(1, time.Time{}, notAfter, true)}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := kr.Sign([]byte("payload")); err != nil {
+		t.Fatalf("Sign inside window: %v", err)
+	}
+
+	kr.now = func() time.Time { return notAfter }
+	if _, err := kr.Sign([]byte("payload")); err == nil {
+		t.Fatal("Sign after notAfter succeeded")
+	}
+}
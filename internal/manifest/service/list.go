@@ -0,0 +1,331 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"pluto-backend/internal/manifest/api/gen"
+	"pluto-backend/internal/manifest/repository"
+)
+
+// ManifestFilter — фильтры каталога; пустые поля не ограничивают выдачу
+type ManifestFilter struct {
+	Category           string
+	Tags               []string
+	MatchAllTags       bool
+	ExcludePermissions []string
+	Author             string
+	CreatedAfter       *time.Time
+	CreatedBefore      *time.Time
+}
+
+// ListManifestsQuery — параметры выдачи каталога.
+// Cursor и Offset взаимоисключающие: offset оставлен только для старых клиентов
+type ListManifestsQuery struct {
+	Filter    ManifestFilter
+	Sort      gen.Sort
+	Limit     int32
+	Offset    int32
+	Cursor    string
+	Locales   []string // цепочка локалей каталога для сортировки по title (utils.LocaleChain): pt → de → en
+	WithTotal bool
+	// Client — возможности клиента: несовместимые с ними манифесты не попадают в выдачу
+	Client ClientCapabilities
+}
+
+// ManifestPage — страница каталога; NextCursor пуст на последней странице,
+// Total (число манифестов под фильтрами) заполняется только по запросу
+type ManifestPage struct {
+	Items      []repository.ListManifestsRow
+	NextCursor string
+	Total      *int64
+}
+
+// listCursor — позиция в выдаче: ключ сортировки последней строки и её id.
+// Клиенту отдаётся непрозрачной base64url-строкой
+type listCursor struct {
+	Sort      gen.Sort  `json:"s"`
+	CreatedAt time.Time `json:"t,omitempty"`
+	Title     string    `json:"title,omitempty"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func encodeCursor(c listCursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(s string) (listCursor, error) {
+	var c listCursor
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, err
+	}
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return c, err
+	}
+	if c.ID == uuid.Nil || (c.Sort != gen.Title && c.CreatedAt.IsZero()) {
+		return c, errors.New("incomplete cursor")
+	}
+	return c, nil
+}
+
+// ListManifests отдаёт страницу каталога; Total считается отдельным запросом и только по просьбе
+func (s *Service) ListManifests(ctx context.Context, q ListManifestsQuery) (ManifestPage, error) {
+	if q.Sort == "" {
+		q.Sort = gen.Newest
+	}
+	f := q.Filter
+	if f.CreatedAfter != nil && f.CreatedBefore != nil && !f.CreatedAfter.Before(*f.CreatedBefore) {
+		return ManifestPage{}, invalidParam("createdBefore", "createdBefore must be later than createdAfter")
+	}
+	if err := checkCapabilities(q.Client); err != nil {
+		return ManifestPage{}, err
+	}
+
+	var c listCursor
+	if q.Cursor != "" {
+		if q.Offset != 0 {
+			return ManifestPage{}, invalidParam("offset", "offset cannot be combined with cursor")
+		}
+		var err error
+		c, err = decodeCursor(q.Cursor)
+		if err != nil {
+			return ManifestPage{}, invalidParam("cursor", "malformed cursor")
+		}
+		if c.Sort != q.Sort {
+			return ManifestPage{}, invalidParam("cursor", "cursor was issued for a different sort order")
+		}
+	}
+
+	filter := listFilter(f, q.Client)
+	rows, err := s.listPage(ctx, q, filter, c)
+	if err != nil {
+		return ManifestPage{}, err
+	}
+
+	page := ManifestPage{Items: rows}
+	if len(rows) > int(q.Limit) {
+		page.Items = rows[:q.Limit]
+		if q.Limit > 0 {
+			last := page.Items[len(page.Items)-1]
+			c := listCursor{Sort: q.Sort, ID: last.ID}
+			if q.Sort == gen.Title {
+				c.Title = last.SortTitle
+			} else {
+				c.CreatedAt = last.CreatedAt
+			}
+			page.NextCursor = encodeCursor(c)
+		}
+	}
+
+	if q.WithTotal {
+		total, err := s.repo.CountManifests(ctx, filter)
+		if err != nil {
+			return ManifestPage{}, err
+		}
+		page.Total = &total
+	}
+
+	return page, nil
+}
+
+// listFilter — фильтры в том виде, в каком их принимают запросы списка и подсчёта
+func listFilter(f ManifestFilter, client ClientCapabilities) repository.CountManifestsParams {
+	p := repository.CountManifestsParams{
+		Category:           nullString(f.Category),
+		ExcludePermissions: nilIfEmpty(f.ExcludePermissions),
+		Author:             nullString(f.Author),
+		CreatedAfter:       nullTime(f.CreatedAfter),
+		CreatedBefore:      nullTime(f.CreatedBefore),
+		ClientAppVersion:   nullString(client.AppVersion),
+		ClientOs:           nullString(client.OS),
+		ClientComponents:   client.Components,
+		ClientPermissions:  client.Permissions,
+	}
+	if f.MatchAllTags {
+		p.TagsAll = nilIfEmpty(f.Tags)
+	} else {
+		p.TagsAny = nilIfEmpty(f.Tags)
+	}
+	return p
+}
+
+// listPage читает страницу запросом своей сортировки: общий ORDER BY через CASE
+// не даёт newest и oldest читаться по idx_manifest_created_at_id. Курсорные страницы
+// (и первая страница) идут без OFFSET, offset-запросы остались только для старых клиентов.
+// Берём на одну строку больше limit, чтобы понять, есть ли следующая страница
+func (s *Service) listPage(
+	ctx context.Context,
+	q ListManifestsQuery,
+	f repository.CountManifestsParams,
+	c listCursor,
+) ([]repository.ListManifestsRow, error) {
+	if q.Offset > 0 {
+		return s.listPageByOffset(ctx, q, f)
+	}
+
+	cursorID := uuid.NullUUID{UUID: c.ID, Valid: c.ID != uuid.Nil}
+	limit := int64(q.Limit) + 1
+
+	switch q.Sort {
+	case gen.Oldest:
+		return listRows(s.repo.ListManifestsOldest(ctx, repository.ListManifestsOldestParams{
+			Category:           f.Category,
+			TagsAny:            f.TagsAny,
+			TagsAll:            f.TagsAll,
+			ExcludePermissions: f.ExcludePermissions,
+			Author:             f.Author,
+			CreatedAfter:       f.CreatedAfter,
+			CreatedBefore:      f.CreatedBefore,
+			ClientAppVersion:   f.ClientAppVersion,
+			ClientOs:           f.ClientOs,
+			ClientComponents:   f.ClientComponents,
+			ClientPermissions:  f.ClientPermissions,
+			CursorID:           cursorID,
+			CursorCreatedAt:    sql.NullTime{Time: c.CreatedAt, Valid: cursorID.Valid},
+			Limit:              limit,
+		}))
+	case gen.Title:
+		return listRows(s.repo.ListManifestsByTitle(ctx, repository.ListManifestsByTitleParams{
+			Locales:            q.Locales,
+			Category:           f.Category,
+			TagsAny:            f.TagsAny,
+			TagsAll:            f.TagsAll,
+			ExcludePermissions: f.ExcludePermissions,
+			Author:             f.Author,
+			CreatedAfter:       f.CreatedAfter,
+			CreatedBefore:      f.CreatedBefore,
+			ClientAppVersion:   f.ClientAppVersion,
+			ClientOs:           f.ClientOs,
+			ClientComponents:   f.ClientComponents,
+			ClientPermissions:  f.ClientPermissions,
+			CursorID:           cursorID,
+			CursorTitle:        sql.NullString{String: c.Title, Valid: cursorID.Valid},
+			Limit:              limit,
+		}))
+	default:
+		return s.repo.ListManifests(ctx, repository.ListManifestsParams{
+			Category:           f.Category,
+			TagsAny:            f.TagsAny,
+			TagsAll:            f.TagsAll,
+			ExcludePermissions: f.ExcludePermissions,
+			Author:             f.Author,
+			CreatedAfter:       f.CreatedAfter,
+			CreatedBefore:      f.CreatedBefore,
+			ClientAppVersion:   f.ClientAppVersion,
+			ClientOs:           f.ClientOs,
+			ClientComponents:   f.ClientComponents,
+			ClientPermissions:  f.ClientPermissions,
+			CursorID:           cursorID,
+			CursorCreatedAt:    sql.NullTime{Time: c.CreatedAt, Valid: cursorID.Valid},
+			Limit:              limit,
+		})
+	}
+}
+
+// listPageByOffset — страница по offset для клиентов, которые ещё не перешли на курсор
+func (s *Service) listPageByOffset(
+	ctx context.Context,
+	q ListManifestsQuery,
+	f repository.CountManifestsParams,
+) ([]repository.ListManifestsRow, error) {
+	limit, offset := int64(q.Limit)+1, int64(q.Offset)
+
+	switch q.Sort {
+	case gen.Oldest:
+		return listRows(s.repo.ListManifestsOldestOffset(ctx, repository.ListManifestsOldestOffsetParams{
+			Category:           f.Category,
+			TagsAny:            f.TagsAny,
+			TagsAll:            f.TagsAll,
+			ExcludePermissions: f.ExcludePermissions,
+			Author:             f.Author,
+			CreatedAfter:       f.CreatedAfter,
+			CreatedBefore:      f.CreatedBefore,
+			ClientAppVersion:   f.ClientAppVersion,
+			ClientOs:           f.ClientOs,
+			ClientComponents:   f.ClientComponents,
+			ClientPermissions:  f.ClientPermissions,
+			Limit:              limit,
+			Offset:             offset,
+		}))
+	case gen.Title:
+		return listRows(s.repo.ListManifestsByTitleOffset(ctx, repository.ListManifestsByTitleOffsetParams{
+			Locales:            q.Locales,
+			Category:           f.Category,
+			TagsAny:            f.TagsAny,
+			TagsAll:            f.TagsAll,
+			ExcludePermissions: f.ExcludePermissions,
+			Author:             f.Author,
+			CreatedAfter:       f.CreatedAfter,
+			CreatedBefore:      f.CreatedBefore,
+			ClientAppVersion:   f.ClientAppVersion,
+			ClientOs:           f.ClientOs,
+			ClientComponents:   f.ClientComponents,
+			ClientPermissions:  f.ClientPermissions,
+			Limit:              limit,
+			Offset:             offset,
+		}))
+	default:
+		return listRows(s.repo.ListManifestsOffset(ctx, repository.ListManifestsOffsetParams{
+			Category:           f.Category,
+			TagsAny:            f.TagsAny,
+			TagsAll:            f.TagsAll,
+			ExcludePermissions: f.ExcludePermissions,
+			Author:             f.Author,
+			CreatedAfter:       f.CreatedAfter,
+			CreatedBefore:      f.CreatedBefore,
+			ClientAppVersion:   f.ClientAppVersion,
+			ClientOs:           f.ClientOs,
+			ClientComponents:   f.ClientComponents,
+			ClientPermissions:  f.ClientPermissions,
+			Limit:              limit,
+			Offset:             offset,
+		}))
+	}
+}
+
+// listRow — строки запросов отдельных сортировок; колонки у них те же, что у ListManifests
+type listRow interface {
+	repository.ListManifestsByTitleRow |
+		repository.ListManifestsByTitleOffsetRow |
+		repository.ListManifestsOffsetRow |
+		repository.ListManifestsOldestRow |
+		repository.ListManifestsOldestOffsetRow
+}
+
+func listRows[T listRow](rows []T, err error) ([]repository.ListManifestsRow, error) {
+	if err != nil {
+		return nil, err
+	}
+	items := make([]repository.ListManifestsRow, len(rows))
+	for i, r := range rows {
+		items[i] = repository.ListManifestsRow(r)
+	}
+	return items, nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+// nilIfEmpty — пустой список в SQL уходит как NULL, т.е. «без фильтра»
+func nilIfEmpty(items []string) []string {
+	if len(items) == 0 {
+		return nil
+	}
+	return items
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"pluto-backend/internal/manifest/api/gen"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	id := uuid.MustParse("6f1c2a4e-8d3b-4f7a-9c1e-2b5d7a9e0f13")
+	createdAt := time.Date(2025, 3, 14, 15, 9, 26, 535897000, time.UTC)
+
+	tests := []struct {
+		name string
+		c    listCursor
+	}{
+		{name: "newest", c: listCursor{Sort: gen.Newest, CreatedAt: createdAt, ID: id}},
+		{name: "oldest", c: listCursor{Sort: gen.Oldest, CreatedAt: createdAt, ID: id}},
+		{name: "title", c: listCursor{Sort: gen.Title, Title: "Ёлка — «Погода»", ID: id}},
+		{name: "empty title", c: listCursor{Sort: gen.Title, ID: id}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := encodeCursor(tt.c)
+			got, err := decodeCursor(s)
+			if err != nil {
+				t.Fatalf("decodeCursor(%q): %v", s, err)
+			}
+			if got.Sort != tt.c.Sort || got.Title != tt.c.Title || got.ID != tt.c.ID || !got.CreatedAt.Equal(tt.c.CreatedAt) {
+				t.Errorf("decodeCursor(encodeCursor(%+v)) = %+v", tt.c, got)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorRejects(t *testing.T) {
+	b64 := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not base64url", cursor: "***"},
+		{name: "padded base64", cursor: b64(`{"s":"title","id":"6f1c2a4e-8d3b-4f7a-9c1e-2b5d7a9e0f13"}`) + "=="},
+		{name: "not json", cursor: b64("title")},
+		{name: "bad id", cursor: b64(`{"s":"title","id":"42"}`)},
+		{name: "no id", cursor: b64(`{"s":"title","title":"a"}`)},
+		{name: "nil id", cursor: b64(`{"s":"title","id":"00000000-0000-0000-0000-000000000000"}`)},
+		{name: "newest without time", cursor: b64(`{"s":"newest","id":"6f1c2a4e-8d3b-4f7a-9c1e-2b5d7a9e0f13"}`)},
+		{name: "bad time", cursor: b64(`{"s":"oldest","t":"yesterday","id":"6f1c2a4e-8d3b-4f7a-9c1e-2b5d7a9e0f13"}`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if c, err := decodeCursor(tt.cursor); err == nil {
+				t.Errorf("decodeCursor(%q) = %+v, want error", tt.cursor, c)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+
+	"pluto-backend/internal/manifest/repository"
+	"pluto-backend/internal/manifest/validation"
+)
+
+// ListPermissions — каталог разрешений с текстами всех локалей, в порядке имени
+func (s *Service) ListPermissions(ctx context.Context) ([]repository.ListPermissionsRow, error) {
+	return s.repo.ListPermissions(ctx)
+}
+
+// checkPermissions отклоняет разрешения, которых нет в каталоге: опечатка иначе была бы
+// сохранена и подписана, а клиент не смог бы показать её на экране согласия
+func checkPermissions(ctx context.Context, q repository.Querier, perms []string) error {
+	rows, err := q.ListPermissions(ctx)
+	if err != nil {
+		return err
+	}
+	known := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		known[row.Name] = true
+	}
+	return validation.Permissions(perms, known)
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"pluto-backend/internal/platform/jwk"
+	"pluto-backend/internal/platform/remotesign"
+)
+
+// RemoteSigner подписывает ключом Ed25519, который хранит внешний сервис подписи
+// (cmd/pluto-signer). Публичная часть запрашивается один раз при создании, и каждая
+// полученная подпись сверяется с ней: сбойный сервис не выдаст битую подпись каталогу
+type RemoteSigner struct {
+	client *remotesign.Client
+	key    string
+	pub    ed25519.PublicKey
+	kid    string
+}
+
+func NewRemoteSigner(ctx context.Context, client *remotesign.Client, key string) (*RemoteSigner, error) {
+	info, err := client.Key(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if info.Algorithm != remotesign.AlgEd25519 {
+		return nil, fmt.Errorf("remote key %q: algorithm %s, want %s", key, info.Algorithm, remotesign.AlgEd25519)
+	}
+	if len(info.PublicKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("remote key %q: public key must be %d bytes", key, ed25519.PublicKeySize)
+	}
+	pub := ed25519.PublicKey(info.PublicKey)
+	return &RemoteSigner{client: client, key: key, pub: pub, kid: jwk.Ed25519Thumbprint(pub)}, nil
+}
+
+func (s *RemoteSigner) Sign(data []byte) (string, error) {
+	sig, err := s.client.Sign(context.Background(), s.key, data)
+	if err != nil {
+		return "", err
+	}
+	if !ed25519.Verify(s.pub, data, sig) {
+		return "", errors.New("remote signer returned a signature that does not verify")
+	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+func (s *RemoteSigner) GetPublicKey() (string, error) {
+	return base64.StdEncoding.EncodeToString(s.pub), nil
+}
+
+func (s *RemoteSigner) KeyID() string {
+	return s.kid
+}
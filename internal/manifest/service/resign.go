@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"pluto-backend/internal/manifest/api/gen"
+	"pluto-backend/internal/manifest/canonical"
+	"pluto-backend/internal/manifest/repository"
+)
+
+// ResignCursor — последняя обработанная версия; переподпись продолжается строго после неё
+type ResignCursor struct {
+	ManifestID uuid.UUID
+	Version    string
+}
+
+// String — форма для флага --after: "<manifest_id>@<version>"
+func (c ResignCursor) String() string {
+	return c.ManifestID.String() + "@" + c.Version
+}
+
+// ParseResignCursor разбирает курсор из ResignCursor.String
+func ParseResignCursor(s string) (ResignCursor, error) {
+	id, version, ok := strings.Cut(s, "@")
+	if !ok {
+		return ResignCursor{}, fmt.Errorf("cursor %q: expected <manifest_id>@<version>", s)
+	}
+	manifestID, err := uuid.Parse(id)
+	if err != nil {
+		return ResignCursor{}, fmt.Errorf("cursor %q: %w", s, err)
+	}
+	return ResignCursor{ManifestID: manifestID, Version: version}, nil
+}
+
+// ResignOptions — параметры переподписи каталога
+type ResignOptions struct {
+	// BatchSize — версий на транзакцию
+	BatchSize int
+	// DryRun — только проверить текущие подписи, ничего не меняя
+	DryRun bool
+	// Force — переподписать и версии, прежняя подпись которых не сходится. Нужен, когда
+	// старый ключ скомпрометирован; иначе такие версии не трогаются, чтобы переподпись
+	// не заверила активным ключом подменённое содержимое
+	Force bool
+	// After — продолжить после этой версии; нулевое значение — с начала
+	After ResignCursor
+	// Progress вызывается после каждой партии
+	Progress func(ResignProgress)
+}
+
+// ResignProgress — счётчики переподписи; Cursor — последняя обработанная версия
+type ResignProgress struct {
+	Total     int64
+	Processed int64
+	// Valid/Invalid — результат проверки прежней подписи доверенными ключами
+	Valid   int64
+	Invalid int64
+	// Resigned — версий, подписанных активным ключом; Current — из них текущих версий манифестов
+	Resigned int64
+	Current  int64
+	Cursor   ResignCursor
+	// Unverified — версии, чья прежняя подпись не сходится; без Force их подпись не менялась
+	Unverified []ResignCursor
+}
+
+// ResignCatalog пересобирает канонический payload каждой версии из сохранённого содержимого
+// и подписывает его активным ключом в текущей схеме канонизации. Берутся только версии,
+// подписанные другим ключом, без detached JWS или в старой схеме, так что повторный запуск после сбоя
+// доделывает оставшееся. Версии, прежняя подпись которых не сходится, пропускаются и попадают
+// в Unverified, если не задан Force. В режиме DryRun подписи лишь проверяются, и обходится весь каталог
+func (s *Service) ResignCatalog(ctx context.Context, opts ResignOptions) (ResignProgress, error) {
+	if opts.BatchSize <= 0 {
+		return ResignProgress{}, errors.New("batch size must be positive")
+	}
+
+	kid := s.signer.KeyID()
+	onlyStale := !opts.DryRun
+
+	total, err := s.repo.CountVersionsForResign(ctx, repository.CountVersionsForResignParams{
+		OnlyStale:        onlyStale,
+		Kid:              kid,
+		CanonicalVersion: currentCanonical,
+	})
+	if err != nil {
+		return ResignProgress{}, err
+	}
+
+	progress := ResignProgress{Total: total, Cursor: opts.After}
+	for {
+		batch, err := s.repo.ListVersionsForResign(ctx, repository.ListVersionsForResignParams{
+			AfterID:          progress.Cursor.ManifestID,
+			AfterVersion:     progress.Cursor.Version,
+			OnlyStale:        onlyStale,
+			Kid:              kid,
+			CanonicalVersion: currentCanonical,
+			BatchSize:        int64(opts.BatchSize),
+		})
+		if err != nil {
+			return progress, err
+		}
+		if len(batch) == 0 {
+			return progress, nil
+		}
+
+		if err := s.resignBatch(ctx, batch, opts, &progress); err != nil {
+			return progress, err
+		}
+		if opts.Progress != nil {
+			opts.Progress(progress)
+		}
+	}
+}
+
+// resignBatch обрабатывает партию в одной транзакции: прерывание не оставит
+// версию и текущую запись манифеста с разными подписями
+func (s *Service) resignBatch(
+	ctx context.Context,
+	batch []repository.ListVersionsForResignRow,
+	opts ResignOptions,
+	progress *ResignProgress,
+) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	q := s.rawRepo.WithTx(tx)
+	kid := nullString(s.signer.KeyID())
+
+	next := *progress
+	next.Unverified = slices.Clip(progress.Unverified)
+	for _, row := range batch {
+		m, err := versionManifest(row)
+		if err != nil {
+			return fmt.Errorf("manifest %s@%s: %w", row.ManifestID, row.Version, err)
+		}
+
+		// прежняя подпись проверяется в той схеме, в которой была сделана
+		stored, err := canonical.Payload(int(row.CanonicalVersion), row.ManifestID, row.Version, row.CreatedAt, m, row.Script)
+		if err != nil {
+			return fmt.Errorf("manifest %s@%s: %w", row.ManifestID, row.Version, err)
+		}
+		cursor := ResignCursor{ManifestID: row.ManifestID, Version: row.Version}
+		verified := s.verifyStored(stored, row.Signature, row.SignatureKid.String)
+		if verified {
+			next.Valid++
+		} else {
+			next.Invalid++
+			next.Unverified = append(next.Unverified, cursor)
+		}
+		next.Processed++
+		next.Cursor = cursor
+
+		if opts.DryRun || (!verified && !opts.Force) {
+			continue
+		}
+
+		payload, err := canonical.Payload(currentCanonical, row.ManifestID, row.Version, row.CreatedAt, m, row.Script)
+		if err != nil {
+			return fmt.Errorf("manifest %s@%s: %w", row.ManifestID, row.Version, err)
+		}
+		signature, jws, err := s.sign(payload)
+		if err != nil {
+			return err
+		}
+		if err := q.UpdateVersionSignature(ctx, repository.UpdateVersionSignatureParams{
+			Signature:        signature,
+			SignatureKid:     kid,
+			SignatureJws:     nullString(jws),
+			CanonicalVersion: currentCanonical,
+			ManifestID:       row.ManifestID,
+			Version:          row.Version,
+		}); err != nil {
+			return err
+		}
+		updated, err := q.UpdateManifestSignature(ctx, repository.UpdateManifestSignatureParams{
+			Signature:        signature,
+			SignatureKid:     kid,
+			CanonicalVersion: currentCanonical,
+			ID:               row.ManifestID,
+			Version:          row.Version,
+		})
+		if err != nil {
+			return err
+		}
+		if err := appendLog(ctx, q, row.ManifestID, row.Version, payload, signature, jws, kid); err != nil {
+			return err
+		}
+		next.Resigned++
+		next.Current += updated
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	*progress = next
+	return nil
+}
+
+// verifyStored — прежняя подпись сделана одним из доверенных ключей
+func (s *Service) verifyStored(payload []byte, signature, kid string) bool {
+	var (
+		ok  bool
+		err error
+	)
+	if kid != "" {
+		ok, err = s.signer.VerifyWithKey(kid, payload, signature)
+	} else {
+		ok, err = s.signer.Verify(payload, signature)
+	}
+	return err == nil && ok
+}
+
+// versionManifest восстанавливает манифест версии из её сохранённой копии
+func versionManifest(row repository.ListVersionsForResignRow) (gen.ManifestCreate, error) {
+	var actions *[]gen.ManifestActionBase
+	if err := json.Unmarshal(row.Actions, &actions); err != nil {
+		return gen.ManifestCreate{}, err
+	}
+	var localization gen.ManifestLocalizationCreate
+	if err := json.Unmarshal(row.Localization, &localization); err != nil {
+		return gen.ManifestCreate{}, err
+	}
+
+	return gen.ManifestCreate{
+		Actions: actions,
+		Author: gen.Author{
+			Name:  row.AuthorName,
+			Email: row.AuthorEmail,
+		},
+		Category:     row.Category,
+		Icon:         row.Icon,
+		Localization: localization,
+		Permissions:  row.Permissions,
+		Tags:         row.Tags,
+		Ui:           row.Ui,
+
+		AuthorSignature: storedAuthorSignature(row.AuthorKid, row.AuthorSignature),
+	}, nil
+}
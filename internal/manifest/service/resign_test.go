@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"pluto-backend/internal/manifest/api/gen"
+	"pluto-backend/internal/manifest/canonical"
+	"pluto-backend/internal/manifest/repository"
+)
+
+// recordDriver — database/sql драйвер без базы: запоминает запросы, Exec затрагивает
+// одну строку, Query отдаёт одну строку с единственной колонкой 1
+type recordDriver struct {
+	mu      sync.Mutex
+	queries []string
+}
+
+func (d *recordDriver) Open(string) (driver.Conn, error) { return recordConn{d}, nil }
+
+func (d *recordDriver) record(query string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.queries = append(d.queries, query)
+}
+
+// writes — запросы, меняющие данные
+func (d *recordDriver) writes() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var out []string
+	for _, q := range d.queries {
+		if strings.Contains(q, "UPDATE ") || strings.Contains(q, "INSERT ") {
+			out = append(out, q)
+		}
+	}
+	return out
+}
+
+type recordConn struct{ d *recordDriver }
+
+func (c recordConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c recordConn) Close() error                        { return nil }
+func (c recordConn) Begin() (driver.Tx, error)           { return c, nil }
+func (c recordConn) Commit() error                       { return nil }
+func (c recordConn) Rollback() error                     { return nil }
+
+func (c recordConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.d.record(query)
+	return driver.RowsAffected(1), nil
+}
+
+func (c recordConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.d.record(query)
+	return &oneRow{}, nil
+}
+
+type oneRow struct{ done bool }
+
+func (r *oneRow) Columns() []string { return []string{"id"} }
+func (r *oneRow) Close() error      { return nil }
+func (r *oneRow) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = int64(1)
+	return nil
+}
+
+func newResignTestService(t *testing.T) (*Service, *recordDriver) {
+	t.Helper()
+	d := &recordDriver{}
+	name := "resign-" + t.Name()
+	sql.Register(name, d)
+	db, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	priv := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	signer, err := NewKeyring([]KeyringKey{{Private: priv, Public: priv.Public().(ed25519.PublicKey)}}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return New(db, signer), d
+}
+
+// resignRow — версия в старой схеме, подписанная активным ключом связки
+func resignRow(t *testing.T, s *Service) repository.ListVersionsForResignRow {
+	t.Helper()
+	localization, _ := json.Marshal(gen.ManifestLocalizationCreate{
+		"en": {"title": "Weather", "description": "Forecast"},
+	})
+	row := repository.ListVersionsForResignRow{
+		ManifestID:       uuid.MustParse("6f1c2a4e-8d3b-4f7a-9c1e-2b5d7a9e0f13"),
+		Version:          "1.2.0",
+		Icon:             "cloud",
+		Category:         "weather",
+		Tags:             []string{"forecast"},
+		AuthorName:       "Jane",
+		AuthorEmail:      "jane@example.com",
+		Ui:               json.RawMessage(`{"layout":"column","components":[]}`),
+		Script:           "",
+		Actions:          json.RawMessage(`null`),
+		Permissions:      []string{},
+		Localization:     localization,
+		SignatureKid:     nullString(s.signer.KeyID()),
+		CanonicalVersion: 1,
+		CreatedAt:        time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC),
+	}
+	m, err := versionManifest(row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := canonical.Payload(int(row.CanonicalVersion), row.ManifestID, row.Version, row.CreatedAt, m, row.Script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	row.Signature, err = s.signer.Sign(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return row
+}
+
+func TestResignBatch(t *testing.T) {
+	tests := []struct {
+		name           string
+		tamper         bool
+		opts           ResignOptions
+		wantResigned   int64
+		wantUnverified bool
+	}{
+		{name: "valid", wantResigned: 1},
+		{name: "tampered", tamper: true, wantUnverified: true},
+		{name: "tampered dry run", tamper: true, opts: ResignOptions{DryRun: true}, wantUnverified: true},
+		{name: "tampered force", tamper: true, opts: ResignOptions{Force: true}, wantResigned: 1, wantUnverified: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, d := newResignTestService(t)
+			row := resignRow(t, s)
+			if tt.tamper {
+				// подпись другого содержимого тем же ключом
+				other, err := s.signer.Sign([]byte("other payload"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				row.Signature = other
+			}
+
+			var p ResignProgress
+			if err := s.resignBatch(context.Background(), []repository.ListVersionsForResignRow{row}, tt.opts, &p); err != nil {
+				t.Fatalf("resignBatch: %v", err)
+			}
+
+			if p.Processed != 1 || p.Resigned != tt.wantResigned {
+				t.Errorf("processed = %d, resigned = %d, want 1, %d", p.Processed, p.Resigned, tt.wantResigned)
+			}
+			want := ResignCursor{ManifestID: row.ManifestID, Version: row.Version}
+			if p.Cursor != want {
+				t.Errorf("cursor = %s, want %s", p.Cursor, want)
+			}
+			if tt.wantUnverified {
+				if len(p.Unverified) != 1 || p.Unverified[0] != want || p.Invalid != 1 {
+					t.Errorf("unverified = %v, invalid = %d, want [%s], 1", p.Unverified, p.Invalid, want)
+				}
+			} else if len(p.Unverified) != 0 || p.Valid != 1 {
+				t.Errorf("unverified = %v, valid = %d, want none, 1", p.Unverified, p.Valid)
+			}
+
+			writes := d.writes()
+			if tt.wantResigned == 0 && len(writes) != 0 {
+				t.Errorf("signature of %s changed: %q", want, writes)
+			}
+			if tt.wantResigned == 1 && len(writes) == 0 {
+				t.Errorf("%s not re-signed", want)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"pluto-backend/internal/manifest/repository"
+	"pluto-backend/pkg/manifestsig"
+)
+
+// defaultRevocationTTL — срок действия подписанного списка отзыва: клиент, который
+// дольше не может его обновить, должен считать себя отрезанным от kill switch
+const defaultRevocationTTL = 24 * time.Hour
+
+// revocationCache хранит последний подписанный список: он пересобирается, когда
+// появился новый отзыв или прошла половина срока действия
+type revocationCache struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	sequence int64
+	list     []byte
+	issued   time.Time
+}
+
+// SetRevocationExpiry задаёт срок действия списка отзыва; нулевое значение оставляет умолчание
+func (s *Service) SetRevocationExpiry(ttl time.Duration) {
+	s.revocations.mu.Lock()
+	defer s.revocations.mu.Unlock()
+	if ttl > 0 {
+		s.revocations.ttl = ttl
+	}
+}
+
+// RevokeManifest отзывает манифест целиком (пустой version) или одну его версию.
+// Отзыв необратим и сразу попадает в следующий список отзыва
+func (s *Service) RevokeManifest(ctx context.Context, id uuid.UUID, version, reason string) (repository.Revocation, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return repository.Revocation{}, invalidParam("reason", "must not be empty")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return repository.Revocation{}, err
+	}
+	defer tx.Rollback()
+
+	q := s.rawRepo.WithTx(tx)
+
+	if version == "" {
+		if _, err := q.GetManifest(ctx, id); err != nil {
+			return repository.Revocation{}, notFound(err, ErrManifestNotFound)
+		}
+	} else if _, err := q.GetManifestVersion(ctx, repository.GetManifestVersionParams{
+		ManifestID: id,
+		Version:    version,
+	}); err != nil {
+		return repository.Revocation{}, notFound(err, ErrVersionNotFound)
+	}
+
+	if err := q.LockRevocations(ctx); err != nil {
+		return repository.Revocation{}, err
+	}
+	rev, err := q.CreateRevocation(ctx, repository.CreateRevocationParams{
+		ManifestID: id,
+		Version:    nullString(version),
+		Reason:     reason,
+	})
+	if isUniqueViolation(err) {
+		return repository.Revocation{}, ErrAlreadyRevoked
+	}
+	if err != nil {
+		return repository.Revocation{}, err
+	}
+
+	return rev, tx.Commit()
+}
+
+// ManifestRevocation — отзыв, действующий на версию манифеста, или nil
+func (s *Service) ManifestRevocation(ctx context.Context, id uuid.UUID, version string) (*repository.Revocation, error) {
+	rev, err := s.repo.GetManifestRevocation(ctx, repository.GetManifestRevocationParams{
+		ManifestID: id,
+		Version:    version,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rev, nil
+}
+
+// RevocationList — подписанный список отзыва; байты одинаковы, пока список не пересобран
+func (s *Service) RevocationList(ctx context.Context) ([]byte, error) {
+	c := &s.revocations
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sequence, err := s.repo.GetRevocationSequence(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if c.list != nil && sequence == c.sequence && now.Sub(c.issued) < c.ttl/2 {
+		return c.list, nil
+	}
+
+	// sequence мог вырасти между запросами; список читается целиком и номер берётся из него
+	rows, err := s.repo.ListRevocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list := manifestsig.RevocationList{
+		Type:    manifestsig.TypeRevocations,
+		Expires: now.Add(c.ttl).UTC().Truncate(time.Second),
+		Revoked: make([]manifestsig.Revocation, 0, len(rows)),
+	}
+	for _, row := range rows {
+		list.Sequence = row.Sequence
+		list.Revoked = append(list.Revoked, manifestsig.Revocation{
+			ManifestID: row.ManifestID.String(),
+			Version:    row.Version.String,
+			Reason:     row.Reason,
+			RevokedAt:  row.RevokedAt.UTC(),
+		})
+	}
+
+	signed, err := s.signMetadata(list)
+	if err != nil {
+		return nil, err
+	}
+	c.sequence, c.list, c.issued = list.Sequence, signed, now
+	return signed, nil
+}
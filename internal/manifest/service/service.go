@@ -4,18 +4,25 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"pluto-backend/internal/manifest/api/gen"
+	"pluto-backend/internal/manifest/canonical"
+	"pluto-backend/internal/manifest/validation"
 
 	"pluto-backend/internal/manifest/repository"
 )
 
 type Service struct {
-	repo    repository.Querier
-	rawRepo *repository.Queries
-	db      *sql.DB
-	signer  Signer
+	repo        repository.Querier
+	rawRepo     *repository.Queries
+	db          *sql.DB
+	signer      Signer
+	catalog     catalogCache
+	log         logCache
+	revocations revocationCache
 }
 
 func New(db *sql.DB, signer Signer) *Service {
@@ -25,6 +32,11 @@ func New(db *sql.DB, signer Signer) *Service {
 		rawRepo: raw,
 		db:      db,
 		signer:  signer,
+		catalog: catalogCache{
+			snapshotTTL:  defaultSnapshotTTL,
+			timestampTTL: defaultTimestampTTL,
+		},
+		revocations: revocationCache{ttl: defaultRevocationTTL},
 	}
 }
 
@@ -37,24 +49,42 @@ func (s *Service) GetPublicKey(ctx context.Context) (string, error) {
 
 }
 
-func (s *Service) ListManifests(ctx context.Context, limit, offset int32, locale string) ([]repository.ListManifestsRow, error) {
-	params := repository.ListManifestsParams{Limit: int64(limit), Offset: int64(offset), Column3: locale}
-	return s.repo.ListManifests(ctx, params)
+// SigningKeys — ключи, которым сейчас доверяет сервис, активный первым
+func (s *Service) SigningKeys() []PublicKey {
+	return s.signer.PublicKeys()
 }
 
-func (s *Service) SearchManifests(ctx context.Context, search string, locale string) ([]repository.SearchManifestsRow, error) {
-	params := repository.SearchManifestsParams{Search: search, Locale: locale}
-	return s.repo.SearchManifests(ctx, params)
+// Locales — все локали каталога
+func (s *Service) Locales(ctx context.Context) ([]string, error) {
+	return s.repo.ListLocales(ctx)
 }
 
-func (s *Service) SearchManifestsFTS(ctx context.Context, query string, locale string, config string) ([]repository.SearchManifestsFTSRow, error) {
-	params := repository.SearchManifestsFTSParams{Query: query, Locale: locale, Config: config}
+// SearchManifestsFTS — полнотекстовый поиск; несовместимые с client манифесты не попадают в выдачу
+func (s *Service) SearchManifestsFTS(
+	ctx context.Context,
+	query string,
+	locales []string,
+	config string,
+	client ClientCapabilities,
+) ([]repository.SearchManifestsFTSRow, error) {
+	if err := checkCapabilities(client); err != nil {
+		return nil, err
+	}
+	params := repository.SearchManifestsFTSParams{
+		Query:             query,
+		Locales:           locales,
+		Config:            config,
+		ClientAppVersion:  nullString(client.AppVersion),
+		ClientOs:          nullString(client.OS),
+		ClientComponents:  client.Components,
+		ClientPermissions: client.Permissions,
+	}
 	return s.repo.SearchManifestsFTS(ctx, params)
 }
 
-func (s *Service) GetManifestById(ctx context.Context, id uuid.UUID, locale string) (repository.GetManifestRow, error) {
-	params := repository.GetManifestParams{ManifestID: id, Locale: locale}
-	return s.repo.GetManifest(ctx, params)
+func (s *Service) GetManifestById(ctx context.Context, id uuid.UUID) (repository.GetManifestRow, error) {
+	row, err := s.repo.GetManifest(ctx, id)
+	return row, notFound(err, ErrManifestNotFound)
 }
 
 func (s *Service) CreateManifest(ctx context.Context, req gen.ManifestCreate) (uuid.UUID, error) {
@@ -63,42 +93,40 @@ func (s *Service) CreateManifest(ctx context.Context, req gen.ManifestCreate) (u
 		return uuid.Nil, err
 	}
 
-	enLocale, ok := req.Localization["en"]
-	if !ok {
-		return uuid.Nil, errors.New("localization must include 'en'")
+	scriptCode, err := validation.Manifest(req)
+	if err != nil {
+		return uuid.Nil, err
 	}
-	if _, ok := enLocale["title"]; !ok {
-		return uuid.Nil, errors.New("localization 'en' must include 'title'")
+	if err := checkComponents(ctx, s.repo, req.Ui); err != nil {
+		return uuid.Nil, err
 	}
-	if _, ok := enLocale["description"]; !ok {
-		return uuid.Nil, errors.New("localization 'en' must include 'description'")
+	if err := checkPermissions(ctx, s.repo, req.Permissions); err != nil {
+		return uuid.Nil, err
 	}
 
-	var locales, keys, values []string
-	for locale, entries := range req.Localization {
-		for key, value := range entries {
-			locales = append(locales, locale)
-			keys = append(keys, key)
-			values = append(values, value)
-		}
-	}
+	locales, keys, values := flattenLocalization(req.Localization)
 
-	scriptCode, err := extractScriptCode(req.Script)
-	if err != nil {
+	if err := verifyAuthorSignature(ctx, s.repo, canonical.InitialVersion, req, scriptCode); err != nil {
 		return uuid.Nil, err
 	}
 
+	// время публикации входит в подпись, поэтому задаём его сами, а не через now() в БД;
+	// точность — как у timestamptz
+	createdAt := time.Now().UTC().Truncate(time.Microsecond)
+
 	// формируем каноническое представление
-	payload, err := buildCanonicalPayload(id, "1.0.0", req, scriptCode)
+	payload, err := canonical.Payload(currentCanonical, id, canonical.InitialVersion, createdAt, req, scriptCode)
 	if err != nil {
 		return uuid.Nil, err
 	}
 
 	// подписываем
-	signature, err := s.signer.Sign(payload)
+	signature, jws, err := s.sign(payload)
 	if err != nil {
 		return uuid.Nil, err
 	}
+	kid := nullString(s.signer.KeyID())
+	authorKid, authorSignature := authorColumns(req.AuthorSignature)
 
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -109,14 +137,19 @@ func (s *Service) CreateManifest(ctx context.Context, req gen.ManifestCreate) (u
 	q := s.rawRepo.WithTx(tx)
 
 	if _, err := q.CreateManifest(ctx, repository.CreateManifestParams{
-		ID:          id,
-		Version:     "1.0.0",
-		Icon:        req.Icon,
-		Category:    req.Category,
-		Tags:        req.Tags,
-		AuthorName:  req.Author.Name,
-		AuthorEmail: req.Author.Email,
-		Signature:   signature,
+		ID:               id,
+		Version:          canonical.InitialVersion,
+		Icon:             req.Icon,
+		Category:         req.Category,
+		Tags:             req.Tags,
+		AuthorName:       req.Author.Name,
+		AuthorEmail:      req.Author.Email,
+		CreatedAt:        createdAt,
+		Signature:        signature,
+		SignatureKid:     kid,
+		CanonicalVersion: currentCanonical,
+		AuthorKid:        authorKid,
+		AuthorSignature:  authorSignature,
 	}); err != nil {
 		return uuid.Nil, err
 	}
@@ -135,6 +168,12 @@ func (s *Service) CreateManifest(ctx context.Context, req gen.ManifestCreate) (u
 	}); err != nil {
 		return uuid.Nil, err
 	}
+	if err := setComponentTypes(ctx, q, id, req.Ui); err != nil {
+		return uuid.Nil, err
+	}
+	if err := setCompatibility(ctx, q, id, req.Compatibility); err != nil {
+		return uuid.Nil, err
+	}
 
 	// — localizations (batch insert)
 	if err := q.CreateLocalizations(ctx, repository.CreateLocalizationsParams{
@@ -146,6 +185,15 @@ func (s *Service) CreateManifest(ctx context.Context, req gen.ManifestCreate) (u
 		return uuid.Nil, err
 	}
 
+	// — неизменяемая копия релиза
+	if err := createVersion(ctx, q, id, canonical.InitialVersion, req, []string{}, []string{}, scriptCode, actionsJSON, signature, jws, kid); err != nil {
+		return uuid.Nil, err
+	}
+
+	if err := appendLog(ctx, q, id, canonical.InitialVersion, payload, signature, jws, kid); err != nil {
+		return uuid.Nil, err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return uuid.Nil, err
 	}
@@ -153,12 +201,230 @@ func (s *Service) CreateManifest(ctx context.Context, req gen.ManifestCreate) (u
 	return id, nil
 }
 
-func extractScriptCode(raw json.RawMessage) (string, error) {
-	var payload struct {
-		Code string `json:"code"`
+// UpdateManifest сливает частичное обновление с сохранённым манифестом,
+// поднимает версию, переподписывает и заменяет контент в одной транзакции.
+func (s *Service) UpdateManifest(ctx context.Context, id uuid.UUID, req gen.ManifestUpdate) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
 	}
-	if err := json.Unmarshal(raw, &payload); err != nil {
-		return "", err
+	defer tx.Rollback()
+
+	q := s.rawRepo.WithTx(tx)
+
+	// блокируем строку, чтобы параллельные PATCH не перетёрли друг друга
+	cur, err := q.GetManifestForUpdate(ctx, id)
+	if err != nil {
+		return notFound(err, ErrManifestNotFound)
+	}
+	locRows, err := q.ListLocalizations(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	current, err := manifestFromStored(cur, locRows)
+	if err != nil {
+		return err
+	}
+	next := mergeManifest(current, req)
+
+	// совместимость не входит ни в payload платформы, ни в payload автора (canonical.AuthorPayload):
+	// подпись автора её не удостоверяет, поэтому PATCH только совместимости меняет её без новой
+	// версии и намеренно минует verifyAuthorSignature — проверять пришлось бы подпись неизменённого
+	// содержимого. Смена автора или содержимого ниже по-прежнему идёт через проверки автора
+	if req.Compatibility != nil {
+		if err := validation.Compatibility(req.Compatibility); err != nil {
+			return err
+		}
+		if err := setCompatibility(ctx, q, id, req.Compatibility); err != nil {
+			return err
+		}
+	}
+
+	bump, changed := inferBump(current, next)
+	if req.Bump != nil {
+		bump, changed = *req.Bump, true
+	}
+	// подпись автора к неизменённому содержимому выпускается отдельным patch-релизом
+	if !changed && req.AuthorSignature != nil {
+		bump, changed = gen.Patch, true
+	}
+	if !changed {
+		return tx.Commit()
+	}
+
+	scriptCode, err := validation.Manifest(next)
+	if err != nil {
+		return err
+	}
+	if err := checkComponents(ctx, q, next.Ui); err != nil {
+		return err
+	}
+	if err := checkPermissions(ctx, q, next.Permissions); err != nil {
+		return err
+	}
+
+	version, err := canonical.BumpVersion(cur.Version, bump)
+	if err != nil {
+		return err
+	}
+
+	if err := checkAuthorTransfer(ctx, q, current, next); err != nil {
+		return err
+	}
+	if err := verifyAuthorSignature(ctx, q, version, next, scriptCode); err != nil {
+		return err
+	}
+
+	payload, err := canonical.Payload(currentCanonical, id, version, cur.CreatedAt, next, scriptCode)
+	if err != nil {
+		return err
+	}
+
+	signature, jws, err := s.sign(payload)
+	if err != nil {
+		return err
+	}
+	kid := nullString(s.signer.KeyID())
+	authorKid, authorSignature := authorColumns(next.AuthorSignature)
+
+	if err := q.UpdateManifest(ctx, repository.UpdateManifestParams{
+		Version:          version,
+		Icon:             next.Icon,
+		Category:         next.Category,
+		Tags:             next.Tags,
+		AuthorName:       next.Author.Name,
+		AuthorEmail:      next.Author.Email,
+		Signature:        signature,
+		SignatureKid:     kid,
+		CanonicalVersion: currentCanonical,
+		AuthorKid:        authorKid,
+		AuthorSignature:  authorSignature,
+		ID:               id,
+	}); err != nil {
+		return err
+	}
+
+	actionsJSON, err := json.Marshal(next.Actions)
+	if err != nil {
+		return err
+	}
+	if err := q.UpdateManifestContent(ctx, repository.UpdateManifestContentParams{
+		Ui:          next.Ui,
+		Script:      scriptCode,
+		Actions:     actionsJSON,
+		Permissions: next.Permissions,
+		ManifestID:  id,
+	}); err != nil {
+		return err
+	}
+	if err := setComponentTypes(ctx, q, id, next.Ui); err != nil {
+		return err
+	}
+
+	// локализации проще заменить целиком, чем вычислять дельту
+	if err := q.DeleteLocalizations(ctx, id); err != nil {
+		return err
+	}
+	locales, keys, values := flattenLocalization(next.Localization)
+	if err := q.CreateLocalizations(ctx, repository.CreateLocalizationsParams{
+		ManifestID: id,
+		Locales:    locales,
+		Keys:       keys,
+		Values:     values,
+	}); err != nil {
+		return err
+	}
+
+	// клиент с предыдущей версией должен снова спросить согласие на добавленные разрешения
+	added, removed := diffPermissions(current.Permissions, next.Permissions)
+	if err := createVersion(ctx, q, id, version, next, added, removed, scriptCode, actionsJSON, signature, jws, kid); err != nil {
+		return err
+	}
+
+	if err := appendLog(ctx, q, id, version, payload, signature, jws, kid); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *Service) ListManifestVersions(ctx context.Context, id uuid.UUID) ([]repository.ListManifestVersionsRow, error) {
+	versions, err := s.repo.ListManifestVersions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// у опубликованного манифеста всегда есть хотя бы одна версия
+	if len(versions) == 0 {
+		return nil, ErrManifestNotFound
+	}
+	return versions, nil
+}
+
+func (s *Service) GetManifestVersion(ctx context.Context, id uuid.UUID, version string) (repository.GetManifestVersionRow, error) {
+	params := repository.GetManifestVersionParams{ManifestID: id, Version: version}
+	row, err := s.repo.GetManifestVersion(ctx, params)
+	return row, notFound(err, ErrVersionNotFound)
+}
+
+// createVersion сохраняет подписанный релиз в историю версий вместе с разрешениями,
+// добавленными и убранными относительно предыдущей версии; у первой версии оба списка пусты
+func createVersion(
+	ctx context.Context,
+	q *repository.Queries,
+	id uuid.UUID,
+	version string,
+	m gen.ManifestCreate,
+	added []string,
+	removed []string,
+	scriptCode string,
+	actionsJSON []byte,
+	signature string,
+	jws string,
+	kid sql.NullString,
+) error {
+	localizationJSON, err := json.Marshal(m.Localization)
+	if err != nil {
+		return err
+	}
+	authorKid, authorSignature := authorColumns(m.AuthorSignature)
+
+	err = q.CreateManifestVersion(ctx, repository.CreateManifestVersionParams{
+		ManifestID:         id,
+		Version:            version,
+		Icon:               m.Icon,
+		Category:           m.Category,
+		Tags:               m.Tags,
+		AuthorName:         m.Author.Name,
+		AuthorEmail:        m.Author.Email,
+		Ui:                 m.Ui,
+		Script:             scriptCode,
+		Actions:            actionsJSON,
+		Permissions:        m.Permissions,
+		PermissionsAdded:   added,
+		PermissionsRemoved: removed,
+		Localization:       localizationJSON,
+		Signature:          signature,
+		SignatureKid:       kid,
+		SignatureJws:       nullString(jws),
+		CanonicalVersion:   currentCanonical,
+		AuthorKid:          authorKid,
+		AuthorSignature:    authorSignature,
+	})
+	if isUniqueViolation(err) {
+		return ErrVersionConflict
+	}
+	return err
+}
+
+// flattenLocalization раскладывает локализации в параллельные массивы для batch insert
+func flattenLocalization(loc gen.ManifestLocalizationCreate) (locales, keys, values []string) {
+	for locale, entries := range loc {
+		for key, value := range entries {
+			locales = append(locales, locale)
+			keys = append(keys, key)
+			values = append(values, value)
+		}
 	}
-	return payload.Code, nil
+	return locales, keys, values
 }
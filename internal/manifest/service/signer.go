@@ -3,21 +3,42 @@ package service
 import (
 	"crypto/ed25519"
 	"encoding/base64"
+	"time"
+
+	"pluto-backend/internal/platform/jwk"
 )
 
 type Signer interface {
 	Sign(data []byte) (string, error)
+	// Verify проверяет подпись любым из доверенных сейчас ключей
 	Verify(data []byte, sig string) (bool, error)
+	// VerifyWithKey проверяет подпись конкретным ключом; ErrUnknownKey, если kid не доверен
+	VerifyWithKey(kid string, data []byte, sig string) (bool, error)
+	// GetPublicKey — base64 публичного ключа, которым подписываем сейчас
 	GetPublicKey() (string, error)
+	// KeyID — kid ключа, которым подписываем сейчас
+	KeyID() string
+	// PublicKeys — все доверенные сейчас ключи, активный первым
+	PublicKeys() []PublicKey
+}
+
+// PublicKey — публикуемый ключ и окно его доверия; нулевое время — без ограничения
+type PublicKey struct {
+	Kid       string
+	Key       ed25519.PublicKey
+	Active    bool
+	NotBefore time.Time
+	NotAfter  time.Time
 }
 
 type Ed25519Signer struct {
 	priv ed25519.PrivateKey
 	pub  ed25519.PublicKey
+	kid  string
 }
 
 func NewEd25519Signer(privKey ed25519.PrivateKey, pubKey ed25519.PublicKey) *Ed25519Signer {
-	return &Ed25519Signer{priv: privKey, pub: pubKey}
+	return &Ed25519Signer{priv: privKey, pub: pubKey, kid: jwk.Ed25519Thumbprint(pubKey)}
 }
 
 func (s *Ed25519Signer) GetPublicKey() (string, error) {
@@ -25,6 +46,14 @@ func (s *Ed25519Signer) GetPublicKey() (string, error) {
 	return pubKeyB64, nil
 }
 
+func (s *Ed25519Signer) KeyID() string {
+	return s.kid
+}
+
+func (s *Ed25519Signer) PublicKeys() []PublicKey {
+	return []PublicKey{{Kid: s.kid, Key: s.pub, Active: true}}
+}
+
 func (s *Ed25519Signer) Sign(data []byte) (string, error) {
 	sig := ed25519.Sign(s.priv, data)
 	return base64.StdEncoding.EncodeToString(sig), nil
@@ -38,3 +67,10 @@ func (s *Ed25519Signer) Verify(data []byte, sigB64 string) (bool, error) {
 	ok := ed25519.Verify(s.pub, data, sig)
 	return ok, nil
 }
+
+func (s *Ed25519Signer) VerifyWithKey(kid string, data []byte, sigB64 string) (bool, error) {
+	if kid != s.kid {
+		return false, ErrUnknownKey
+	}
+	return s.Verify(data, sigB64)
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"pluto-backend/internal/manifest/repository"
+	"pluto-backend/pkg/manifestsig"
+)
+
+// maxLogEntries — сколько записей журнала отдаётся за один запрос
+const maxLogEntries = 1000
+
+// logCache держит хеши всех листьев журнала в памяти: журнал только дополняется,
+// поэтому достаточно дочитывать хвост. Голова дерева переподписывается, когда он вырос
+type logCache struct {
+	mu     sync.Mutex
+	leaves [][]byte
+	head   *manifestsig.SignedTreeHead
+}
+
+// InclusionProof — путь аудита листа к корню дерева размера TreeSize
+type InclusionProof struct {
+	LeafIndex int64
+	TreeSize  int64
+	AuditPath [][]byte
+}
+
+// ConsistencyProof доказывает, что дерево размера First — префикс дерева размера Second
+type ConsistencyProof struct {
+	First  int64
+	Second int64
+	Proof  [][]byte
+}
+
+// LogEntry — лист журнала в том виде, в каком он хешируется, и время добавления
+type LogEntry struct {
+	LeafIndex int64
+	Entry     manifestsig.LogEntry
+	LeafData  []byte
+	CreatedAt time.Time
+}
+
+// appendLog вносит выданные подписи версии — над payload и detached JWS — в журнал
+// в транзакции, которая их сохраняет: откат транзакции не оставит в журнале подписи,
+// которой нет в каталоге
+func appendLog(
+	ctx context.Context,
+	q *repository.Queries,
+	id uuid.UUID,
+	version string,
+	payload []byte,
+	signature string,
+	jws string,
+	kid sql.NullString,
+) error {
+	sum := sha256.Sum256(payload)
+	entry := manifestsig.LogEntry{
+		ManifestID:       id.String(),
+		Version:          version,
+		CanonicalVersion: currentCanonical,
+		PayloadSHA256:    hex.EncodeToString(sum[:]),
+		Signature:        signature,
+		SignatureKid:     kid.String,
+	}
+	jwsEntry := entry
+	jwsEntry.Signature, jwsEntry.Format = jws, manifestsig.LogFormatJWS
+
+	// индекс листа — max+1, поэтому писатели идут строго по очереди до коммита
+	if err := q.LockTransparencyLog(ctx); err != nil {
+		return err
+	}
+	for _, e := range []manifestsig.LogEntry{entry, jwsEntry} {
+		data, err := e.LeafData()
+		if err != nil {
+			return err
+		}
+		if _, err := q.AppendLogEntry(ctx, repository.AppendLogEntryParams{
+			LeafHash:     manifestsig.LeafHash(data),
+			LeafData:     data,
+			ManifestID:   id,
+			Version:      version,
+			SignatureKid: kid,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TreeHead — подписанная голова журнала по всем закоммиченным записям
+func (s *Service) TreeHead(ctx context.Context) (manifestsig.SignedTreeHead, error) {
+	s.log.mu.Lock()
+	defer s.log.mu.Unlock()
+
+	if err := s.syncLog(ctx); err != nil {
+		return manifestsig.SignedTreeHead{}, err
+	}
+	size := int64(len(s.log.leaves))
+	if s.log.head != nil && s.log.head.TreeSize == size {
+		return *s.log.head, nil
+	}
+
+	head := manifestsig.TreeHead{
+		TreeSize:  size,
+		RootHash:  manifestsig.RootHash(s.log.leaves),
+		Timestamp: time.Now().UTC().Truncate(time.Second),
+	}
+	payload, err := manifestsig.MetadataPayload(head)
+	if err != nil {
+		return manifestsig.SignedTreeHead{}, err
+	}
+	sig, err := s.signer.Sign(payload)
+	if err != nil {
+		return manifestsig.SignedTreeHead{}, err
+	}
+	s.log.head = &manifestsig.SignedTreeHead{TreeHead: head, KeyID: s.signer.KeyID(), Signature: sig}
+	return *s.log.head, nil
+}
+
+// InclusionProof ищет лист по хешу (base64) и строит путь к корню дерева размера treeSize;
+// нулевой treeSize — текущий размер журнала
+func (s *Service) InclusionProof(ctx context.Context, hash string, treeSize int64) (InclusionProof, error) {
+	leafHash, err := base64.StdEncoding.DecodeString(hash)
+	if err != nil || len(leafHash) != sha256.Size {
+		return InclusionProof{}, invalidParam("hash", "must be a base64 SHA-256 leaf hash")
+	}
+	if treeSize < 0 {
+		return InclusionProof{}, invalidParam("treeSize", "must not be negative")
+	}
+
+	index, err := s.repo.GetLogLeafIndex(ctx, leafHash)
+	if err != nil {
+		return InclusionProof{}, notFound(err, ErrLogEntryNotFound)
+	}
+
+	leaves, err := s.logLeaves(ctx)
+	if err != nil {
+		return InclusionProof{}, err
+	}
+	size := int64(len(leaves))
+	if treeSize == 0 {
+		treeSize = size
+	}
+	if treeSize > size {
+		return InclusionProof{}, invalidParam("treeSize", "exceeds the current tree size")
+	}
+	// лист мог попасть в журнал после запрошенного состояния
+	if index >= treeSize {
+		return InclusionProof{}, ErrLogEntryNotFound
+	}
+
+	return InclusionProof{
+		LeafIndex: index,
+		TreeSize:  treeSize,
+		AuditPath: manifestsig.InclusionProof(leaves[:treeSize], int(index)),
+	}, nil
+}
+
+// ConsistencyProof между деревьями размеров first и second; нулевой second — текущий размер
+func (s *Service) ConsistencyProof(ctx context.Context, first, second int64) (ConsistencyProof, error) {
+	leaves, err := s.logLeaves(ctx)
+	if err != nil {
+		return ConsistencyProof{}, err
+	}
+	size := int64(len(leaves))
+	if second == 0 {
+		second = size
+	}
+	switch {
+	case first <= 0:
+		return ConsistencyProof{}, invalidParam("first", "must be positive")
+	case second > size:
+		return ConsistencyProof{}, invalidParam("second", "exceeds the current tree size")
+	case first > second:
+		return ConsistencyProof{}, invalidParam("first", "must not exceed second")
+	}
+
+	return ConsistencyProof{
+		First:  first,
+		Second: second,
+		Proof:  manifestsig.ConsistencyProof(leaves[:second], int(first)),
+	}, nil
+}
+
+// LogEntries — записи журнала с индексами [start, end); end ограничен maxLogEntries
+// записями и не обязан быть меньше размера дерева
+func (s *Service) LogEntries(ctx context.Context, start, end int64) ([]LogEntry, error) {
+	switch {
+	case start < 0:
+		return nil, invalidParam("start", "must not be negative")
+	case end <= start:
+		return nil, invalidParam("end", "must be greater than start")
+	}
+	if end-start > maxLogEntries {
+		end = start + maxLogEntries
+	}
+
+	rows, err := s.repo.ListLogEntries(ctx, repository.ListLogEntriesParams{StartIndex: start, EndIndex: end})
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]LogEntry, 0, len(rows))
+	for _, row := range rows {
+		var entry manifestsig.LogEntry
+		if err := json.Unmarshal(row.LeafData, &entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, LogEntry{
+			LeafIndex: row.LeafIndex,
+			Entry:     entry,
+			LeafData:  row.LeafData,
+			CreatedAt: row.CreatedAt,
+		})
+	}
+	return entries, nil
+}
+
+// logLeaves — снимок хешей листьев; срез только дополняется, поэтому его можно
+// читать после снятия блокировки
+func (s *Service) logLeaves(ctx context.Context) ([][]byte, error) {
+	s.log.mu.Lock()
+	defer s.log.mu.Unlock()
+
+	if err := s.syncLog(ctx); err != nil {
+		return nil, err
+	}
+	return s.log.leaves[:len(s.log.leaves):len(s.log.leaves)], nil
+}
+
+// syncLog дочитывает листья, добавленные после прошлого обращения
+func (s *Service) syncLog(ctx context.Context) error {
+	rows, err := s.repo.ListLogLeafHashes(ctx, int64(len(s.log.leaves)))
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		s.log.leaves = append(s.log.leaves, row.LeafHash)
+	}
+	return nil
+}
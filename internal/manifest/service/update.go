@@ -0,0 +1,163 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+
+	"pluto-backend/internal/manifest/api/gen"
+	"pluto-backend/internal/manifest/repository"
+)
+
+// manifestFromStored собирает текущее состояние манифеста в форме запроса на создание,
+// чтобы обновление и подпись шли через те же функции, что и CreateManifest
+func manifestFromStored(
+	cur repository.GetManifestForUpdateRow,
+	locRows []repository.ListLocalizationsRow,
+) (gen.ManifestCreate, error) {
+	script, err := json.Marshal(map[string]string{"code": cur.Script})
+	if err != nil {
+		return gen.ManifestCreate{}, err
+	}
+
+	var actions *[]gen.ManifestActionBase
+	if err := json.Unmarshal(cur.Actions, &actions); err != nil {
+		return gen.ManifestCreate{}, err
+	}
+
+	loc := gen.ManifestLocalizationCreate{}
+	for _, row := range locRows {
+		if loc[row.Locale] == nil {
+			loc[row.Locale] = map[string]string{}
+		}
+		loc[row.Locale][row.Key] = row.Value
+	}
+
+	return gen.ManifestCreate{
+		Actions: actions,
+		Author: gen.Author{
+			Name:  cur.AuthorName,
+			Email: cur.AuthorEmail,
+		},
+		Category:     cur.Category,
+		Icon:         cur.Icon,
+		Localization: loc,
+		Permissions:  cur.Permissions,
+		Script:       script,
+		Tags:         cur.Tags,
+		Ui:           cur.Ui,
+	}, nil
+}
+
+// mergeManifest накладывает заданные поля PATCH-запроса на текущее состояние.
+// Переданная локаль заменяет сохранённую целиком, остальные локали не трогаются.
+// Подпись автора не наследуется: она относится к прежней версии
+func mergeManifest(cur gen.ManifestCreate, req gen.ManifestUpdate) gen.ManifestCreate {
+	next := cur
+	next.AuthorSignature = req.AuthorSignature
+
+	if req.Icon != nil {
+		next.Icon = *req.Icon
+	}
+	if req.Category != nil {
+		next.Category = *req.Category
+	}
+	if req.Tags != nil {
+		next.Tags = *req.Tags
+	}
+	if req.Author != nil {
+		next.Author = *req.Author
+	}
+	if req.Ui != nil {
+		next.Ui = *req.Ui
+	}
+	if req.Script != nil {
+		next.Script = *req.Script
+	}
+	if req.Actions != nil {
+		next.Actions = req.Actions
+	}
+	if req.Permissions != nil {
+		next.Permissions = *req.Permissions
+	}
+	if req.Compatibility != nil {
+		next.Compatibility = req.Compatibility
+	}
+	if req.Localization != nil {
+		loc := make(gen.ManifestLocalizationCreate, len(cur.Localization))
+		for locale, entries := range cur.Localization {
+			loc[locale] = entries
+		}
+		for locale, entries := range *req.Localization {
+			loc[locale] = entries
+		}
+		next.Localization = loc
+	}
+
+	return next
+}
+
+// inferBump выводит часть версии из характера изменений:
+// новые разрешения — major, изменения ui/script/actions или отзыв разрешений — minor,
+// мета и локализация — patch. Второе значение false, если менять нечего.
+func inferBump(cur, next gen.ManifestCreate) (gen.ManifestUpdateBump, bool) {
+	added, removed := diffPermissions(cur.Permissions, next.Permissions)
+	if len(added) > 0 {
+		return gen.Major, true
+	}
+
+	curActions, _ := json.Marshal(cur.Actions)
+	nextActions, _ := json.Marshal(next.Actions)
+	if len(removed) > 0 ||
+		!jsonEqual(cur.Ui, next.Ui) ||
+		!jsonEqual(cur.Script, next.Script) ||
+		!jsonEqual(curActions, nextActions) {
+		return gen.Minor, true
+	}
+
+	if cur.Icon != next.Icon ||
+		cur.Category != next.Category ||
+		cur.Author != next.Author ||
+		!reflect.DeepEqual(cur.Tags, next.Tags) ||
+		!reflect.DeepEqual(cur.Permissions, next.Permissions) ||
+		!reflect.DeepEqual(cur.Localization, next.Localization) {
+		return gen.Patch, true
+	}
+
+	return "", false
+}
+
+// diffPermissions возвращает разрешения, которые появились и которые пропали, в порядке
+// манифеста; списки не nil, чтобы их можно было сохранить в NOT NULL колонку
+func diffPermissions(before, after []string) (added, removed []string) {
+	added, removed = []string{}, []string{}
+	was := make(map[string]bool, len(before))
+	for _, p := range before {
+		was[p] = true
+	}
+	now := make(map[string]bool, len(after))
+	for _, p := range after {
+		now[p] = true
+		if !was[p] {
+			added = append(added, p)
+		}
+	}
+	for _, p := range before {
+		if !now[p] {
+			removed = append(removed, p)
+		}
+	}
+	return added, removed
+}
+
+// jsonEqual сравнивает JSON по смыслу: jsonb в базе не сохраняет порядок ключей и пробелы
+func jsonEqual(a, b []byte) bool {
+	var va, vb any
+	if err := json.Unmarshal(a, &va); err != nil {
+		return bytes.Equal(a, b)
+	}
+	if err := json.Unmarshal(b, &vb); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
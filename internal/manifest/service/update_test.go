@@ -0,0 +1,143 @@
+package service
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"pluto-backend/internal/manifest/api/gen"
+)
+
+func TestDiffPermissions(t *testing.T) {
+	tests := []struct {
+		name        string
+		before      []string
+		after       []string
+		wantAdded   []string
+		wantRemoved []string
+	}{
+		{name: "both empty", wantAdded: []string{}, wantRemoved: []string{}},
+		{
+			name:        "unchanged",
+			before:      []string{"network", "storage"},
+			after:       []string{"network", "storage"},
+			wantAdded:   []string{},
+			wantRemoved: []string{},
+		},
+		{
+			name:        "reordered",
+			before:      []string{"network", "storage"},
+			after:       []string{"storage", "network"},
+			wantAdded:   []string{},
+			wantRemoved: []string{},
+		},
+		{
+			name:        "added in manifest order",
+			before:      []string{"network"},
+			after:       []string{"location", "network", "camera"},
+			wantAdded:   []string{"location", "camera"},
+			wantRemoved: []string{},
+		},
+		{
+			name:        "removed in manifest order",
+			before:      []string{"camera", "network", "location"},
+			after:       []string{"network"},
+			wantAdded:   []string{},
+			wantRemoved: []string{"camera", "location"},
+		},
+		{
+			name:        "replaced",
+			before:      []string{"camera"},
+			after:       []string{"microphone"},
+			wantAdded:   []string{"microphone"},
+			wantRemoved: []string{"camera"},
+		},
+		{
+			name:        "from nothing",
+			after:       []string{"network"},
+			wantAdded:   []string{"network"},
+			wantRemoved: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, removed := diffPermissions(tt.before, tt.after)
+			// nil в NOT NULL колонку не сохранить, поэтому сравниваем с учётом nil
+			if !reflect.DeepEqual(added, tt.wantAdded) {
+				t.Errorf("added = %#v, want %#v", added, tt.wantAdded)
+			}
+			if !reflect.DeepEqual(removed, tt.wantRemoved) {
+				t.Errorf("removed = %#v, want %#v", removed, tt.wantRemoved)
+			}
+		})
+	}
+}
+
+func TestInferBump(t *testing.T) {
+	base := gen.ManifestCreate{
+		Category:    "tools",
+		Icon:        "star",
+		Tags:        []string{"a", "b"},
+		Permissions: []string{"network", "storage"},
+		Ui:          json.RawMessage(`{"type":"list","items":[]}`),
+		Script:      json.RawMessage(`{"code":"main()"}`),
+		Localization: map[string]map[string]string{
+			"en": {"title": "Title"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		change func(m *gen.ManifestCreate)
+		want   gen.ManifestUpdateBump
+		ok     bool
+	}{
+		{name: "nothing changed", change: func(*gen.ManifestCreate) {}},
+		{
+			name:   "ui key order only",
+			change: func(m *gen.ManifestCreate) { m.Ui = json.RawMessage(`{ "items": [], "type": "list" }`) },
+		},
+		{
+			name:   "permission added",
+			change: func(m *gen.ManifestCreate) { m.Permissions = []string{"network", "storage", "camera"} },
+			want:   gen.Major, ok: true,
+		},
+		{
+			name:   "permission removed",
+			change: func(m *gen.ManifestCreate) { m.Permissions = []string{"network"} },
+			want:   gen.Minor, ok: true,
+		},
+		{
+			name:   "script changed",
+			change: func(m *gen.ManifestCreate) { m.Script = json.RawMessage(`{"code":"main(1)"}`) },
+			want:   gen.Minor, ok: true,
+		},
+		{
+			name:   "permissions reordered",
+			change: func(m *gen.ManifestCreate) { m.Permissions = []string{"storage", "network"} },
+			want:   gen.Patch, ok: true,
+		},
+		{
+			name:   "icon changed",
+			change: func(m *gen.ManifestCreate) { m.Icon = "moon" },
+			want:   gen.Patch, ok: true,
+		},
+		{
+			name: "localization changed",
+			change: func(m *gen.ManifestCreate) {
+				m.Localization = map[string]map[string]string{"en": {"title": "Other"}}
+			},
+			want: gen.Patch, ok: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := base
+			tt.change(&next)
+			got, ok := inferBump(base, next)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("inferBump = (%q, %v), want (%q, %v)", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+
+	"pluto-backend/pkg/manifestsig"
+)
+
+// VerifyManifest проверяет подпись присланного клиентом манифеста ключами сервиса:
+// указан signatureKid — только этим ключом, иначе любым доверенным. Если передан
+// signatureJws, он проверяется ключом из своего заголовка, authorSignature — ключом
+// автора из GET /api/authors/keys. Для v2 строки локализации дополнительно сверяются
+// с подписанным деревом хешей.
+// nil — манифест подлинный; manifestsig.ErrNoSignature, ErrMalformedSignature,
+// ErrMalformedJWS, ErrSignatureMismatch, ErrUnknownKey и ErrLocalizationMismatch — не подлинный
+func (s *Service) VerifyManifest(ctx context.Context, m manifestsig.Manifest) error {
+	if m.CanonicalVersion >= manifestsig.CanonicalV2 && m.Meta.CreatedAt.IsZero() {
+		return invalid("/meta/createdAt", "meta.createdAt is required since canonicalVersion 2")
+	}
+	payload, err := manifestsig.Canonical(m)
+	if errors.Is(err, manifestsig.ErrUnsupportedCanonical) {
+		return invalid("/canonicalVersion", err.Error())
+	}
+	if err != nil {
+		return invalid("/script", "script must be an object with 'code'")
+	}
+	if m.Signature == "" {
+		return manifestsig.ErrNoSignature
+	}
+
+	var ok bool
+	if m.SignatureKid != "" {
+		ok, err = s.signer.VerifyWithKey(m.SignatureKid, payload, m.Signature)
+	} else {
+		ok, err = s.signer.Verify(payload, m.Signature)
+	}
+	if errors.Is(err, ErrUnknownKey) {
+		return manifestsig.ErrUnknownKey
+	}
+	if err != nil {
+		return manifestsig.ErrMalformedSignature
+	}
+	if !ok {
+		return manifestsig.ErrSignatureMismatch
+	}
+
+	if m.SignatureJWS != "" {
+		if err := s.verifyJWS(m.SignatureJWS, payload); err != nil {
+			return err
+		}
+	}
+	if m.AuthorSignature != nil {
+		if err := s.verifyAuthor(ctx, m); err != nil {
+			return err
+		}
+	}
+	return manifestsig.VerifyLocalization(m)
+}
+
+func (s *Service) verifyJWS(jws string, payload []byte) error {
+	h, protected, sig, err := manifestsig.ParseDetachedJWS(jws)
+	if err != nil {
+		return err
+	}
+	ok, err := s.signer.VerifyWithKey(h.Kid, manifestsig.JWSSigningInput(protected, payload), base64.StdEncoding.EncodeToString(sig))
+	if errors.Is(err, ErrUnknownKey) {
+		return manifestsig.ErrUnknownKey
+	}
+	if err != nil {
+		return manifestsig.ErrMalformedJWS
+	}
+	if !ok {
+		return manifestsig.ErrSignatureMismatch
+	}
+	return nil
+}
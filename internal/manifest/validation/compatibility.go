@@ -0,0 +1,76 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"pluto-backend/internal/manifest/api/gen"
+	"pluto-backend/internal/manifest/canonical"
+)
+
+// Compatibility проверяет диапазон версий приложения и список ОС манифеста
+func Compatibility(c *gen.ManifestCompatibility) error {
+	if c == nil {
+		return nil
+	}
+
+	var errs Errors
+	versionOK := func(v *string, pointer string) bool {
+		if v == nil {
+			return false
+		}
+		if !canonical.ValidVersion(*v) {
+			errs = append(errs, &Error{Pointer: pointer, Message: "must be MAJOR.MINOR.PATCH"})
+			return false
+		}
+		return true
+	}
+	minOK := versionOK(c.MinAppVersion, "/compatibility/minAppVersion")
+	maxOK := versionOK(c.MaxAppVersion, "/compatibility/maxAppVersion")
+	if minOK && maxOK {
+		if cmp, _ := canonical.CompareVersions(*c.MinAppVersion, *c.MaxAppVersion); cmp > 0 {
+			errs = append(errs, &Error{Pointer: "/compatibility/maxAppVersion", Message: "must not be lower than minAppVersion"})
+		}
+	}
+
+	if c.Os != nil {
+		for i, os := range *c.Os {
+			if os == "" || os != strings.ToLower(strings.TrimSpace(os)) {
+				errs = append(errs, &Error{
+					Pointer: fmt.Sprintf("/compatibility/os/%d", i),
+					Message: "must be a non-empty lowercase name such as ios or android",
+				})
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// ComponentTypes — различные типы ui.components в порядке сортировки; выдача сверяет их
+// с возможностями клиента. Разбор ui уже проверен UI, поэтому ошибка даёт пустой список
+func ComponentTypes(raw gen.ManifestUiBase) []string {
+	var u struct {
+		Components []struct {
+			Type string `json:"type"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(raw, &u); err != nil {
+		return []string{}
+	}
+	seen := make(map[string]bool, len(u.Components))
+	types := make([]string, 0, len(u.Components))
+	for _, c := range u.Components {
+		if c.Type != "" && !seen[c.Type] {
+			seen[c.Type] = true
+			types = append(types, c.Type)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
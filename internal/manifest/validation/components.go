@@ -0,0 +1,74 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"pluto-backend/internal/manifest/api/gen"
+	platformerrors "pluto-backend/internal/platform/errors"
+)
+
+// Registry — поддерживаемые типы компонентов UI и схемы их props
+// (реестр ui_components, GET /api/components)
+type Registry map[string]*openapi3.Schema
+
+// ParsePropsSchema разбирает JSON Schema props из реестра; схема — подмножество OpenAPI 3.0
+func ParsePropsSchema(raw json.RawMessage) (*openapi3.Schema, error) {
+	var schema openapi3.Schema
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// Components проверяет каждый ui.components[]: type есть в реестре, props подходят под его
+// схему. Возвращает Errors со всеми нарушениями; указатели — от корня тела манифеста
+func Components(raw gen.ManifestUiBase, reg Registry) error {
+	var u struct {
+		Components []struct {
+			Type  string `json:"type"`
+			Props any    `json:"props"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(raw, &u); err != nil {
+		return Invalid("/ui", "ui must be an object with 'layout' and 'components'")
+	}
+
+	var errs Errors
+	for i, c := range u.Components {
+		schema, ok := reg[c.Type]
+		if !ok {
+			errs = append(errs, &Error{
+				Pointer: fmt.Sprintf("/ui/components/%d/type", i),
+				Message: fmt.Sprintf("unknown component type %q, supported: %s", c.Type, reg.types()),
+			})
+			continue
+		}
+		err := schema.VisitJSON(c.Props, openapi3.MultiErrors())
+		if err == nil {
+			continue
+		}
+		prefix := fmt.Sprintf("/ui/components/%d/props", i)
+		for _, fe := range platformerrors.FieldErrors(err) {
+			errs = append(errs, &Error{Pointer: prefix + fe.Pointer, Message: fe.Message})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (r Registry) types() string {
+	types := make([]string, 0, len(r))
+	for t := range r {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return strings.Join(types, ", ")
+}
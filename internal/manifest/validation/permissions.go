@@ -0,0 +1,67 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+)
+
+// maxSuggestDistance — с какой разницей в символах имя из каталога ещё считается опечаткой
+const maxSuggestDistance = 2
+
+// Permissions проверяет, что каждое разрешение есть в каталоге known (таблица permissions,
+// GET /api/permissions); для опечатки подсказывает ближайшее известное имя
+func Permissions(perms []string, known map[string]bool) error {
+	var errs Errors
+	for i, p := range perms {
+		if known[p] {
+			continue
+		}
+		msg := fmt.Sprintf("unknown permission %q", p)
+		if s := suggest(p, known); s != "" {
+			msg += fmt.Sprintf(", did you mean %q?", s)
+		}
+		errs = append(errs, &Error{Pointer: fmt.Sprintf("/permissions/%d", i), Message: msg})
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// suggest — ближайшее по расстоянию Левенштейна имя из known, если оно достаточно близко
+func suggest(name string, known map[string]bool) string {
+	names := make([]string, 0, len(known))
+	for k := range known {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	best, bestDist := "", maxSuggestDistance+1
+	for _, k := range names {
+		if d := levenshtein(name, k); d < bestDist {
+			best, bestDist = k, d
+		}
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	x, y := []rune(a), []rune(b)
+	prev := make([]int, len(y)+1)
+	cur := make([]int, len(y)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(x); i++ {
+		cur[0] = i
+		for j := 1; j <= len(y); j++ {
+			cost := 1
+			if x[i-1] == y[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(y)]
+}
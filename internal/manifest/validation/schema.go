@@ -0,0 +1,98 @@
+package validation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+
+	"pluto-backend/internal/manifest/api/gen"
+	platformerrors "pluto-backend/internal/platform/errors"
+)
+
+var (
+	createBodyOnce sync.Once
+	createBody     *openapi3.RequestBody
+	createBodyErr  error
+)
+
+// createRequestBody — описание тела POST /api/manifests из встроенной спецификации
+func createRequestBody() (*openapi3.RequestBody, error) {
+	createBodyOnce.Do(func() {
+		spec, err := gen.GetSwagger()
+		if err != nil {
+			createBodyErr = err
+			return
+		}
+		item := spec.Paths.Find("/api/manifests")
+		if item == nil || item.Post == nil || item.Post.RequestBody == nil {
+			createBodyErr = errors.New("spec has no request body for POST /api/manifests")
+			return
+		}
+		createBody = item.Post.RequestBody.Value
+	})
+	return createBody, createBodyErr
+}
+
+// Schema проверяет тело POST /api/manifests по OpenAPI-спецификации тем же валидатором
+// kin-openapi, что стоит в middleware manifest-service; ошибки — в том виде, в каком их
+// вернул бы ответ 400
+func Schema(body []byte) ([]*Error, error) {
+	rb, err := createRequestBody()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "/api/manifests", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	input := &openapi3filter.RequestValidationInput{
+		Request: req,
+		Options: &openapi3filter.Options{MultiError: true},
+	}
+	err = openapi3filter.ValidateRequestBody(context.Background(), input, rb)
+	if err == nil {
+		return nil, nil
+	}
+
+	var out []*Error
+	for _, fe := range platformerrors.FieldErrors(err) {
+		out = append(out, &Error{Pointer: fe.Pointer, Parameter: fe.Parameter, Message: fe.Message})
+	}
+	return out, nil
+}
+
+// Request — все проверки тела POST /api/manifests, которые сервер выполняет до обращения
+// к базе: схема OpenAPI, затем Manifest. Подпись автора сверяется с ключами из базы
+// уже при публикации. Пустой список — тело будет принято
+func Request(body []byte) ([]*Error, error) {
+	problems, err := Schema(body)
+	if err != nil || len(problems) > 0 {
+		return problems, err
+	}
+
+	var m gen.ManifestCreate
+	if err := json.Unmarshal(body, &m); err != nil {
+		return []*Error{{Message: "invalid request body"}}, nil
+	}
+	if _, err := Manifest(m); err != nil {
+		var errs Errors
+		if errors.As(err, &errs) {
+			return errs, nil
+		}
+		var ve *Error
+		if errors.As(err, &ve) {
+			return []*Error{ve}, nil
+		}
+		return nil, err
+	}
+	return nil, nil
+}
@@ -0,0 +1,120 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"pluto-backend/internal/manifest/api/gen"
+)
+
+// Errors — несколько нарушений одной проверки; API отдаёт их одним ответом 400
+type Errors []*Error
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// ui — то, что рендерер клиента читает из ManifestUiBase. В Go это json.RawMessage,
+// поэтому связность ссылок схема не проверяет
+type ui struct {
+	Layout struct {
+		Children []struct {
+			Ref string `json:"$ref"`
+		} `json:"children"`
+	} `json:"layout"`
+	Components []struct {
+		ID      string `json:"id"`
+		Actions []struct {
+			OnTap string `json:"onTap"`
+		} `json:"actions"`
+	} `json:"components"`
+}
+
+// handlerDecl — объявления функций верхнего уровня в коде скрипта:
+// function name(…), async function name(…), const|let|var name = …
+var handlerDecl = regexp.MustCompile(
+	`(?m)^\s*(?:export\s+)?(?:(?:async\s+)?function\s*\*?\s*([A-Za-z_$][\w$]*)|(?:const|let|var)\s+([A-Za-z_$][\w$]*)\s*=)`)
+
+// Handlers — имена обработчиков, объявленных в коде скрипта
+func Handlers(code string) map[string]bool {
+	out := map[string]bool{}
+	for _, m := range handlerDecl.FindAllStringSubmatch(code, -1) {
+		if m[1] != "" {
+			out[m[1]] = true
+		} else {
+			out[m[2]] = true
+		}
+	}
+	return out
+}
+
+// UI проверяет связность интерфейса: id компонентов уникальны, каждый layout.children[].$ref
+// ссылается на существующий компонент, а onTap компонентов и actions — на обработчик,
+// объявленный в скрипте. Возвращает Errors со всеми нарушениями сразу
+func UI(raw gen.ManifestUiBase, actions *[]gen.ManifestActionBase, scriptCode string) error {
+	var u ui
+	if err := json.Unmarshal(raw, &u); err != nil {
+		return Invalid("/ui", "ui must be an object with 'layout' and 'components'")
+	}
+	handlers := Handlers(scriptCode)
+
+	var errs Errors
+	ids := make(map[string]int, len(u.Components))
+	for i, c := range u.Components {
+		if first, ok := ids[c.ID]; ok {
+			errs = append(errs, &Error{
+				Pointer: fmt.Sprintf("/ui/components/%d/id", i),
+				Message: fmt.Sprintf("duplicate component id %q, already used by /ui/components/%d", c.ID, first),
+			})
+			continue
+		}
+		ids[c.ID] = i
+	}
+
+	for i, child := range u.Layout.Children {
+		if _, ok := ids[child.Ref]; !ok {
+			errs = append(errs, &Error{
+				Pointer: fmt.Sprintf("/ui/layout/children/%d/$ref", i),
+				Message: fmt.Sprintf("no component with id %q", child.Ref),
+			})
+		}
+	}
+
+	for i, c := range u.Components {
+		for j, a := range c.Actions {
+			if !handlers[a.OnTap] {
+				errs = append(errs, unknownHandler(fmt.Sprintf("/ui/components/%d/actions/%d/onTap", i, j), a.OnTap))
+			}
+		}
+	}
+
+	if actions != nil {
+		for i, rawAction := range *actions {
+			var a struct {
+				OnTap string `json:"onTap"`
+			}
+			if err := json.Unmarshal(rawAction, &a); err != nil {
+				errs = append(errs, &Error{Pointer: fmt.Sprintf("/actions/%d", i), Message: "action must be an object"})
+				continue
+			}
+			if !handlers[a.OnTap] {
+				errs = append(errs, unknownHandler(fmt.Sprintf("/actions/%d/onTap", i), a.OnTap))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func unknownHandler(pointer, name string) *Error {
+	return &Error{Pointer: pointer, Message: fmt.Sprintf("handler %q is not declared in script", name)}
+}
@@ -0,0 +1,80 @@
+// Package validation — проверки манифеста, общие для manifest-service и cmd/pluto-manifest:
+// автор узнаёт о проблемах до публикации по тем же правилам, что применит сервер
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"pluto-backend/internal/manifest/api/gen"
+)
+
+// Error — запрос не прошёл проверку. Pointer — JSON Pointer (RFC 6901)
+// на поле тела, Parameter — имя query-параметра; заполнено одно из двух
+type Error struct {
+	Pointer   string
+	Parameter string
+	Message   string
+}
+
+func (e *Error) Error() string {
+	if e.Parameter != "" {
+		return fmt.Sprintf("%s: %s", e.Parameter, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.Pointer, e.Message)
+}
+
+// Invalid — ошибка поля тела запроса
+func Invalid(pointer, message string) error {
+	return &Error{Pointer: pointer, Message: message}
+}
+
+// InvalidParam — ошибка query-параметра
+func InvalidParam(name, message string) error {
+	return &Error{Parameter: name, Message: message}
+}
+
+// Manifest — проверки CreateManifest и UpdateManifest, которым не нужна база;
+// возвращает код скрипта
+func Manifest(m gen.ManifestCreate) (string, error) {
+	if err := Localization(m.Localization); err != nil {
+		return "", err
+	}
+	code, err := ScriptCode(m.Script)
+	if err != nil {
+		return "", err
+	}
+	if err := UI(m.Ui, m.Actions, code); err != nil {
+		return "", err
+	}
+	if err := Compatibility(m.Compatibility); err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// Localization требует английскую локаль с title и description: это откат для всех остальных
+func Localization(loc gen.ManifestLocalizationCreate) error {
+	enLocale, ok := loc["en"]
+	if !ok {
+		return Invalid("/localization", "localization must include 'en'")
+	}
+	if _, ok := enLocale["title"]; !ok {
+		return Invalid("/localization/en/title", "localization 'en' must include 'title'")
+	}
+	if _, ok := enLocale["description"]; !ok {
+		return Invalid("/localization/en/description", "localization 'en' must include 'description'")
+	}
+	return nil
+}
+
+// ScriptCode достаёт код из объекта script
+func ScriptCode(raw json.RawMessage) (string, error) {
+	var payload struct {
+		Code string `json:"code"`
+	}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return "", Invalid("/script", "script must be an object with 'code'")
+	}
+	return payload.Code, nil
+}
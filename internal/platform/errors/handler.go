@@ -2,60 +2,123 @@ package errors
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
 	middleware "github.com/oapi-codegen/nethttp-middleware"
+	"pluto-backend/internal/platform/problem"
 )
 
-type ErrorResponse struct {
-	Timestamp string   `json:"timestamp"`
-	Path      string   `json:"path"`
-	Errors    []string `json:"errors"`
+// ErrorHandlerWithMultiError — обработчик ошибок OpenAPI-валидатора
+func ErrorHandlerWithMultiError(
+	ctx context.Context,
+	err error,
+	w http.ResponseWriter,
+	r *http.Request,
+	opts middleware.ErrorHandlerOpts,
+) {
+	status := opts.StatusCode
+	if status == 0 {
+		status = http.StatusBadRequest
+	}
+	if errors.Is(err, routers.ErrMethodNotAllowed) {
+		status = http.StatusMethodNotAllowed
+	}
+
+	problem.Write(w, r, validationProblem(status, err))
 }
 
-func NiceErrorHandler(err error, r *http.Request) (int, []byte) {
-	resp := ErrorResponse{
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
-		Path:      r.URL.Path,
-		Errors:    make([]string, 0),
+// ChiErrorHandler — обработчик ошибок разбора параметров в сгенерированном роутере
+func ChiErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	problem.Write(w, r, validationProblem(http.StatusBadRequest, err))
+}
+
+func validationProblem(status int, err error) *problem.Problem {
+	switch status {
+	case http.StatusNotFound:
+		return problem.New(status, problem.CodeNotFound, firstLine(err.Error()))
+	case http.StatusMethodNotAllowed:
+		return problem.New(status, problem.CodeMethodNotAllowed, firstLine(err.Error()))
+	case http.StatusUnauthorized:
+		return problem.New(status, problem.CodeUnauthorized, firstLine(err.Error()))
+	case http.StatusBadRequest:
+		return problem.New(status, problem.CodeValidation, "request does not match the API schema").
+			WithErrors(fieldErrors(err, "")...)
+	default:
+		return problem.New(status, problem.CodeInternal, firstLine(err.Error()))
 	}
+}
 
-	if me, ok := err.(openapi3.MultiError); ok {
+// FieldErrors раскладывает ошибку валидатора kin-openapi на ошибки по полям с JSON Pointer —
+// так же, как их видит клиент в ответе 400
+func FieldErrors(err error) []problem.FieldError {
+	return fieldErrors(err, "")
+}
+
+// fieldErrors раскладывает ошибки kin-openapi на ошибки по полям с JSON Pointer
+func fieldErrors(err error, param string) []problem.FieldError {
+	var me openapi3.MultiError
+	if errors.As(err, &me) {
+		var out []problem.FieldError
 		for _, e := range me {
-			msg := e.Error()
-			if idx := strings.IndexRune(msg, '\n'); idx != -1 {
-				msg = msg[:idx]
-			}
-			resp.Errors = append(resp.Errors, msg)
+			out = append(out, fieldErrors(e, param)...)
 		}
-	} else {
-		resp.Errors = append(resp.Errors, err.Error())
+		return out
 	}
 
-	data, _ := json.Marshal(resp)
-	return http.StatusBadRequest, data
+	var re *openapi3filter.RequestError
+	if errors.As(err, &re) {
+		if re.Parameter != nil {
+			param = re.Parameter.Name
+		}
+		if re.Err != nil && (errors.As(re.Err, &me) || isSchemaError(re.Err)) {
+			return fieldErrors(re.Err, param)
+		}
+		msg := re.Reason
+		if msg == "" && re.Err != nil {
+			msg = re.Err.Error()
+		}
+		return []problem.FieldError{{Parameter: param, Message: firstLine(msg)}}
+	}
+
+	var se *openapi3.SchemaError
+	if errors.As(err, &se) {
+		fe := problem.FieldError{Parameter: param, Message: se.Reason}
+		if param == "" {
+			fe.Pointer = jsonPointer(se.JSONPointer())
+		}
+		return []problem.FieldError{fe}
+	}
+
+	return []problem.FieldError{{Parameter: param, Message: firstLine(err.Error())}}
 }
 
-func ErrorHandlerWithMultiError(
-	ctx context.Context,
-	err error,
-	w http.ResponseWriter,
-	r *http.Request,
-	opts middleware.ErrorHandlerOpts,
-) {
-	status, body := NiceErrorHandler(err, r)
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	w.Write(body)
+func isSchemaError(err error) bool {
+	var se *openapi3.SchemaError
+	return errors.As(err, &se)
 }
 
-func ChiErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
-	status, body := NiceErrorHandler(err, r)
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	w.Write(body)
+// jsonPointer собирает RFC 6901 указатель из сегментов пути
+func jsonPointer(path []string) string {
+	if len(path) == 0 {
+		return ""
+	}
+	escaper := strings.NewReplacer("~", "~0", "/", "~1")
+	var b strings.Builder
+	for _, seg := range path {
+		b.WriteByte('/')
+		b.WriteString(escaper.Replace(seg))
+	}
+	return b.String()
+}
+
+func firstLine(msg string) string {
+	if idx := strings.IndexRune(msg, '\n'); idx != -1 {
+		return msg[:idx]
+	}
+	return msg
 }
@@ -0,0 +1,141 @@
+// Package jwk — публичные ключи в формате JWK (RFC 7517) и их отпечатки (RFC 7638),
+// которые служат kid
+package jwk
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Key — публичный JWK; для Ed25519 kty=OKP, crv=Ed25519, x — сам ключ;
+// для RSA kty=RSA с n и e; для ECDSA kty=EC с crv, x и y
+type Key struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+}
+
+// Set — JWKS
+type Set struct {
+	Keys []Key `json:"keys"`
+}
+
+// Ed25519Thumbprint — отпечаток RFC 7638: SHA-256 от канонического JWK
+// с обязательными членами в лексикографическом порядке, base64url без паддинга
+func Ed25519Thumbprint(pub ed25519.PublicKey) string {
+	return thumbprint(fmt.Sprintf(`{"crv":"Ed25519","kty":"OKP","x":"%s"}`, base64.RawURLEncoding.EncodeToString(pub)))
+}
+
+// FromEd25519 собирает JWK подписи EdDSA; kid — отпечаток ключа
+func FromEd25519(pub ed25519.PublicKey) Key {
+	return Key{
+		Kty: "OKP",
+		Crv: "Ed25519",
+		X:   base64.RawURLEncoding.EncodeToString(pub),
+		Kid: Ed25519Thumbprint(pub),
+		Use: "sig",
+		Alg: "EdDSA",
+	}
+}
+
+// Ed25519 извлекает публичный ключ из JWK
+func (k Key) Ed25519() (ed25519.PublicKey, error) {
+	if k.Kty != "OKP" || k.Crv != "Ed25519" {
+		return nil, fmt.Errorf("unsupported key type %s/%s", k.Kty, k.Crv)
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(k.X)
+	if err != nil {
+		return nil, fmt.Errorf("decode x: %w", err)
+	}
+	if len(raw) != ed25519.PublicKeySize {
+		return nil, errors.New("invalid Ed25519 key length")
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+// RSAThumbprint — отпечаток RFC 7638 ключа RSA
+func RSAThumbprint(pub *rsa.PublicKey) string {
+	n, e := rsaMembers(pub)
+	return thumbprint(fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`, e, n))
+}
+
+// FromRSA собирает JWK подписи RS256; kid — отпечаток ключа
+func FromRSA(pub *rsa.PublicKey) Key {
+	n, e := rsaMembers(pub)
+	return Key{Kty: "RSA", N: n, E: e, Kid: RSAThumbprint(pub), Use: "sig", Alg: "RS256"}
+}
+
+// ECThumbprint — отпечаток RFC 7638 ключа ECDSA
+func ECThumbprint(pub *ecdsa.PublicKey) (string, error) {
+	crv, _, x, y, err := ecMembers(pub)
+	if err != nil {
+		return "", err
+	}
+	return thumbprint(fmt.Sprintf(`{"crv":"%s","kty":"EC","x":"%s","y":"%s"}`, crv, x, y)), nil
+}
+
+// FromECDSA собирает JWK подписи ES256/ES384/ES512; kid — отпечаток ключа
+func FromECDSA(pub *ecdsa.PublicKey) (Key, error) {
+	crv, alg, x, y, err := ecMembers(pub)
+	if err != nil {
+		return Key{}, err
+	}
+	kid, _ := ECThumbprint(pub)
+	return Key{Kty: "EC", Crv: crv, X: x, Y: y, Kid: kid, Use: "sig", Alg: alg}, nil
+}
+
+// FromPublicKey — JWK для ключа Ed25519, RSA или ECDSA
+func FromPublicKey(pub crypto.PublicKey) (Key, error) {
+	switch pub := pub.(type) {
+	case ed25519.PublicKey:
+		return FromEd25519(pub), nil
+	case *rsa.PublicKey:
+		return FromRSA(pub), nil
+	case *ecdsa.PublicKey:
+		return FromECDSA(pub)
+	default:
+		return Key{}, fmt.Errorf("unsupported public key type %T", pub)
+	}
+}
+
+func rsaMembers(pub *rsa.PublicKey) (n, e string) {
+	return base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+}
+
+// ecMembers: координаты дополняются нулями до размера поля (RFC 7518, 6.2.1.2)
+func ecMembers(pub *ecdsa.PublicKey) (crv, alg, x, y string, err error) {
+	switch pub.Curve {
+	case elliptic.P256():
+		crv, alg = "P-256", "ES256"
+	case elliptic.P384():
+		crv, alg = "P-384", "ES384"
+	case elliptic.P521():
+		crv, alg = "P-521", "ES512"
+	default:
+		return "", "", "", "", errors.New("unsupported elliptic curve")
+	}
+	size := (pub.Curve.Params().BitSize + 7) / 8
+	x = base64.RawURLEncoding.EncodeToString(pub.X.FillBytes(make([]byte, size)))
+	y = base64.RawURLEncoding.EncodeToString(pub.Y.FillBytes(make([]byte, size)))
+	return crv, alg, x, y, nil
+}
+
+func thumbprint(canon string) string {
+	sum := sha256.Sum256([]byte(canon))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
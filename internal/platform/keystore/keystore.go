@@ -0,0 +1,388 @@
+// Package keystore — зашифрованное хранилище ключей подписи. Файл — JSON, в котором
+// метаданные ключей открыты, а приватные части зашифрованы XChaCha20-Poly1305 ключом,
+// выведенным из пароля через scrypt. Утечка файла без пароля не раскрывает ключи.
+//
+// Приватная часть хранится как 64 байта ключа Ed25519 или PKCS #8 DER ключа RSA.
+// Все открытые поля записи — id, алгоритм, createdAt, notBefore, notAfter и publicKey —
+// входят в associated data: без пароля запись нельзя переименовать, выдать за ключ
+// другого типа или подменить публичный ключ, а снятие notAfter не вернёт доверие
+// выведенному из оборота ключу
+package keystore
+
+import (
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Алгоритмы ключей — те же имена, что в протоколе remotesign
+const (
+	AlgEd25519 = "Ed25519"
+	AlgRS256   = "RS256"
+)
+
+// PassphraseEnv — переменная окружения с паролем хранилища; важнее файла с паролем
+const PassphraseEnv = "PLUTO_KEYSTORE_PASSPHRASE"
+
+const formatVersion = 1
+
+// Параметры scrypt для новых файлов (рекомендация для интерактивного входа, 2017)
+const (
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = chacha20poly1305.KeySize
+	saltSize     = 32
+)
+
+// Пределы параметров scrypt из файла: файл не доверенный, и без них подменённые n, r, p
+// заставят при старте выделить гигабайты памяти или считать ключ часами.
+// Память scrypt — 128·r·n байт
+const (
+	maxScryptN   = 1 << 20
+	maxScryptRP  = 1 << 30
+	maxScryptP   = 16
+	maxScryptMem = 1 << 30
+)
+
+var (
+	// ErrWrongPassphrase — пароль не подходит или файл повреждён
+	ErrWrongPassphrase = errors.New("keystore: wrong passphrase or corrupted key")
+	// ErrKeyNotFound — в хранилище нет ключа с таким id
+	ErrKeyNotFound = errors.New("keystore: key not found")
+)
+
+// Key — расшифрованный ключ хранилища
+type Key struct {
+	ID        string
+	Algorithm string
+	CreatedAt time.Time
+	// NotBefore/NotAfter — окно доверия ключу; нулевое время — без ограничения
+	NotBefore time.Time
+	NotAfter  time.Time
+	// Private — 64 байта Ed25519 или PKCS #8 DER RSA
+	Private []byte
+}
+
+// Ed25519 — приватный ключ Ed25519
+func (k Key) Ed25519() (ed25519.PrivateKey, error) {
+	if k.Algorithm != AlgEd25519 || len(k.Private) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("keystore: key %s is not an Ed25519 key", k.ID)
+	}
+	return ed25519.PrivateKey(k.Private), nil
+}
+
+// RSA — приватный ключ RSA
+func (k Key) RSA() (*rsa.PrivateKey, error) {
+	if k.Algorithm != AlgRS256 {
+		return nil, fmt.Errorf("keystore: key %s is not an RSA key", k.ID)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(k.Private)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: key %s: %w", k.ID, err)
+	}
+	priv, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("keystore: key %s is not an RSA key", k.ID)
+	}
+	return priv, nil
+}
+
+// PublicKey — публичная часть: 32 байта Ed25519 или PKIX DER RSA
+func (k Key) PublicKey() ([]byte, error) {
+	switch k.Algorithm {
+	case AlgEd25519:
+		priv, err := k.Ed25519()
+		if err != nil {
+			return nil, err
+		}
+		return priv.Public().(ed25519.PublicKey), nil
+	case AlgRS256:
+		priv, err := k.RSA()
+		if err != nil {
+			return nil, err
+		}
+		return x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	default:
+		return nil, fmt.Errorf("keystore: key %s: unsupported algorithm %q", k.ID, k.Algorithm)
+	}
+}
+
+// Keystore — содержимое расшифрованного файла
+type Keystore struct {
+	Keys []Key
+}
+
+// Get ищет ключ по id
+func (ks *Keystore) Get(id string) (Key, error) {
+	for _, k := range ks.Keys {
+		if k.ID == id {
+			return k, nil
+		}
+	}
+	return Key{}, fmt.Errorf("%w: %s", ErrKeyNotFound, id)
+}
+
+type file struct {
+	Version int         `json:"version"`
+	KDF     kdfParams   `json:"kdf"`
+	Keys    []fileEntry `json:"keys"`
+}
+
+type kdfParams struct {
+	Name string `json:"name"`
+	Salt []byte `json:"salt"`
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+}
+
+type fileEntry struct {
+	ID         string     `json:"id"`
+	Algorithm  string     `json:"algorithm"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	NotBefore  *time.Time `json:"notBefore,omitempty"`
+	NotAfter   *time.Time `json:"notAfter,omitempty"`
+	PublicKey  []byte     `json:"publicKey"`
+	Nonce      []byte     `json:"nonce"`
+	Ciphertext []byte     `json:"ciphertext"`
+}
+
+// Load открывает хранилище path паролем из PLUTO_KEYSTORE_PASSPHRASE или passphraseFile
+func Load(path, passphraseFile string) (*Keystore, error) {
+	passphrase, err := LoadPassphrase(passphraseFile)
+	if err != nil {
+		return nil, err
+	}
+	return Open(path, passphrase)
+}
+
+// Open читает и расшифровывает файл хранилища
+func Open(path string, passphrase []byte) (*Keystore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Decrypt(data, passphrase)
+}
+
+// Save шифрует ключи и атомарно записывает файл с правами 0600
+func Save(path string, passphrase []byte, ks *Keystore) error {
+	data, err := Encrypt(ks, passphrase)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".keystore-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Decrypt разбирает содержимое файла хранилища
+func Decrypt(data, passphrase []byte) (*Keystore, error) {
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("keystore: %w", err)
+	}
+	if f.Version != formatVersion {
+		return nil, fmt.Errorf("keystore: unsupported version %d", f.Version)
+	}
+	if f.KDF.Name != "scrypt" {
+		return nil, fmt.Errorf("keystore: unsupported kdf %q", f.KDF.Name)
+	}
+	if err := checkScryptParams(f.KDF); err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(passphrase, f.KDF)
+	if err != nil {
+		return nil, err
+	}
+
+	ks := &Keystore{Keys: make([]Key, 0, len(f.Keys))}
+	for _, e := range f.Keys {
+		if len(e.Nonce) != aead.NonceSize() {
+			return nil, fmt.Errorf("keystore: key %s: invalid nonce", e.ID)
+		}
+		ad, err := associatedData(e)
+		if err != nil {
+			return nil, err
+		}
+		private, err := aead.Open(nil, e.Nonce, e.Ciphertext, ad)
+		if err != nil {
+			return nil, ErrWrongPassphrase
+		}
+		ks.Keys = append(ks.Keys, Key{
+			ID:        e.ID,
+			Algorithm: e.Algorithm,
+			CreatedAt: e.CreatedAt,
+			NotBefore: derefTime(e.NotBefore),
+			NotAfter:  derefTime(e.NotAfter),
+			Private:   private,
+		})
+	}
+	return ks, nil
+}
+
+// Encrypt шифрует ключи на пароле со свежей солью; каждый ключ — со своим nonce
+func Encrypt(ks *Keystore, passphrase []byte) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("keystore: empty passphrase")
+	}
+	kdf := kdfParams{Name: "scrypt", Salt: make([]byte, saltSize), N: scryptN, R: scryptR, P: scryptP}
+	if _, err := rand.Read(kdf.Salt); err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(passphrase, kdf)
+	if err != nil {
+		return nil, err
+	}
+
+	f := file{Version: formatVersion, KDF: kdf, Keys: make([]fileEntry, 0, len(ks.Keys))}
+	seen := make(map[string]bool, len(ks.Keys))
+	for _, k := range ks.Keys {
+		if k.ID == "" {
+			return nil, errors.New("keystore: key with empty id")
+		}
+		if seen[k.ID] {
+			return nil, fmt.Errorf("keystore: duplicate key id %s", k.ID)
+		}
+		seen[k.ID] = true
+
+		pub, err := k.PublicKey()
+		if err != nil {
+			return nil, err
+		}
+		nonce := make([]byte, aead.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, err
+		}
+		e := fileEntry{
+			ID:        k.ID,
+			Algorithm: k.Algorithm,
+			CreatedAt: k.CreatedAt.UTC(),
+			NotBefore: timePtr(k.NotBefore),
+			NotAfter:  timePtr(k.NotAfter),
+			PublicKey: pub,
+			Nonce:     nonce,
+		}
+		ad, err := associatedData(e)
+		if err != nil {
+			return nil, err
+		}
+		e.Ciphertext = aead.Seal(nil, nonce, k.Private, ad)
+		f.Keys = append(f.Keys, e)
+	}
+	return json.MarshalIndent(f, "", "  ")
+}
+
+// LoadPassphrase берёт пароль из PLUTO_KEYSTORE_PASSPHRASE, иначе из файла path
+// (завершающий перевод строки отбрасывается)
+func LoadPassphrase(path string) ([]byte, error) {
+	if p := os.Getenv(PassphraseEnv); p != "" {
+		return []byte(p), nil
+	}
+	if path == "" {
+		return nil, fmt.Errorf("keystore: passphrase not set: use %s or a passphrase file", PassphraseEnv)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	passphrase := strings.TrimRight(string(data), "\r\n")
+	if passphrase == "" {
+		return nil, fmt.Errorf("keystore: passphrase file %s is empty", path)
+	}
+	return []byte(passphrase), nil
+}
+
+// checkScryptParams отсекает параметры вне пределов до вывода ключа
+func checkScryptParams(kdf kdfParams) error {
+	switch {
+	case kdf.N <= 1 || kdf.N > maxScryptN || kdf.N&(kdf.N-1) != 0:
+		return fmt.Errorf("keystore: scrypt n must be a power of two up to %d, got %d", maxScryptN, kdf.N)
+	case kdf.R < 1 || kdf.P < 1 || kdf.P > maxScryptP:
+		return fmt.Errorf("keystore: scrypt r must be positive and p in [1, %d], got r=%d p=%d", maxScryptP, kdf.R, kdf.P)
+	case int64(kdf.R)*int64(kdf.P) >= maxScryptRP:
+		return fmt.Errorf("keystore: scrypt r*p must be below %d", maxScryptRP)
+	case 128*int64(kdf.R)*int64(kdf.N) > maxScryptMem:
+		return fmt.Errorf("keystore: scrypt n=%d r=%d needs more than %d bytes", kdf.N, kdf.R, maxScryptMem)
+	}
+	return nil
+}
+
+func newAEAD(passphrase []byte, kdf kdfParams) (cipher.AEAD, error) {
+	key, err := scrypt.Key(passphrase, kdf.Salt, kdf.N, kdf.R, kdf.P, scryptKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: %w", err)
+	}
+	return chacha20poly1305.NewX(key)
+}
+
+// associatedData — открытые поля записи, которые аутентифицирует AEAD. Время — в наносекундах
+// Unix (0 — без ограничения), чтобы запись в другом формате даты не меняла данные
+func associatedData(e fileEntry) ([]byte, error) {
+	return json.Marshal(struct {
+		Format    string `json:"format"`
+		ID        string `json:"id"`
+		Algorithm string `json:"algorithm"`
+		CreatedAt int64  `json:"createdAt"`
+		NotBefore int64  `json:"notBefore"`
+		NotAfter  int64  `json:"notAfter"`
+		PublicKey []byte `json:"publicKey"`
+	}{
+		Format:    fmt.Sprintf("pluto-keystore:v%d", formatVersion),
+		ID:        e.ID,
+		Algorithm: e.Algorithm,
+		CreatedAt: e.CreatedAt.UnixNano(),
+		NotBefore: unixNano(e.NotBefore),
+		NotAfter:  unixNano(e.NotAfter),
+		PublicKey: e.PublicKey,
+	})
+}
+
+func unixNano(t *time.Time) int64 {
+	if t == nil {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
+
+func derefTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
@@ -0,0 +1,309 @@
+package keystore
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var passphrase = []byte("correct horse battery staple")
+
+func testKeystore(t *testing.T) *Keystore {
+	t.Helper()
+	seed := bytes.Repeat([]byte{7}, ed25519.SeedSize)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC)
+	return &Keystore{Keys: []Key{
+		{
+			ID:        "manifest",
+			Algorithm: AlgEd25519,
+			CreatedAt: created,
+			NotBefore: created,
+			NotAfter:  created.AddDate(1, 0, 0),
+			Private:   ed25519.NewKeyFromSeed(seed),
+		},
+		{
+			ID:        "auth",
+			Algorithm: AlgRS256,
+			CreatedAt: created,
+			Private:   der,
+		},
+	}}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	want := testKeystore(t)
+	data, err := Encrypt(want, passphrase)
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	if bytes.Contains(data, want.Keys[0].Private[:ed25519.SeedSize]) {
+		t.Fatal("Ed25519 seed is stored in plaintext")
+	}
+
+	got, err := Decrypt(data, passphrase)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if len(got.Keys) != len(want.Keys) {
+		t.Fatalf("Decrypt = %d keys, want %d", len(got.Keys), len(want.Keys))
+	}
+	for i, w := range want.Keys {
+		g := got.Keys[i]
+		if g.ID != w.ID || g.Algorithm != w.Algorithm || !bytes.Equal(g.Private, w.Private) ||
+			!g.CreatedAt.Equal(w.CreatedAt) || !g.NotBefore.Equal(w.NotBefore) || !g.NotAfter.Equal(w.NotAfter) {
+			t.Errorf("key %d = %+v, want %+v", i, g, w)
+		}
+	}
+
+	ed, err := got.Keys[0].Ed25519()
+	if err != nil {
+		t.Fatalf("Ed25519: %v", err)
+	}
+	if !ed.Equal(ed25519.PrivateKey(want.Keys[0].Private)) {
+		t.Error("Ed25519 key changed")
+	}
+	if _, err := got.Keys[1].RSA(); err != nil {
+		t.Errorf("RSA: %v", err)
+	}
+	if _, err := got.Keys[0].RSA(); err == nil {
+		t.Error("Ed25519 key returned as RSA")
+	}
+	if _, err := got.Keys[1].Ed25519(); err == nil {
+		t.Error("RSA key returned as Ed25519")
+	}
+
+	if _, err := got.Get("auth"); err != nil {
+		t.Errorf("Get(auth): %v", err)
+	}
+	if _, err := got.Get("missing"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Get(missing) = %v, want ErrKeyNotFound", err)
+	}
+}
+
+func TestDecryptWrongPassphrase(t *testing.T) {
+	data, err := Encrypt(testKeystore(t), passphrase)
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	for _, p := range [][]byte{[]byte("wrong"), []byte("correct horse battery staple "), nil} {
+		if _, err := Decrypt(data, p); !errors.Is(err, ErrWrongPassphrase) {
+			t.Errorf("Decrypt(%q) = %v, want ErrWrongPassphrase", p, err)
+		}
+	}
+}
+
+// TestDecryptTampered правит открытые поля файла без пароля: каждое изменение
+// обязано сломать расшифровку, а не незаметно поменять свойства ключа
+func TestDecryptTampered(t *testing.T) {
+	data, err := Encrypt(testKeystore(t), passphrase)
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	later := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	otherPub := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{8}, ed25519.SeedSize)).Public().(ed25519.PublicKey)
+
+	tests := []struct {
+		name   string
+		tamper func(e *fileEntry)
+	}{
+		{name: "id", tamper: func(e *fileEntry) { e.ID = "auth-old" }},
+		{name: "algorithm", tamper: func(e *fileEntry) { e.Algorithm = AlgRS256 }},
+		{name: "createdAt", tamper: func(e *fileEntry) { e.CreatedAt = e.CreatedAt.Add(time.Nanosecond) }},
+		{name: "notBefore moved", tamper: func(e *fileEntry) { e.NotBefore = &later }},
+		{name: "notBefore removed", tamper: func(e *fileEntry) { e.NotBefore = nil }},
+		{name: "notAfter extended", tamper: func(e *fileEntry) { e.NotAfter = &later }},
+		{name: "notAfter removed", tamper: func(e *fileEntry) { e.NotAfter = nil }},
+		{name: "publicKey", tamper: func(e *fileEntry) { e.PublicKey = otherPub }},
+		{name: "ciphertext", tamper: func(e *fileEntry) { e.Ciphertext[0] ^= 1 }},
+		{name: "nonce", tamper: func(e *fileEntry) { e.Nonce[0] ^= 1 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var f file
+			if err := json.Unmarshal(data, &f); err != nil {
+				t.Fatal(err)
+			}
+			tt.tamper(&f.Keys[0])
+			tampered, err := json.Marshal(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Decrypt(tampered, passphrase); !errors.Is(err, ErrWrongPassphrase) {
+				t.Errorf("Decrypt = %v, want ErrWrongPassphrase", err)
+			}
+		})
+	}
+
+	// тот же момент в другой зоне — те же данные: формат даты в файле не важен
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		t.Fatal(err)
+	}
+	f.Keys[0].CreatedAt = f.Keys[0].CreatedAt.In(time.FixedZone("MSK", 3*60*60))
+	reformatted, err := json.Marshal(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Decrypt(reformatted, passphrase); err != nil {
+		t.Errorf("Decrypt with createdAt in another zone: %v", err)
+	}
+}
+
+func TestDecryptRejects(t *testing.T) {
+	data, err := Encrypt(testKeystore(t), passphrase)
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	edit := func(fn func(f *file)) []byte {
+		var f file
+		if err := json.Unmarshal(data, &f); err != nil {
+			t.Fatal(err)
+		}
+		fn(&f)
+		out, err := json.Marshal(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "not json", data: []byte("keystore")},
+		{name: "version 0", data: edit(func(f *file) { f.Version = 0 })},
+		{name: "future version", data: edit(func(f *file) { f.Version = formatVersion + 1 })},
+		{name: "other kdf", data: edit(func(f *file) { f.KDF.Name = "pbkdf2" })},
+		// параметры вне пределов отсекаются до scrypt: иначе тест висел бы или падал по памяти
+		{name: "n not power of two", data: edit(func(f *file) { f.KDF.N = 3 << 14 })},
+		{name: "n too large", data: edit(func(f *file) { f.KDF.N = 1 << 30 })},
+		{name: "zero r", data: edit(func(f *file) { f.KDF.R = 0 })},
+		{name: "r*p too large", data: edit(func(f *file) { f.KDF.R, f.KDF.P = 1<<27, 8 })},
+		{name: "p too large", data: edit(func(f *file) { f.KDF.P = 1 << 20 })},
+		{name: "too much memory", data: edit(func(f *file) { f.KDF.N, f.KDF.R = 1<<20, 64 })},
+		{name: "short nonce", data: edit(func(f *file) { f.Keys[0].Nonce = f.Keys[0].Nonce[:12] })},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if ks, err := Decrypt(tt.data, passphrase); err == nil {
+				t.Errorf("Decrypt = %+v, want error", ks)
+			}
+		})
+	}
+}
+
+func TestEncryptRejects(t *testing.T) {
+	valid := testKeystore(t).Keys[0]
+	noID := valid
+	noID.ID = ""
+	wrongAlg := valid
+	wrongAlg.Algorithm = AlgRS256
+
+	tests := []struct {
+		name       string
+		keys       []Key
+		passphrase []byte
+	}{
+		{name: "empty passphrase", keys: []Key{valid}},
+		{name: "empty id", keys: []Key{noID}, passphrase: passphrase},
+		{name: "duplicate id", keys: []Key{valid, valid}, passphrase: passphrase},
+		{name: "algorithm does not match key", keys: []Key{wrongAlg}, passphrase: passphrase},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Encrypt(&Keystore{Keys: tt.keys}, tt.passphrase); err == nil {
+				t.Error("Encrypt succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "keystore.json")
+	if err := Save(path, passphrase, testKeystore(t)); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("keystore mode = %o, want 600", perm)
+	}
+
+	passFile := filepath.Join(dir, "passphrase")
+	if err := os.WriteFile(passFile, append(passphrase, '\r', '\n'), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(PassphraseEnv, "")
+	ks, err := Load(path, passFile)
+	if err != nil {
+		t.Fatalf("Load with passphrase file: %v", err)
+	}
+	if len(ks.Keys) != 2 {
+		t.Errorf("Load = %d keys, want 2", len(ks.Keys))
+	}
+
+	// переменная окружения важнее файла
+	t.Setenv(PassphraseEnv, "wrong")
+	if _, err := Load(path, passFile); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("Load with wrong env passphrase = %v, want ErrWrongPassphrase", err)
+	}
+}
+
+func TestLoadPassphrase(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	tests := []struct {
+		name    string
+		env     string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{name: "env", env: "from-env", path: write("a", "from-file"), want: "from-env"},
+		{name: "file", path: write("b", "from-file"), want: "from-file"},
+		{name: "trailing newline", path: write("c", "secret\n"), want: "secret"},
+		{name: "inner spaces kept", path: write("d", " two words \r\n"), want: " two words "},
+		{name: "empty file", path: write("e", "\n"), wantErr: true},
+		{name: "missing file", path: filepath.Join(dir, "missing"), wantErr: true},
+		{name: "nothing set", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(PassphraseEnv, tt.env)
+			got, err := LoadPassphrase(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadPassphrase error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("LoadPassphrase = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package problem
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// ContentType — media type ответа об ошибке по RFC 7807
+const ContentType = "application/problem+json"
+
+// typePrefix — type ошибки строится как URN, клиенты сравнивают его целиком
+const typePrefix = "urn:pluto:problem:"
+
+// Коды, из которых собирается type
+const (
+	CodeInvalidRequest   = "invalid-request"
+	CodeValidation       = "validation"
+	CodeUnauthorized     = "unauthorized"
+	CodeForbidden        = "forbidden"
+	CodeNotFound         = "not-found"
+	CodeMethodNotAllowed = "method-not-allowed"
+	CodeConflict         = "conflict"
+	CodeInternal         = "internal"
+)
+
+// Problem — единое тело ошибки обоих сервисов
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	RequestID string       `json:"requestId,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError — ошибка конкретного поля: Pointer — JSON Pointer (RFC 6901) в теле запроса,
+// Parameter — имя query/path/header параметра
+type FieldError struct {
+	Pointer   string `json:"pointer,omitempty"`
+	Parameter string `json:"parameter,omitempty"`
+	Message   string `json:"message"`
+}
+
+// New собирает Problem; code превращается в type ("not-found" → "urn:pluto:problem:not-found")
+func New(status int, code, detail string) *Problem {
+	typ := "about:blank"
+	if code != "" {
+		typ = typePrefix + code
+	}
+	return &Problem{
+		Type:   typ,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// WithErrors добавляет ошибки по полям
+func (p *Problem) WithErrors(errs ...FieldError) *Problem {
+	p.Errors = append(p.Errors, errs...)
+	return p
+}
+
+// Write дополняет instance и request id из запроса и отдаёт application/problem+json
+func Write(w http.ResponseWriter, r *http.Request, p *Problem) {
+	if p.Instance == "" {
+		p.Instance = r.URL.Path
+	}
+	if p.RequestID == "" {
+		p.RequestID = middleware.GetReqID(r.Context())
+	}
+
+	buf := &bytes.Buffer{}
+	if err := json.NewEncoder(buf).Encode(p); err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+	buf.WriteTo(w)
+}
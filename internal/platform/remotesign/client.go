@@ -0,0 +1,172 @@
+package remotesign
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Значения по умолчанию для нулевых полей Config
+const (
+	defaultTimeout = 2 * time.Second
+	defaultBackoff = 100 * time.Millisecond
+)
+
+// Config — подключение к сервису подписи
+type Config struct {
+	// Endpoint — "unix:///path/to.sock" или "http://host:port"
+	Endpoint string
+	Token    string
+	// Timeout — на одну попытку
+	Timeout time.Duration
+	// Retries — повторов после неудачной попытки: сетевой ошибки, 5xx или 429
+	Retries int
+	// Backoff — пауза перед первым повтором, дальше удваивается
+	Backoff time.Duration
+}
+
+// Client ходит в сервис подписи. Подпись детерминирована, поэтому запрос можно
+// безопасно повторить
+type Client struct {
+	http    *http.Client
+	base    string
+	token   string
+	timeout time.Duration
+	retries int
+	backoff time.Duration
+}
+
+func NewClient(cfg Config) (*Client, error) {
+	network, addr, err := splitEndpoint(cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	base := "http://" + addr
+	if network == "unix" {
+		// хост в URL ни на что не влияет: соединение всегда идёт в сокет
+		base = "http://signer"
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", addr)
+		}
+	}
+
+	c := &Client{
+		http:    &http.Client{Transport: transport},
+		base:    base,
+		token:   cfg.Token,
+		timeout: cfg.Timeout,
+		retries: cfg.Retries,
+		backoff: cfg.Backoff,
+	}
+	if c.timeout <= 0 {
+		c.timeout = defaultTimeout
+	}
+	if c.backoff <= 0 {
+		c.backoff = defaultBackoff
+	}
+	if c.retries < 0 {
+		c.retries = 0
+	}
+	return c, nil
+}
+
+// Key — алгоритм и публичная часть ключа name
+func (c *Client) Key(ctx context.Context, name string) (KeyInfo, error) {
+	var info KeyInfo
+	if err := c.do(ctx, http.MethodGet, keyPath(name), nil, &info); err != nil {
+		return KeyInfo{}, err
+	}
+	return info, nil
+}
+
+// Sign подписывает data ключом name
+func (c *Client) Sign(ctx context.Context, name string, data []byte) ([]byte, error) {
+	body, err := json.Marshal(signRequest{Data: data})
+	if err != nil {
+		return nil, err
+	}
+	var out signResponse
+	if err := c.do(ctx, http.MethodPost, keyPath(name)+"/sign", body, &out); err != nil {
+		return nil, err
+	}
+	if len(out.Signature) == 0 {
+		return nil, errors.New("remote signer: empty signature")
+	}
+	return out.Signature, nil
+}
+
+func keyPath(name string) string {
+	return "/v1/keys/" + url.PathEscape(name)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body []byte, out any) error {
+	backoff := c.backoff
+	var err error
+	for attempt := 0; ; attempt++ {
+		var retry bool
+		retry, err = c.attempt(ctx, method, path, body, out)
+		if !retry || attempt >= c.retries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// attempt — один запрос; retry сообщает, имеет ли смысл повторить
+func (c *Client) attempt(ctx context.Context, method, path string, body []byte, out any) (retry bool, err error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, c.base+path, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("remote signer: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return true, fmt.Errorf("remote signer: decode response: %w", err)
+		}
+		return false, nil
+	case resp.StatusCode == http.StatusNotFound:
+		return false, ErrUnknownKey
+	case resp.StatusCode == http.StatusUnauthorized:
+		return false, ErrUnauthorized
+	}
+
+	var e errorResponse
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if json.Unmarshal(raw, &e) != nil || e.Error == "" {
+		e.Error = string(raw)
+	}
+	retry = resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retry, &StatusError{Status: resp.StatusCode, Message: e.Error}
+}
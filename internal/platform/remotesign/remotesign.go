@@ -0,0 +1,105 @@
+// Package remotesign — протокол внешнего сервиса подписи: API-процессы отправляют
+// ему байты и получают подпись, не держа приватный ключ в памяти.
+//
+//	GET  /v1/keys/{name}       → {"name", "algorithm", "publicKey"}
+//	POST /v1/keys/{name}/sign  {"data"} → {"signature"}
+//
+// Все двоичные поля — стандартный base64. publicKey — 32 байта для Ed25519 и PKIX DER
+// для RS256. Подпись RS256 — PKCS #1 v1.5 над SHA-256 от data, как в JWS.
+// Сервис слушает TCP ("http://host:port") или Unix-сокет ("unix:///path/to.sock");
+// если задан токен, запросы несут заголовок Authorization: Bearer <token>
+package remotesign
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+)
+
+// Алгоритмы ключей
+const (
+	AlgEd25519 = "Ed25519"
+	AlgRS256   = "RS256"
+)
+
+var (
+	// ErrUnknownKey — у сервиса подписи нет ключа с таким именем
+	ErrUnknownKey = errors.New("remote signer: unknown key")
+	// ErrUnauthorized — сервис подписи отверг токен
+	ErrUnauthorized = errors.New("remote signer: unauthorized")
+)
+
+// KeyInfo — ответ GET /v1/keys/{name}
+type KeyInfo struct {
+	Name      string `json:"name"`
+	Algorithm string `json:"algorithm"`
+	PublicKey []byte `json:"publicKey"`
+}
+
+type signRequest struct {
+	Data []byte `json:"data"`
+}
+
+type signResponse struct {
+	Signature []byte `json:"signature"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// StatusError — сервис подписи ответил неуспешным статусом
+type StatusError struct {
+	Status  int
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("remote signer: status %d: %s", e.Status, e.Message)
+}
+
+// Listen открывает слушающий сокет по адресу в форме эндпоинта; устаревший файл
+// Unix-сокета от прошлого запуска удаляется, а сам сокет доступен только владельцу и группе
+func Listen(endpoint string) (net.Listener, error) {
+	network, addr, err := splitEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if network == "unix" {
+		if err := os.Remove(addr); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+	ln, err := net.Listen(network, addr)
+	if err != nil {
+		return nil, err
+	}
+	if network == "unix" {
+		if err := os.Chmod(addr, 0o660); err != nil {
+			ln.Close()
+			return nil, err
+		}
+	}
+	return ln, nil
+}
+
+// splitEndpoint: "unix:///run/signer.sock" → ("unix", "/run/signer.sock"),
+// "http://host:8090" → ("tcp", "host:8090")
+func splitEndpoint(endpoint string) (network, addr string, err error) {
+	switch {
+	case strings.HasPrefix(endpoint, "unix://"):
+		addr = strings.TrimPrefix(endpoint, "unix://")
+		network = "unix"
+	case strings.HasPrefix(endpoint, "http://"):
+		addr = strings.TrimSuffix(strings.TrimPrefix(endpoint, "http://"), "/")
+		network = "tcp"
+	default:
+		return "", "", fmt.Errorf("remote signer endpoint %q: expected unix:// or http://", endpoint)
+	}
+	if addr == "" {
+		return "", "", fmt.Errorf("remote signer endpoint %q: empty address", endpoint)
+	}
+	return network, addr, nil
+}
@@ -0,0 +1,121 @@
+package remotesign
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// maxSignRequest — предел тела запроса подписи: канонический манифест со скриптом
+// укладывается с большим запасом
+const maxSignRequest = 8 << 20
+
+// Key — ключ сервиса подписи
+type Key struct {
+	algorithm string
+	public    []byte
+	sign      func(data []byte) ([]byte, error)
+}
+
+func NewEd25519Key(priv ed25519.PrivateKey) (Key, error) {
+	if len(priv) != ed25519.PrivateKeySize {
+		return Key{}, fmt.Errorf("ed25519 private key must be %d bytes", ed25519.PrivateKeySize)
+	}
+	return Key{
+		algorithm: AlgEd25519,
+		public:    priv.Public().(ed25519.PublicKey),
+		sign: func(data []byte) ([]byte, error) {
+			return ed25519.Sign(priv, data), nil
+		},
+	}, nil
+}
+
+func NewRSAKey(priv *rsa.PrivateKey) (Key, error) {
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	if err != nil {
+		return Key{}, err
+	}
+	return Key{
+		algorithm: AlgRS256,
+		public:    der,
+		sign: func(data []byte) ([]byte, error) {
+			digest := sha256.Sum256(data)
+			return rsa.SignPKCS1v15(rand.Reader, priv, crypto.SHA256, digest[:])
+		},
+	}, nil
+}
+
+// NewHandler — HTTP-интерфейс сервиса подписи; пустой token отключает проверку
+func NewHandler(keys map[string]Key, token string) http.Handler {
+	r := chi.NewRouter()
+	if token != "" {
+		r.Use(requireToken(token))
+	}
+
+	r.Get("/v1/keys/{name}", func(w http.ResponseWriter, r *http.Request) {
+		name := chi.URLParam(r, "name")
+		key, ok := keys[name]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, errorResponse{Error: ErrUnknownKey.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, KeyInfo{Name: name, Algorithm: key.algorithm, PublicKey: key.public})
+	})
+
+	r.Post("/v1/keys/{name}/sign", func(w http.ResponseWriter, r *http.Request) {
+		key, ok := keys[chi.URLParam(r, "name")]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, errorResponse{Error: ErrUnknownKey.Error()})
+			return
+		}
+
+		var req signRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSignRequest)).Decode(&req); err != nil {
+			status := http.StatusBadRequest
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				status = http.StatusRequestEntityTooLarge
+			}
+			writeJSON(w, status, errorResponse{Error: "invalid request body"})
+			return
+		}
+
+		sig, err := key.sign(req.Data)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "signing failed"})
+			return
+		}
+		writeJSON(w, http.StatusOK, signResponse{Signature: sig})
+	})
+
+	return r
+}
+
+func requireToken(token string) func(http.Handler) http.Handler {
+	want := []byte("Bearer " + token)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), want) != 1 {
+				writeJSON(w, http.StatusUnauthorized, errorResponse{Error: ErrUnauthorized.Error()})
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
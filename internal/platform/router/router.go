@@ -3,11 +3,14 @@ package router
 import (
 	"crypto/tls"
 	"fmt"
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/quic-go/quic-go/http3"
 	"github.com/rs/zerolog"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
 	"net/http"
+	"pluto-backend/internal/platform/problem"
+	"runtime/debug"
 )
 
 //NewChiRouter создаёт chi-маршрутизатор с базовыми middleware.
@@ -74,3 +77,32 @@ func RequestLogger(logger *zerolog.Logger) func(next http.Handler) http.Handler
 		})
 	}
 }
+
+// Recoverer заменяет chi middleware.Recoverer: паника логируется со стеком,
+// а клиент получает 500 в формате application/problem+json
+func Recoverer(logger *zerolog.Logger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				if rvr := recover(); rvr != nil {
+					if rvr == http.ErrAbortHandler {
+						// клиент уже отключился, отвечать некому
+						panic(rvr)
+					}
+
+					logger.Error().
+						Interface("panic", rvr).
+						Str("request_id", middleware.GetReqID(r.Context())).
+						Bytes("stack", debug.Stack()).
+						Msg("panic recovered")
+
+					if r.Header.Get("Connection") != "Upgrade" {
+						problem.Write(w, r, problem.New(http.StatusInternalServerError, problem.CodeInternal, "internal error"))
+					}
+				}
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
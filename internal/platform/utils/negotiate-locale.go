@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// DefaultLocale — локаль, которая обязана быть у каждого манифеста; последнее звено отката
+const DefaultLocale = "en"
+
+// ParseAcceptLanguage разбирает Accept-Language с учётом q (теги уже отсортированы по убыванию);
+// пустой или битый заголовок означает en
+func ParseAcceptLanguage(header string) []language.Tag {
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil || len(tags) == 0 {
+		return []language.Tag{language.English}
+	}
+	return tags
+}
+
+// NegotiateLocale выбирает из available локаль, лучше всего подходящую под prefs
+// (регион и письменность учитываются: zh-Hant не совпадёт с zh-Hans).
+// en ставится первой, поэтому без совпадений matcher возвращает её
+func NegotiateLocale(prefs []language.Tag, available []string) string {
+	if len(available) == 0 {
+		return DefaultLocale
+	}
+
+	ordered := make([]string, 0, len(available))
+	for _, l := range available {
+		if l == DefaultLocale {
+			ordered = append([]string{l}, ordered...)
+		} else {
+			ordered = append(ordered, l)
+		}
+	}
+
+	supported := make([]language.Tag, len(ordered))
+	for i, l := range ordered {
+		supported[i] = language.Make(l)
+	}
+	_, idx, _ := language.NewMatcher(supported).Match(prefs...)
+	return ordered[idx]
+}
+
+// FallbackChain — цепочка отката от самой точной локали к en: pt-BR → pt → en,
+// zh-Hant-TW → zh-Hant → zh → en
+func FallbackChain(locale string) []string {
+	var chain []string
+	for l := locale; l != ""; {
+		chain = append(chain, l)
+		i := strings.LastIndexByte(l, '-')
+		if i < 0 {
+			break
+		}
+		l = l[:i]
+	}
+	if len(chain) == 0 || chain[len(chain)-1] != DefaultLocale {
+		chain = append(chain, DefaultLocale)
+	}
+	return chain
+}
+
+// LocaleChain — порядок, в котором Localize предпочитает локали каталога available:
+// matcher раз за разом выбирает лучшую из оставшихся, за каждой идёт её цепочка отката,
+// en — последней. Для pt-BR и каталога [de, en, pt] это pt → en, а не pt-BR → pt → en.
+// Из неё SQL подбирает title для сортировки и строки для поиска: первая локаль цепочки,
+// которая есть у манифеста, — та же, что выберет для него Localize
+func LocaleChain(prefs []language.Tag, available []string) []string {
+	rest := make([]string, 0, len(available))
+	for _, l := range available {
+		if l != DefaultLocale {
+			rest = append(rest, l)
+		}
+	}
+	// en — у каждого манифеста, поэтому локали после неё уже никогда не выбираются
+	rest = append([]string{DefaultLocale}, rest...)
+
+	var chain []string
+	seen := map[string]bool{DefaultLocale: true}
+	for len(rest) > 0 {
+		supported := make([]language.Tag, len(rest))
+		for i, l := range rest {
+			supported[i] = language.Make(l)
+		}
+		_, idx, conf := language.NewMatcher(supported).Match(prefs...)
+		if conf == language.No || rest[idx] == DefaultLocale {
+			break
+		}
+		for _, l := range FallbackChain(rest[idx]) {
+			if !seen[l] {
+				seen[l] = true
+				chain = append(chain, l)
+			}
+		}
+		rest = append(rest[:idx], rest[idx+1:]...)
+	}
+	return append(chain, DefaultLocale)
+}
+
+// Localize выбирает локаль из имеющихся у манифеста и собирает строки по ключам:
+// каждый ключ берётся из первой локали цепочки отката, где он переведён,
+// так что частично переведённый манифест не теряет строки
+func Localize(all map[string]map[string]string, prefs []language.Tag) (string, map[string]string) {
+	available := make([]string, 0, len(all))
+	for l := range all {
+		available = append(available, l)
+	}
+	sort.Strings(available)
+
+	locale := NegotiateLocale(prefs, available)
+	chain := FallbackChain(locale)
+
+	out := make(map[string]string)
+	for i := len(chain) - 1; i >= 0; i-- {
+		for k, v := range all[chain[i]] {
+			out[k] = v
+		}
+	}
+	return locale, out
+}
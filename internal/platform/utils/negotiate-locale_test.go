@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestLocaleChainAgreesWithLocalize: для любого набора локалей манифеста первая локаль
+// цепочки, которая у него есть, — та, что выберет Localize
+func TestLocaleChainAgreesWithLocalize(t *testing.T) {
+	catalog := []string{"de", "en", "pt", "pt-BR", "pt-PT", "zh", "zh-Hans", "zh-Hant", "es-419", "es"}
+	headers := []string{"pt-BR", "pt", "pt-BR, de", "de, pt-BR", "en, de", "fr", "zh-TW", "zh-CN, en", "es-MX", "es-ES, pt;q=0.5"}
+
+	for _, h := range headers {
+		prefs := ParseAcceptLanguage(h)
+		chain := LocaleChain(prefs, catalog)
+		// все подмножества каталога с обязательной en
+		for mask := 0; mask < 1<<len(catalog); mask++ {
+			available := []string{DefaultLocale}
+			for i, l := range catalog {
+				if mask&(1<<i) != 0 && l != DefaultLocale {
+					available = append(available, l)
+				}
+			}
+			want := NegotiateLocale(prefs, available)
+			var got string
+			for _, l := range chain {
+				if slices.Contains(available, l) {
+					got = l
+					break
+				}
+			}
+			if got != want {
+				t.Errorf("%q, available %v: chain %v picks %s, Localize picks %s", h, available, chain, got, want)
+			}
+		}
+	}
+}
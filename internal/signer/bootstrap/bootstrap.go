@@ -0,0 +1,171 @@
+package bootstrap
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"pluto-backend/internal/platform/keystore"
+	"pluto-backend/internal/platform/logger"
+	"pluto-backend/internal/platform/remotesign"
+	routerpkg "pluto-backend/internal/platform/router"
+	"pluto-backend/internal/signer/config"
+)
+
+// RunSigner поднимает сервис подписи: единственный процесс, который держит приватные
+// ключи; manifest-service и auth-service ходят к нему по протоколу remotesign
+func RunSigner() error {
+	cfg := config.GetConfig()
+	log := logger.New(cfg.Logging.Level)
+
+	keys, err := loadKeys(cfg.Keys)
+	if err != nil {
+		return logFatalWrap(log, err, "invalid keys")
+	}
+	if cfg.Keystore.Path != "" {
+		if err := loadKeystore(cfg.Keystore, keys); err != nil {
+			return logFatalWrap(log, err, "failed to load keystore")
+		}
+	}
+	if len(keys) == 0 {
+		return logFatalWrap(log, errors.New("no keys configured"), "invalid keys")
+	}
+	if cfg.Server.Token == "" {
+		log.Warn().Msg("server.token is empty: any local process that can reach the listener can sign")
+	}
+
+	ln, err := remotesign.Listen(cfg.Server.Listen)
+	if err != nil {
+		return logFatalWrap(log, err, "failed to listen")
+	}
+
+	srv := &http.Server{
+		Handler:           routerpkg.RequestLogger(log)(remotesign.NewHandler(keys, cfg.Server.Token)),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      30 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Error().Err(err).Msg("Error during shutdown")
+		}
+	}()
+
+	log.Info().Str("listen", cfg.Server.Listen).Int("keys", len(keys)).Msg("Starting pluto-signer")
+	if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Error().Err(err).Msg("signer server failed")
+		return err
+	}
+	log.Info().Msg("shutting down")
+	return nil
+}
+
+func loadKeys(cfgKeys []config.KeyConfig) (map[string]remotesign.Key, error) {
+	keys := make(map[string]remotesign.Key, len(cfgKeys))
+	for i, k := range cfgKeys {
+		if k.Name == "" {
+			return nil, fmt.Errorf("keys[%d]: empty name", i)
+		}
+		if _, dup := keys[k.Name]; dup {
+			return nil, fmt.Errorf("keys[%d]: duplicate name %q", i, k.Name)
+		}
+		raw, err := base64.StdEncoding.DecodeString(k.PrivateKeyB64)
+		if err != nil {
+			return nil, fmt.Errorf("keys[%d]: invalid private_key_b64: %w", i, err)
+		}
+
+		var key remotesign.Key
+		switch k.Algorithm {
+		case remotesign.AlgEd25519:
+			key, err = remotesign.NewEd25519Key(ed25519.PrivateKey(raw))
+		case remotesign.AlgRS256:
+			var priv *rsa.PrivateKey
+			if priv, err = parseRSAPrivateKey(raw); err == nil {
+				key, err = remotesign.NewRSAKey(priv)
+			}
+		default:
+			err = fmt.Errorf("unsupported algorithm %q", k.Algorithm)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("keys[%d] %s: %w", i, k.Name, err)
+		}
+		keys[k.Name] = key
+	}
+	return keys, nil
+}
+
+// loadKeystore добавляет к keys ключи хранилища под их id
+func loadKeystore(cfg config.KeystoreConfig, keys map[string]remotesign.Key) error {
+	ks, err := keystore.Load(cfg.Path, cfg.PassphraseFile)
+	if err != nil {
+		return err
+	}
+	for _, k := range ks.Keys {
+		if _, dup := keys[k.ID]; dup {
+			return fmt.Errorf("keystore key %q duplicates a configured key", k.ID)
+		}
+		var key remotesign.Key
+		switch k.Algorithm {
+		case keystore.AlgEd25519:
+			var priv ed25519.PrivateKey
+			if priv, err = k.Ed25519(); err == nil {
+				key, err = remotesign.NewEd25519Key(priv)
+			}
+		case keystore.AlgRS256:
+			var priv *rsa.PrivateKey
+			if priv, err = k.RSA(); err == nil {
+				key, err = remotesign.NewRSAKey(priv)
+			}
+		default:
+			err = fmt.Errorf("unsupported algorithm %q", k.Algorithm)
+		}
+		if err != nil {
+			return fmt.Errorf("keystore key %s: %w", k.ID, err)
+		}
+		keys[k.ID] = key
+	}
+	return nil
+}
+
+// parseRSAPrivateKey — PEM с ключом PKCS #1 или PKCS #8
+func parseRSAPrivateKey(pemBytes []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("invalid PEM for private key")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not RSA")
+	}
+	return key, nil
+}
+
+func logFatalWrap(log *zerolog.Logger, err error, msg string) error {
+	log.Fatal().Err(err).Msg(msg)
+	return err
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+type Config struct {
+	Server   ServerConfig   `mapstructure:"server"`
+	Logging  LoggingConfig  `mapstructure:"logging"`
+	Keys     []KeyConfig    `mapstructure:"keys"`
+	Keystore KeystoreConfig `mapstructure:"keystore"`
+}
+
+type ServerConfig struct {
+	// Listen — unix:///path/to.sock или http://host:port
+	Listen string `mapstructure:"listen"`
+	// Token — общий секрет клиентов; пустой — без проверки (только для Unix-сокета с правами)
+	Token string `mapstructure:"token"`
+}
+
+type LoggingConfig struct {
+	Level string `mapstructure:"level"`
+}
+
+// KeyConfig — ключ, который сервис держит у себя. Для Ed25519 private_key_b64 —
+// 64 байта ключа в base64, для RS256 — base64 PEM (PKCS #1 или PKCS #8).
+// В репозиторных конфигах keys пуст: ключи берутся из keystore
+type KeyConfig struct {
+	Name          string `mapstructure:"name"`
+	Algorithm     string `mapstructure:"algorithm"` // Ed25519 | RS256
+	PrivateKeyB64 string `mapstructure:"private_key_b64"`
+}
+
+// KeystoreConfig — зашифрованное хранилище (internal/platform/keystore); все его ключи
+// доступны по id. Пароль берётся из PLUTO_KEYSTORE_PASSPHRASE, иначе из passphrase_file
+type KeystoreConfig struct {
+	Path           string `mapstructure:"path"`
+	PassphraseFile string `mapstructure:"passphrase_file"`
+}
+
+func loadConfig(path string) Config {
+	v := viper.New()
+
+	v.SetConfigFile(path)
+
+	v.AutomaticEnv()
+	v.SetEnvPrefix("PLUTO")
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+
+	if err := v.ReadInConfig(); err != nil {
+		panic(err)
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		panic(err)
+	}
+	return cfg
+}
+
+var (
+	instance   *Config
+	once       sync.Once
+	configPath = "configs/signer.yaml"
+)
+
+func GetConfig() *Config {
+	once.Do(func() {
+		cfg := loadConfig(configPath)
+		instance = &cfg
+	})
+	return instance
+}
@@ -0,0 +1,78 @@
+CREATE TABLE IF NOT EXISTS manifest_versions
+(
+    manifest_id  UUID        NOT NULL REFERENCES manifest (id),
+    version      TEXT        NOT NULL, -- "1.2.0"
+    icon         TEXT        NOT NULL,
+    category     TEXT        NOT NULL,
+    tags         TEXT[]      NOT NULL,
+    author_name  TEXT        NOT NULL,
+    author_email TEXT        NOT NULL,
+    ui           JSONB       NOT NULL,
+    script       TEXT        NOT NULL,
+    actions      JSONB       NOT NULL,
+    permissions  TEXT[]      NOT NULL,
+    localization JSONB       NOT NULL, -- { "en": { "title": … }, "ru": { … } }
+    signature    TEXT        NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (manifest_id, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_manifest_versions_created_at
+    ON manifest_versions (manifest_id, created_at DESC);
+
+-- подписанный релиз неизменяем: удалять нельзя, а содержимое нельзя переписать
+CREATE OR REPLACE FUNCTION manifest_versions_immutable() RETURNS trigger AS
+$$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        RAISE EXCEPTION 'manifest_versions is append-only';
+    END IF;
+    IF NEW.manifest_id IS DISTINCT FROM OLD.manifest_id
+        OR NEW.version IS DISTINCT FROM OLD.version
+        OR NEW.icon IS DISTINCT FROM OLD.icon
+        OR NEW.category IS DISTINCT FROM OLD.category
+        OR NEW.tags IS DISTINCT FROM OLD.tags
+        OR NEW.author_name IS DISTINCT FROM OLD.author_name
+        OR NEW.author_email IS DISTINCT FROM OLD.author_email
+        OR NEW.ui IS DISTINCT FROM OLD.ui
+        OR NEW.script IS DISTINCT FROM OLD.script
+        OR NEW.actions IS DISTINCT FROM OLD.actions
+        OR NEW.permissions IS DISTINCT FROM OLD.permissions
+        OR NEW.localization IS DISTINCT FROM OLD.localization
+        OR NEW.created_at IS DISTINCT FROM OLD.created_at THEN
+        RAISE EXCEPTION 'manifest_versions content is immutable';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_manifest_versions_immutable
+    BEFORE UPDATE OR DELETE
+    ON manifest_versions
+    FOR EACH ROW
+EXECUTE FUNCTION manifest_versions_immutable();
+
+-- переносим уже опубликованные манифесты как их первую (текущую) версию
+INSERT INTO manifest_versions (manifest_id, version, icon, category, tags, author_name, author_email,
+                               ui, script, actions, permissions, localization, signature, created_at)
+SELECT m.id,
+       m.version,
+       m.icon,
+       m.category,
+       m.tags,
+       m.author_name,
+       m.author_email,
+       mc.ui,
+       mc.script,
+       mc.actions,
+       mc.permissions,
+       coalesce((SELECT jsonb_object_agg(per_locale.locale, per_locale.entries)
+                 FROM (SELECT l.locale, jsonb_object_agg(l.key, l.value) AS entries
+                       FROM manifest_localizations l
+                       WHERE l.manifest_id = m.id
+                       GROUP BY l.locale) AS per_locale), '{}'::jsonb),
+       m.signature,
+       m.created_at
+FROM manifest m
+         JOIN manifest_content mc ON mc.manifest_id = m.id
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,3 @@
+-- Keyset-пагинация списка: ORDER BY created_at DESC, id DESC
+CREATE INDEX IF NOT EXISTS idx_manifest_created_at_id
+    ON manifest (created_at DESC, id DESC);
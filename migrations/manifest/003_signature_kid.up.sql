@@ -0,0 +1,9 @@
+-- kid (RFC 7638) ключа, которым подписан манифест; NULL — подписано до появления
+-- связки ключей, т.е. исходным ключом из signing.private_key_b64
+ALTER TABLE manifest
+    ADD COLUMN IF NOT EXISTS signature_kid TEXT;
+
+-- в manifest_versions колонка не входит в проверку неизменяемости: переподпись
+-- новым ключом меняет signature и signature_kid, но не содержимое релиза
+ALTER TABLE manifest_versions
+    ADD COLUMN IF NOT EXISTS signature_kid TEXT;
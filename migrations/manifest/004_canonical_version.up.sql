@@ -0,0 +1,7 @@
+-- схема канонизации подписанного payload: 1 — без локализаций, 2 — с деревом хешей
+-- локализаций и createdAt. Уже сохранённые подписи остаются v1 до переподписи
+ALTER TABLE manifest
+    ADD COLUMN IF NOT EXISTS canonical_version SMALLINT NOT NULL DEFAULT 1;
+
+ALTER TABLE manifest_versions
+    ADD COLUMN IF NOT EXISTS canonical_version SMALLINT NOT NULL DEFAULT 1;
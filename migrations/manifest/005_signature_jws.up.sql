@@ -0,0 +1,6 @@
+-- detached JWS выпускается вместе с signature при публикации и переподписи;
+-- GET отдаёт сохранённый JWS, а не подписывает его заново.
+-- NULL — версия подписана до появления колонки, JWS ей выпустит manifest-resign.
+-- Как и signature, в проверку неизменяемости не входит
+ALTER TABLE manifest_versions
+    ADD COLUMN IF NOT EXISTS signature_jws TEXT;
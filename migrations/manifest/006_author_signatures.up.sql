@@ -0,0 +1,48 @@
+-- публичные ключи авторов; kid — отпечаток RFC 7638, ключ привязан к email автора манифеста
+CREATE TABLE IF NOT EXISTS author_keys
+(
+    kid          TEXT PRIMARY KEY,
+    author_email TEXT        NOT NULL,
+    public_key   BYTEA       NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_author_keys_email
+    ON author_keys (lower(author_email));
+
+-- подпись автора над каноническим payload; NULL — манифест подписан только платформой
+ALTER TABLE manifest
+    ADD COLUMN IF NOT EXISTS author_kid       TEXT,
+    ADD COLUMN IF NOT EXISTS author_signature TEXT;
+
+ALTER TABLE manifest_versions
+    ADD COLUMN IF NOT EXISTS author_kid       TEXT,
+    ADD COLUMN IF NOT EXISTS author_signature TEXT;
+
+-- подпись автора — часть релиза: в отличие от подписи платформы, переподписать её нельзя
+CREATE OR REPLACE FUNCTION manifest_versions_immutable() RETURNS trigger AS
+$$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        RAISE EXCEPTION 'manifest_versions is append-only';
+    END IF;
+    IF NEW.manifest_id IS DISTINCT FROM OLD.manifest_id
+        OR NEW.version IS DISTINCT FROM OLD.version
+        OR NEW.icon IS DISTINCT FROM OLD.icon
+        OR NEW.category IS DISTINCT FROM OLD.category
+        OR NEW.tags IS DISTINCT FROM OLD.tags
+        OR NEW.author_name IS DISTINCT FROM OLD.author_name
+        OR NEW.author_email IS DISTINCT FROM OLD.author_email
+        OR NEW.ui IS DISTINCT FROM OLD.ui
+        OR NEW.script IS DISTINCT FROM OLD.script
+        OR NEW.actions IS DISTINCT FROM OLD.actions
+        OR NEW.permissions IS DISTINCT FROM OLD.permissions
+        OR NEW.localization IS DISTINCT FROM OLD.localization
+        OR NEW.author_kid IS DISTINCT FROM OLD.author_kid
+        OR NEW.author_signature IS DISTINCT FROM OLD.author_signature
+        OR NEW.created_at IS DISTINCT FROM OLD.created_at THEN
+        RAISE EXCEPTION 'manifest_versions content is immutable';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
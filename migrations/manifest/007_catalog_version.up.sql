@@ -0,0 +1,29 @@
+-- версия каталога для подписанного snapshot: растёт при любом изменении manifest
+-- (создание, обновление, переподпись), поэтому клиент может отличить свежий каталог от старого
+CREATE TABLE IF NOT EXISTS catalog_state
+(
+    singleton  BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (singleton),
+    version    BIGINT      NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+INSERT INTO catalog_state (version)
+SELECT count(*) + 1
+FROM manifest_versions
+ON CONFLICT DO NOTHING;
+
+CREATE OR REPLACE FUNCTION catalog_state_bump() RETURNS trigger AS
+$$
+BEGIN
+    UPDATE catalog_state
+    SET version    = version + 1,
+        updated_at = now();
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_manifest_catalog_bump
+    AFTER INSERT OR UPDATE OR DELETE
+    ON manifest
+    FOR EACH STATEMENT
+EXECUTE FUNCTION catalog_state_bump();
@@ -0,0 +1,33 @@
+-- журнал прозрачности: каждая выданная подпись манифеста — лист дерева Меркла (RFC 6962).
+-- leaf_index плотный и назначается под блокировкой таблицы, leaf_data — канонический JSON
+-- manifestsig.LogEntry, leaf_hash = SHA-256(0x00 || leaf_data)
+CREATE TABLE IF NOT EXISTS transparency_log
+(
+    leaf_index    BIGINT PRIMARY KEY CHECK (leaf_index >= 0),
+    leaf_hash     BYTEA       NOT NULL,
+    leaf_data     BYTEA       NOT NULL,
+    manifest_id   UUID        NOT NULL REFERENCES manifest (id),
+    version       TEXT        NOT NULL,
+    signature_kid TEXT,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_transparency_log_leaf_hash
+    ON transparency_log (leaf_hash);
+
+CREATE INDEX IF NOT EXISTS idx_transparency_log_manifest
+    ON transparency_log (manifest_id, version);
+
+-- журнал только дополняется
+CREATE OR REPLACE FUNCTION transparency_log_append_only() RETURNS trigger AS
+$$
+BEGIN
+    RAISE EXCEPTION 'transparency_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_transparency_log_append_only
+    BEFORE UPDATE OR DELETE
+    ON transparency_log
+    FOR EACH ROW
+EXECUTE FUNCTION transparency_log_append_only();
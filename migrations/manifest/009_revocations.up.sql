@@ -0,0 +1,27 @@
+-- отзыв манифестов: version IS NULL отзывает все версии. sequence — номер списка отзыва,
+-- назначается под блокировкой таблицы, поэтому растёт в порядке коммитов
+CREATE TABLE IF NOT EXISTS revocations
+(
+    sequence    BIGINT PRIMARY KEY CHECK (sequence > 0),
+    manifest_id UUID        NOT NULL REFERENCES manifest (id),
+    version     TEXT,
+    reason      TEXT        NOT NULL CHECK (reason <> ''),
+    revoked_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_revocations_manifest_version
+    ON revocations (manifest_id, coalesce(version, ''));
+
+-- клиенты сверяют sequence с уже принятым списком, поэтому записи не меняются и не удаляются
+CREATE OR REPLACE FUNCTION revocations_append_only() RETURNS trigger AS
+$$
+BEGIN
+    RAISE EXCEPTION 'revocations are append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_revocations_append_only
+    BEFORE UPDATE OR DELETE
+    ON revocations
+    FOR EACH ROW
+EXECUTE FUNCTION revocations_append_only();
@@ -0,0 +1,57 @@
+-- реестр компонентов server-driven UI: что может стоять в ui.components[].type, JSON Schema
+-- для props (подмножество OpenAPI 3.0) и версия клиента, начиная с которой тип поддерживается.
+-- Один источник правды для клиента и сервера: CreateManifest проверяет props по props_schema,
+-- клиент получает реестр из GET /api/components
+CREATE TABLE IF NOT EXISTS ui_components
+(
+    type               TEXT PRIMARY KEY CHECK (type <> ''),
+    props_schema       JSONB       NOT NULL CHECK (jsonb_typeof(props_schema) = 'object'),
+    min_client_version TEXT        NOT NULL CHECK (min_client_version ~ '^\d+\.\d+\.\d+$'),
+    description        TEXT        NOT NULL DEFAULT '',
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+INSERT INTO ui_components (type, props_schema, min_client_version, description)
+VALUES ('text',
+        '{"type": "object",
+          "properties": {
+            "text": {"type": "string"},
+            "style": {"type": "string", "enum": ["title", "body", "caption"]}
+          },
+          "required": ["text"],
+          "additionalProperties": false}',
+        '1.0.0',
+        'Статический текст; text — ключ локализации или строка'),
+       ('button',
+        '{"type": "object",
+          "properties": {
+            "label": {"type": "string"},
+            "style": {"type": "string", "enum": ["primary", "secondary", "destructive"]},
+            "enabled": {"type": "boolean"}
+          },
+          "required": ["label"],
+          "additionalProperties": false}',
+        '1.0.0',
+        'Кнопка; нажатие вызывает actions[].onTap'),
+       ('input',
+        '{"type": "object",
+          "properties": {
+            "placeholder": {"type": "string"},
+            "value": {"type": "string"},
+            "multiline": {"type": "boolean"},
+            "maxLength": {"type": "integer", "minimum": 1}
+          },
+          "additionalProperties": false}',
+        '1.0.0',
+        'Поле ввода; значение доступно скрипту по id компонента'),
+       ('list',
+        '{"type": "object",
+          "properties": {
+            "items": {"type": "array", "items": {"type": "string"}},
+            "selectable": {"type": "boolean"}
+          },
+          "required": ["items"],
+          "additionalProperties": false}',
+        '1.1.0',
+        'Список строк; с selectable выбор элемента вызывает actions[].onTap')
+ON CONFLICT (type) DO NOTHING;
@@ -0,0 +1,50 @@
+-- совместимость манифеста с клиентом: диапазон версий приложения и список ОС (пустой — любые).
+-- component_types — типы ui.components, которые использует текущая версия; сервис пишет их
+-- вместе с ui, чтобы выдача могла сверять их с реестром и возможностями клиента
+ALTER TABLE manifest
+    ADD COLUMN IF NOT EXISTS min_app_version TEXT CHECK (min_app_version ~ '^\d+\.\d+\.\d+$'),
+    ADD COLUMN IF NOT EXISTS max_app_version TEXT CHECK (max_app_version ~ '^\d+\.\d+\.\d+$'),
+    ADD COLUMN IF NOT EXISTS os              TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS component_types TEXT[] NOT NULL DEFAULT '{}';
+
+UPDATE manifest AS m
+SET component_types = x.types
+FROM (SELECT c.manifest_id,
+             array_agg(DISTINCT comp ->> 'type') FILTER (WHERE comp ->> 'type' IS NOT NULL) AS types
+      FROM manifest_content AS c
+               CROSS JOIN LATERAL jsonb_array_elements(
+              CASE WHEN jsonb_typeof(c.ui -> 'components') = 'array' THEN c.ui -> 'components' ELSE '[]' END
+                                  ) AS comp
+      GROUP BY c.manifest_id) AS x
+WHERE x.manifest_id = m.id
+  AND x.types IS NOT NULL;
+
+-- app_version_key — MAJOR.MINOR.PATCH как int[]: массивы сравниваются поэлементно
+CREATE OR REPLACE FUNCTION app_version_key(v TEXT) RETURNS INT[] AS
+$$
+SELECT string_to_array(v, '.')::int[]
+$$ LANGUAGE sql IMMUTABLE STRICT;
+
+-- manifest_compatible — подходит ли манифест клиенту. NULL в аргументе — клиент об этом
+-- не сообщил, и это условие не проверяется. Компонент из реестра, появившийся в клиенте
+-- позже client_app_version, делает манифест несовместимым так же, как min_app_version
+CREATE OR REPLACE FUNCTION manifest_compatible(target UUID,
+                                               client_app_version TEXT,
+                                               client_os TEXT,
+                                               client_components TEXT[],
+                                               client_permissions TEXT[]) RETURNS BOOLEAN AS
+$$
+SELECT (client_app_version IS NULL OR (
+    (m.min_app_version IS NULL OR app_version_key(m.min_app_version) <= app_version_key(client_app_version))
+        AND (m.max_app_version IS NULL OR app_version_key(m.max_app_version) >= app_version_key(client_app_version))
+        AND NOT EXISTS (SELECT 1
+                        FROM ui_components AS uc
+                        WHERE uc.type = ANY (m.component_types)
+                          AND app_version_key(uc.min_client_version) > app_version_key(client_app_version))))
+           AND (client_os IS NULL OR cardinality(m.os) = 0 OR client_os = ANY (m.os))
+           AND (client_components IS NULL OR m.component_types <@ client_components)
+           AND (client_permissions IS NULL OR c.permissions <@ client_permissions)
+FROM manifest AS m
+         JOIN manifest_content AS c ON c.manifest_id = m.id
+WHERE m.id = target
+$$ LANGUAGE sql STABLE;
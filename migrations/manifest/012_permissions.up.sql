@@ -0,0 +1,48 @@
+-- каталог разрешений: что манифест может запросить в permissions. CreateManifest и UpdateManifest
+-- отклоняют имена не из каталога, а экран согласия приложения берёт тексты из GET /api/permissions.
+-- risk — насколько чувствителен доступ; requires_review — манифесты с таким разрешением
+-- нужно проверять вручную, флаг отдаётся клиентам и модерации
+CREATE TABLE IF NOT EXISTS permissions
+(
+    name            TEXT PRIMARY KEY CHECK (name ~ '^[a-z][a-z0-9]*(\.[a-z][a-z0-9]*)*$'),
+    risk            TEXT        NOT NULL CHECK (risk IN ('low', 'medium', 'high')),
+    requires_review BOOLEAN     NOT NULL DEFAULT FALSE,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- тексты для экрана согласия; en обязательна как откат для остальных локалей
+CREATE TABLE IF NOT EXISTS permission_localizations
+(
+    permission  TEXT NOT NULL REFERENCES permissions (name) ON DELETE CASCADE,
+    locale      TEXT NOT NULL,
+    title       TEXT NOT NULL CHECK (title <> ''),
+    description TEXT NOT NULL CHECK (description <> ''),
+    PRIMARY KEY (permission, locale)
+);
+
+INSERT INTO permissions (name, risk, requires_review)
+VALUES ('clipboard.read', 'medium', FALSE),
+       ('clipboard.write', 'low', FALSE),
+       ('share', 'low', FALSE),
+       ('notifications', 'low', FALSE),
+       ('network', 'medium', FALSE),
+       ('location', 'high', TRUE),
+       ('camera', 'high', TRUE)
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO permission_localizations (permission, locale, title, description)
+VALUES ('clipboard.read', 'en', 'Read clipboard', 'The plugin can read text you have copied.'),
+       ('clipboard.read', 'ru', 'Чтение буфера обмена', 'Плагин может читать скопированный вами текст.'),
+       ('clipboard.write', 'en', 'Write to clipboard', 'The plugin can put text on the clipboard.'),
+       ('clipboard.write', 'ru', 'Запись в буфер обмена', 'Плагин может помещать текст в буфер обмена.'),
+       ('share', 'en', 'Share', 'The plugin can open the system share sheet.'),
+       ('share', 'ru', 'Поделиться', 'Плагин может открывать системное меню «Поделиться».'),
+       ('notifications', 'en', 'Notifications', 'The plugin can show notifications.'),
+       ('notifications', 'ru', 'Уведомления', 'Плагин может показывать уведомления.'),
+       ('network', 'en', 'Network access', 'The plugin can send requests to the internet.'),
+       ('network', 'ru', 'Доступ к сети', 'Плагин может отправлять запросы в интернет.'),
+       ('location', 'en', 'Location', 'The plugin can see where your device is.'),
+       ('location', 'ru', 'Местоположение', 'Плагин может узнать, где находится устройство.'),
+       ('camera', 'en', 'Camera', 'The plugin can take photos and video.'),
+       ('camera', 'ru', 'Камера', 'Плагин может снимать фото и видео.')
+ON CONFLICT (permission, locale) DO NOTHING;
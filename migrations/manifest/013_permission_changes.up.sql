@@ -0,0 +1,57 @@
+-- изменение разрешений относительно предыдущей версии: клиент, у которого установлена
+-- более ранняя версия, должен снова спросить согласие, если разрешения добавились.
+-- У первой версии оба списка пусты
+ALTER TABLE manifest_versions
+    ADD COLUMN IF NOT EXISTS permissions_added   TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS permissions_removed TEXT[] NOT NULL DEFAULT '{}';
+
+-- уже опубликованные версии сравниваем с предыдущей по времени создания, сохраняя порядок
+-- разрешений в манифесте
+WITH history AS (SELECT mv.manifest_id,
+                        mv.version,
+                        mv.permissions,
+                        lag(mv.permissions) OVER (PARTITION BY mv.manifest_id ORDER BY mv.created_at) AS previous
+                 FROM manifest_versions mv)
+UPDATE manifest_versions AS mv
+SET permissions_added   = ARRAY(SELECT p
+                                FROM unnest(h.permissions) WITH ORDINALITY AS t(p, i)
+                                WHERE p <> ALL (h.previous)
+                                ORDER BY i),
+    permissions_removed = ARRAY(SELECT p
+                                FROM unnest(h.previous) WITH ORDINALITY AS t(p, i)
+                                WHERE p <> ALL (h.permissions)
+                                ORDER BY i)
+FROM history AS h
+WHERE h.manifest_id = mv.manifest_id
+  AND h.version = mv.version
+  AND h.previous IS NOT NULL;
+
+-- дельта — часть релиза, как и сами разрешения
+CREATE OR REPLACE FUNCTION manifest_versions_immutable() RETURNS trigger AS
+$$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        RAISE EXCEPTION 'manifest_versions is append-only';
+    END IF;
+    IF NEW.manifest_id IS DISTINCT FROM OLD.manifest_id
+        OR NEW.version IS DISTINCT FROM OLD.version
+        OR NEW.icon IS DISTINCT FROM OLD.icon
+        OR NEW.category IS DISTINCT FROM OLD.category
+        OR NEW.tags IS DISTINCT FROM OLD.tags
+        OR NEW.author_name IS DISTINCT FROM OLD.author_name
+        OR NEW.author_email IS DISTINCT FROM OLD.author_email
+        OR NEW.ui IS DISTINCT FROM OLD.ui
+        OR NEW.script IS DISTINCT FROM OLD.script
+        OR NEW.actions IS DISTINCT FROM OLD.actions
+        OR NEW.permissions IS DISTINCT FROM OLD.permissions
+        OR NEW.permissions_added IS DISTINCT FROM OLD.permissions_added
+        OR NEW.permissions_removed IS DISTINCT FROM OLD.permissions_removed
+        OR NEW.localization IS DISTINCT FROM OLD.localization
+        OR NEW.author_kid IS DISTINCT FROM OLD.author_kid
+        OR NEW.author_signature IS DISTINCT FROM OLD.author_signature
+        OR NEW.created_at IS DISTINCT FROM OLD.created_at THEN
+        RAISE EXCEPTION 'manifest_versions content is immutable';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
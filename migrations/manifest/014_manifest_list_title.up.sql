@@ -0,0 +1,7 @@
+-- Сортировка списка по title: ключ — первый заголовок по цепочке Accept-Language,
+-- он зависит от запроса, поэтому порядок страницы индекс не задаёт. Индекс покрывает
+-- поиск заголовка манифеста по локалям цепочки (index-only scan вместо чтения таблицы).
+-- newest и oldest читаются по idx_manifest_created_at_id из 002 в прямом и обратном порядке
+CREATE INDEX IF NOT EXISTS idx_manifest_localizations_title
+    ON manifest_localizations (manifest_id, locale) INCLUDE (value)
+    WHERE key = 'title';
@@ -0,0 +1,50 @@
+package manifestsig
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"strings"
+)
+
+// AuthorKeyProof — строка, которую автор подписывает при регистрации ключа в
+// POST /api/authors/keys: владением ключом (proof) и заверением другим своим ключом (endorsement)
+func AuthorKeyProof(email, kid string) []byte {
+	return []byte("pluto-author-key:" + strings.ToLower(email) + ":" + kid)
+}
+
+// SignAuthor подписывает AuthorCanonical манифеста ключом автора; результат
+// передаётся в authorSignature при создании или обновлении манифеста
+func SignAuthor(priv ed25519.PrivateKey, kid string, m Manifest) (AuthorSignature, error) {
+	payload, err := AuthorCanonical(m)
+	if err != nil {
+		return AuthorSignature{}, err
+	}
+	sig := ed25519.Sign(priv, payload)
+	return AuthorSignature{Kid: kid, Signature: base64.StdEncoding.EncodeToString(sig)}, nil
+}
+
+// VerifyAuthor проверяет подпись автора над AuthorCanonical заданным ключом
+func VerifyAuthor(pub ed25519.PublicKey, m Manifest) error {
+	if m.AuthorSignature == nil {
+		return ErrNoAuthorSignature
+	}
+	payload, err := AuthorCanonical(m)
+	if err != nil {
+		return err
+	}
+	return VerifyPayload(pub, payload, m.AuthorSignature.Signature)
+}
+
+// VerifyAuthorWithKeySet проверяет подпись автора ключом с её kid из набора
+// GET /api/authors/keys. Вместе с VerifyWithKeySet даёт правило
+// «подписано автором и одобрено платформой»: подпись платформы с v2 покрывает authorSignature
+func VerifyAuthorWithKeySet(set KeySet, m Manifest) error {
+	if m.AuthorSignature == nil {
+		return ErrNoAuthorSignature
+	}
+	pub, ok := set[m.AuthorSignature.Kid]
+	if !ok {
+		return ErrUnknownKey
+	}
+	return VerifyAuthor(pub, m)
+}
@@ -0,0 +1,211 @@
+package manifestsig
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gibson042/canonicaljson-go"
+)
+
+// Метаданные каталога в духе TUF: snapshot перечисляет текущие версии всех манифестов
+// с хешами их подписанного payload, короткоживущий timestamp ссылается на актуальный
+// snapshot. Оба документа подписываются ключом платформы из GET /api/keys
+const (
+	TypeSnapshot  = "snapshot"
+	TypeTimestamp = "timestamp"
+)
+
+var (
+	// ErrMalformedMetadata — документ не разбирается или другого типа
+	ErrMalformedMetadata = errors.New("malformed catalog metadata")
+	// ErrMetadataExpired — срок действия документа истёк (атака заморозки)
+	ErrMetadataExpired = errors.New("catalog metadata expired")
+	// ErrSnapshotMismatch — snapshot не совпадает с тем, на который ссылается timestamp,
+	// или манифест не совпадает со своей записью в snapshot
+	ErrSnapshotMismatch = errors.New("catalog snapshot mismatch")
+	// ErrRollback — каталог старее уже виденного клиентом или из него пропали манифесты
+	ErrRollback = errors.New("catalog rollback detected")
+)
+
+// ManifestFile — запись snapshot: текущая версия манифеста, длина и хеши его
+// канонического payload (того, что подписан)
+type ManifestFile struct {
+	Version string            `json:"version"`
+	Length  int               `json:"length"`
+	Hashes  map[string]string `json:"hashes"`
+}
+
+// MetaFile — ссылка timestamp на snapshot: номер версии, длина и хеши байт документа
+type MetaFile struct {
+	Version int64             `json:"version"`
+	Length  int               `json:"length"`
+	Hashes  map[string]string `json:"hashes"`
+}
+
+// Snapshot — текущие версии всех манифестов каталога по id
+type Snapshot struct {
+	Type      string                  `json:"_type"`
+	Version   int64                   `json:"version"`
+	Expires   time.Time               `json:"expires"`
+	Manifests map[string]ManifestFile `json:"manifests"`
+}
+
+// Timestamp — свежая ссылка на актуальный snapshot
+type Timestamp struct {
+	Type     string    `json:"_type"`
+	Version  int64     `json:"version"`
+	Expires  time.Time `json:"expires"`
+	Snapshot MetaFile  `json:"snapshot"`
+}
+
+// MetadataSignature — подпись канонического JSON из signed; sig — base64
+type MetadataSignature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// SignedMetadata — конверт документа, как его отдают /api/catalog/*
+type SignedMetadata[T any] struct {
+	Signed     T                   `json:"signed"`
+	Signatures []MetadataSignature `json:"signatures"`
+}
+
+// MetadataPayload — подписываемые байты: канонический JSON содержимого signed
+func MetadataPayload(signed any) ([]byte, error) {
+	return canonicaljson.Marshal(signed)
+}
+
+// Hashes — хеши байт в форме поля hashes; пока только SHA-256
+func Hashes(data []byte) map[string]string {
+	sum := sha256.Sum256(data)
+	return map[string]string{"sha256": hex.EncodeToString(sum[:])}
+}
+
+// VerifySnapshot проверяет подпись и срок действия snapshot из GET /api/catalog/snapshot
+func VerifySnapshot(set KeySet, data []byte, now time.Time) (Snapshot, error) {
+	var env SignedMetadata[Snapshot]
+	if err := verifyMetadata(set, data, &env); err != nil {
+		return Snapshot{}, err
+	}
+	if env.Signed.Type != TypeSnapshot {
+		return Snapshot{}, fmt.Errorf("%w: _type %q", ErrMalformedMetadata, env.Signed.Type)
+	}
+	return env.Signed, checkExpires(env.Signed.Expires, now)
+}
+
+// VerifyTimestamp проверяет подпись и срок действия timestamp из GET /api/catalog/timestamp
+func VerifyTimestamp(set KeySet, data []byte, now time.Time) (Timestamp, error) {
+	var env SignedMetadata[Timestamp]
+	if err := verifyMetadata(set, data, &env); err != nil {
+		return Timestamp{}, err
+	}
+	if env.Signed.Type != TypeTimestamp {
+		return Timestamp{}, fmt.Errorf("%w: _type %q", ErrMalformedMetadata, env.Signed.Type)
+	}
+	return env.Signed, checkExpires(env.Signed.Expires, now)
+}
+
+// verifyMetadata разбирает конверт и принимает его, если хотя бы одна подпись сделана
+// ключом из набора; подписанные байты пересобираются тем же MetadataPayload
+func verifyMetadata[T any](set KeySet, data []byte, env *SignedMetadata[T]) error {
+	if err := json.Unmarshal(data, env); err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformedMetadata, err)
+	}
+	if len(env.Signatures) == 0 {
+		return ErrNoSignature
+	}
+	payload, err := MetadataPayload(env.Signed)
+	if err != nil {
+		return err
+	}
+
+	err = ErrUnknownKey
+	for _, sig := range env.Signatures {
+		pub, ok := set[sig.KeyID]
+		if !ok {
+			continue
+		}
+		if err = VerifyPayload(pub, payload, sig.Sig); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func checkExpires(expires, now time.Time) error {
+	if !now.Before(expires) {
+		return fmt.Errorf("%w at %s", ErrMetadataExpired, expires.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// CheckSnapshot сверяет байты snapshot с ссылкой в timestamp: подменить или
+// подсунуть старый snapshot при свежем timestamp нельзя
+func CheckSnapshot(ts Timestamp, snapshot []byte) error {
+	if len(snapshot) != ts.Snapshot.Length || Hashes(snapshot)["sha256"] != ts.Snapshot.Hashes["sha256"] {
+		return fmt.Errorf("%w: snapshot %d is not the one referenced by timestamp", ErrSnapshotMismatch, ts.Snapshot.Version)
+	}
+	return nil
+}
+
+// CheckManifest сверяет манифест из API с его записью в snapshot: та же версия
+// и тот же хеш канонического payload
+func CheckManifest(s Snapshot, m Manifest) error {
+	entry, ok := s.Manifests[m.Meta.ID]
+	if !ok {
+		return fmt.Errorf("%w: manifest %s is not in snapshot", ErrSnapshotMismatch, m.Meta.ID)
+	}
+	payload, err := Canonical(m)
+	if err != nil {
+		return err
+	}
+	if entry.Version != m.Meta.Version || entry.Hashes["sha256"] != Hashes(payload)["sha256"] {
+		return fmt.Errorf("%w: manifest %s@%s", ErrSnapshotMismatch, m.Meta.ID, m.Meta.Version)
+	}
+	return nil
+}
+
+// CheckRollback сравнивает новый snapshot с последним принятым клиентом: номер snapshot
+// не убывает, манифесты не пропадают, а их версии не откатываются
+func CheckRollback(prev, next Snapshot) error {
+	if next.Version < prev.Version {
+		return fmt.Errorf("%w: snapshot %d is older than %d", ErrRollback, next.Version, prev.Version)
+	}
+	for id, was := range prev.Manifests {
+		now, ok := next.Manifests[id]
+		if !ok {
+			return fmt.Errorf("%w: manifest %s disappeared", ErrRollback, id)
+		}
+		if compareVersions(now.Version, was.Version) < 0 {
+			return fmt.Errorf("%w: manifest %s went from %s to %s", ErrRollback, id, was.Version, now.Version)
+		}
+	}
+	return nil
+}
+
+// compareVersions сравнивает MAJOR.MINOR.PATCH покомпонентно как числа
+func compareVersions(a, b string) int {
+	pa, pb := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x, _ = strconv.Atoi(pa[i])
+		}
+		if i < len(pb) {
+			y, _ = strconv.Atoi(pb[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
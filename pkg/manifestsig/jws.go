@@ -0,0 +1,114 @@
+package manifestsig
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrMalformedJWS — signatureJws не является detached JWS в компактной форме
+var ErrMalformedJWS = errors.New("malformed detached JWS")
+
+// JWSHeader — защищённый заголовок detached JWS. Payload не кодируется в base64url
+// (RFC 7797, b64=false), поэтому подписывается сам канонический JSON
+type JWSHeader struct {
+	Alg  string   `json:"alg"`
+	Kid  string   `json:"kid,omitempty"`
+	B64  bool     `json:"b64"`
+	Crit []string `json:"crit"`
+}
+
+// NewJWSHeader — заголовок EdDSA с незакодированным payload
+func NewJWSHeader(kid string) JWSHeader {
+	return JWSHeader{Alg: "EdDSA", Kid: kid, B64: false, Crit: []string{"b64"}}
+}
+
+// EncodeJWSHeader — BASE64URL(UTF8(header)), первая часть компактной формы
+func EncodeJWSHeader(h JWSHeader) (string, error) {
+	raw, err := json.Marshal(h)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// JWSSigningInput — то, что подписывается при b64=false: ASCII(protected) || '.' || payload
+func JWSSigningInput(protected string, payload []byte) []byte {
+	input := make([]byte, 0, len(protected)+1+len(payload))
+	input = append(input, protected...)
+	input = append(input, '.')
+	return append(input, payload...)
+}
+
+// DetachedJWS собирает компактную форму с пустым payload: protected..signature
+func DetachedJWS(protected string, signature []byte) string {
+	return protected + ".." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// ParseDetachedJWS разбирает protected..signature и проверяет, что заголовок
+// описывает EdDSA с b64=false, объявленным в crit
+func ParseDetachedJWS(jws string) (JWSHeader, string, []byte, error) {
+	parts := strings.Split(jws, ".")
+	if len(parts) != 3 || parts[1] != "" {
+		return JWSHeader{}, "", nil, ErrMalformedJWS
+	}
+
+	rawHeader, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return JWSHeader{}, "", nil, fmt.Errorf("%w: header: %v", ErrMalformedJWS, err)
+	}
+	// b64 по умолчанию true, отсутствие поля нельзя спутать с false
+	h := JWSHeader{B64: true}
+	if err := json.Unmarshal(rawHeader, &h); err != nil {
+		return JWSHeader{}, "", nil, fmt.Errorf("%w: header: %v", ErrMalformedJWS, err)
+	}
+	if h.Alg != "EdDSA" {
+		return JWSHeader{}, "", nil, fmt.Errorf("%w: unsupported alg %q", ErrMalformedJWS, h.Alg)
+	}
+	if h.B64 || !contains(h.Crit, "b64") {
+		return JWSHeader{}, "", nil, fmt.Errorf("%w: expected b64=false listed in crit", ErrMalformedJWS)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return JWSHeader{}, "", nil, ErrMalformedSignature
+	}
+	return h, parts[0], sig, nil
+}
+
+// VerifyJWS проверяет m.SignatureJWS над каноническим payload манифеста ключом из набора
+// по kid заголовка; для v2 дополнительно сверяет строки локализации
+func VerifyJWS(set KeySet, m Manifest) error {
+	if m.SignatureJWS == "" {
+		return ErrNoSignature
+	}
+	h, protected, sig, err := ParseDetachedJWS(m.SignatureJWS)
+	if err != nil {
+		return err
+	}
+	pub, ok := set[h.Kid]
+	if !ok {
+		return ErrUnknownKey
+	}
+
+	payload, err := Canonical(m)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(pub, JWSSigningInput(protected, payload), sig) {
+		return ErrSignatureMismatch
+	}
+	return VerifyLocalization(m)
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package manifestsig
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+// vectorJWS — detached JWS testKey(1) с kid test-kid над vectorV2 (проверен openssl pkeyutl);
+// заголовок {"alg":"EdDSA","kid":"test-kid","b64":false,"crit":["b64"]}
+const vectorJWS = "eyJhbGciOiJFZERTQSIsImtpZCI6InRlc3Qta2lkIiwiYjY0IjpmYWxzZSwiY3JpdCI6WyJiNjQiXX0" +
+	"..NHv_XEUtCQTIWK39j1LaLYp46vMiItMPnZ6SB8Mkt17rtHOEC3mVQvynZ03-4PH7kugu7t8rFHPuYZAk2wb5DQ"
+
+func jwsHeader(header string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(header))
+}
+
+func TestDetachedJWSVector(t *testing.T) {
+	protected, err := EncodeJWSHeader(NewJWSHeader("test-kid"))
+	if err != nil {
+		t.Fatalf("EncodeJWSHeader: %v", err)
+	}
+	sig := ed25519.Sign(testKey(1), JWSSigningInput(protected, []byte(vectorV2)))
+	if got := DetachedJWS(protected, sig); got != vectorJWS {
+		t.Errorf("DetachedJWS =\n%s\nwant\n%s", got, vectorJWS)
+	}
+}
+
+func TestParseDetachedJWS(t *testing.T) {
+	sig := base64.RawURLEncoding.EncodeToString(make([]byte, ed25519.SignatureSize))
+	valid := jwsHeader(`{"alg":"EdDSA","kid":"k","b64":false,"crit":["b64"]}`)
+
+	tests := []struct {
+		name    string
+		jws     string
+		wantKid string
+		wantErr error
+	}{
+		{name: "vector", jws: vectorJWS, wantKid: "test-kid"},
+		{name: "no kid", jws: jwsHeader(`{"alg":"EdDSA","b64":false,"crit":["b64"]}`) + ".." + sig},
+		{name: "valid", jws: valid + ".." + sig, wantKid: "k"},
+		{name: "attached payload", jws: valid + ".e30." + sig, wantErr: ErrMalformedJWS},
+		{name: "two parts", jws: valid + "." + sig, wantErr: ErrMalformedJWS},
+		{name: "four parts", jws: valid + "..." + sig, wantErr: ErrMalformedJWS},
+		{name: "header not base64url", jws: "eyJ+..." + sig, wantErr: ErrMalformedJWS},
+		{name: "header not json", jws: jwsHeader(`alg`) + ".." + sig, wantErr: ErrMalformedJWS},
+		{name: "other alg", jws: jwsHeader(`{"alg":"ES256","b64":false,"crit":["b64"]}`) + ".." + sig, wantErr: ErrMalformedJWS},
+		{name: "alg none", jws: jwsHeader(`{"alg":"none","b64":false,"crit":["b64"]}`) + ".." + sig, wantErr: ErrMalformedJWS},
+		{name: "b64 omitted", jws: jwsHeader(`{"alg":"EdDSA","crit":["b64"]}`) + ".." + sig, wantErr: ErrMalformedJWS},
+		{name: "b64 true", jws: jwsHeader(`{"alg":"EdDSA","b64":true,"crit":["b64"]}`) + ".." + sig, wantErr: ErrMalformedJWS},
+		{name: "b64 not critical", jws: jwsHeader(`{"alg":"EdDSA","b64":false}`) + ".." + sig, wantErr: ErrMalformedJWS},
+		{name: "signature not base64url", jws: valid + "..+/==", wantErr: ErrMalformedSignature},
+		{name: "signature too short", jws: valid + "..AAAA", wantErr: ErrMalformedSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _, _, err := ParseDetachedJWS(tt.jws)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseDetachedJWS error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && h.Kid != tt.wantKid {
+				t.Errorf("kid = %q, want %q", h.Kid, tt.wantKid)
+			}
+		})
+	}
+}
+
+func TestVerifyJWS(t *testing.T) {
+	set := KeySet{"test-kid": testKey(1).Public().(ed25519.PublicKey)}
+
+	tests := []struct {
+		name    string
+		set     KeySet
+		tamper  func(m *Manifest)
+		wantErr error
+	}{
+		{name: "vector", set: set},
+		{name: "unknown kid", set: KeySet{"other": testKey(1).Public().(ed25519.PublicKey)}, wantErr: ErrUnknownKey},
+		{name: "key rotated under same kid", set: KeySet{"test-kid": testKey(2).Public().(ed25519.PublicKey)}, wantErr: ErrSignatureMismatch},
+		{name: "content changed", set: set, tamper: func(m *Manifest) {
+			m.Permissions = append(m.Permissions, "camera")
+		}, wantErr: ErrSignatureMismatch},
+		{name: "localization changed", set: set, tamper: func(m *Manifest) {
+			m.Localization["title"] = "Clima"
+		}, wantErr: ErrLocalizationMismatch},
+		{name: "no jws", set: set, tamper: func(m *Manifest) {
+			m.SignatureJWS = ""
+		}, wantErr: ErrNoSignature},
+		{name: "plain signature is not a jws", set: set, tamper: func(m *Manifest) {
+			m.SignatureJWS = vectorSignatureV2
+		}, wantErr: ErrMalformedJWS},
+		{name: "header swapped for b64 true", set: set, tamper: func(m *Manifest) {
+			h := jwsHeader(`{"alg":"EdDSA","kid":"test-kid","b64":true,"crit":["b64"]}`)
+			m.SignatureJWS = h + vectorJWS[len(vectorJWS)-88:]
+		}, wantErr: ErrMalformedJWS},
+		{name: "kid swapped", set: KeySet{"test-kid": set["test-kid"], "b": set["test-kid"]}, tamper: func(m *Manifest) {
+			h := jwsHeader(`{"alg":"EdDSA","kid":"b","b64":false,"crit":["b64"]}`)
+			m.SignatureJWS = h + vectorJWS[len(vectorJWS)-88:]
+		}, wantErr: ErrSignatureMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := testManifest(CanonicalV2)
+			m.SignatureJWS = vectorJWS
+			if tt.tamper != nil {
+				tt.tamper(&m)
+			}
+			if err := VerifyJWS(tt.set, m); !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyJWS = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,369 @@
+// Package manifestsig проверяет подпись манифестов Pluto без обращения к серверу:
+// достаточно манифеста в том виде, в каком его отдаёт GET /api/manifests/{id},
+// и публичного ключа из GET /api/public-key либо набора ключей из GET /api/keys.
+// Подпись в форме detached JWS (RFC 7515/7797) проверяет VerifyJWS, а также
+// любая стандартная JOSE-библиотека. Подпись автора (authorSignature) проверяет
+// VerifyAuthorWithKeySet ключами из GET /api/authors/keys; ставится она SignAuthor.
+// Свежесть и полноту каталога проверяют VerifyTimestamp, VerifySnapshot, CheckSnapshot
+// и CheckRollback по документам GET /api/catalog/timestamp и /api/catalog/snapshot.
+// Попадание подписи в журнал прозрачности (GET /api/log/*) проверяют NewLogEntry
+// (NewJWSLogEntry для detached JWS), VerifyInclusion, VerifyConsistency и VerifyTreeHead.
+// Отозванные манифесты перечисляет список GET /api/revocations: его проверяют
+// VerifyRevocations и CheckRevocationRollback, а манифест — RevocationList.CheckRevoked.
+package manifestsig
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gibson042/canonicaljson-go"
+)
+
+var (
+	// ErrNoSignature — у манифеста нет подписи
+	ErrNoSignature = errors.New("manifest has no signature")
+	// ErrMalformedSignature — подпись не base64 или неверной длины
+	ErrMalformedSignature = errors.New("malformed signature")
+	// ErrSignatureMismatch — подпись не соответствует содержимому
+	ErrSignatureMismatch = errors.New("signature does not match manifest content")
+	// ErrUnknownKey — ключа с kid подписи нет среди доверенных
+	ErrUnknownKey = errors.New("signing key is unknown or no longer trusted")
+	// ErrLocalizationMismatch — строка локализации не совпадает с подписанным хешем
+	ErrLocalizationMismatch = errors.New("localization does not match signed digests")
+	// ErrUnsupportedCanonical — неизвестная версия канонического формата
+	ErrUnsupportedCanonical = errors.New("unsupported canonicalVersion")
+	// ErrNoAuthorSignature — манифест не подписан автором
+	ErrNoAuthorSignature = errors.New("manifest has no author signature")
+)
+
+// Версии канонического формата подписи
+const (
+	// CanonicalV1 — meta, ui, script.code, actions и permissions
+	CanonicalV1 = 1
+	// CanonicalV2 — v1, а также canonicalVersion, meta.createdAt и дерево хешей локализаций
+	CanonicalV2 = 2
+)
+
+// Author — автор манифеста
+type Author struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// Meta — подписываемая часть meta
+type Meta struct {
+	ID       string   `json:"id"`
+	Version  string   `json:"version"`
+	Author   Author   `json:"author"`
+	Category string   `json:"category"`
+	Icon     string   `json:"icon"`
+	Tags     []string `json:"tags"`
+	// CreatedAt подписывается начиная с v2
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// AuthorSignature — подпись автора над AuthorCanonical ключом с kid из GET /api/authors/keys
+type AuthorSignature struct {
+	Kid       string `json:"kid"`
+	Signature string `json:"signature"`
+}
+
+// Manifest — манифест в форме ответа API; поля, не входящие в подпись, игнорируются
+type Manifest struct {
+	// CanonicalVersion — схема канонизации; 0 у старых ответов означает v1
+	CanonicalVersion int `json:"canonicalVersion,omitempty"`
+
+	Meta        Meta            `json:"meta"`
+	UI          json.RawMessage `json:"ui"`
+	Script      json.RawMessage `json:"script"`
+	Actions     json.RawMessage `json:"actions"`
+	Permissions []string        `json:"permissions"`
+	Signature   string          `json:"signature"`
+	// SignatureKid — kid ключа подписи; пуст у манифестов, подписанных до ротации ключей
+	SignatureKid string `json:"signatureKid,omitempty"`
+	// SignatureJWS — detached JWS над тем же payload (ответ с signatureFormat=jws)
+	SignatureJWS string `json:"signatureJws,omitempty"`
+	// AuthorSignature — подпись автора; с v2 входит в payload подписи платформы
+	AuthorSignature *AuthorSignature `json:"authorSignature,omitempty"`
+
+	// Locale и Localization — выбранная сервером локаль и строки с откатом по ключам
+	Locale       string            `json:"locale,omitempty"`
+	Localization map[string]string `json:"localization,omitempty"`
+	// LocalizationDigests — подписанное (v2) дерево хешей всех локалей: locale → key → sha256
+	LocalizationDigests map[string]map[string]string `json:"localizationDigests,omitempty"`
+}
+
+// Parse разбирает JSON манифеста, полученный от API
+func Parse(data []byte) (Manifest, error) {
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return Manifest{}, fmt.Errorf("decode manifest: %w", err)
+	}
+	return m, nil
+}
+
+// DigestLocalization строит дерево хешей локализаций: каждая строка заменяется
+// её SHA-256 (hex). Подписывается дерево, поэтому ответ с одной локалью
+// проверяется без остальных
+func DigestLocalization(all map[string]map[string]string) map[string]map[string]string {
+	out := make(map[string]map[string]string, len(all))
+	for locale, strs := range all {
+		digests := make(map[string]string, len(strs))
+		for key, value := range strs {
+			digests[key] = digest(value)
+		}
+		out[locale] = digests
+	}
+	return out
+}
+
+func digest(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// Canonical формирует подписываемый payload: канонический JSON (отсортированные ключи,
+// без пробелов) из meta, ui, script.code, actions и permissions. С v2 в него входят
+// canonicalVersion, meta.createdAt (UTC, RFC 3339), дерево хешей локализаций
+// и подпись автора, если она есть. Порядок tags и permissions сохраняется как есть
+func Canonical(m Manifest) ([]byte, error) {
+	canon, err := canonicalFields(m)
+	if err != nil {
+		return nil, err
+	}
+	if m.CanonicalVersion == CanonicalV2 {
+		if m.Meta.CreatedAt.IsZero() {
+			return nil, errors.New("meta.createdAt is required since canonicalVersion 2")
+		}
+		canon["meta"].(map[string]any)["createdAt"] = m.Meta.CreatedAt.UTC().Format(time.RFC3339Nano)
+		if m.AuthorSignature != nil {
+			canon["authorSignature"] = map[string]string{
+				"kid":       m.AuthorSignature.Kid,
+				"signature": m.AuthorSignature.Signature,
+			}
+		}
+	} else if m.AuthorSignature != nil {
+		return nil, fmt.Errorf("%w: authorSignature requires canonicalVersion 2", ErrUnsupportedCanonical)
+	}
+	return canonicaljson.Marshal(canon)
+}
+
+// AuthorCanonical — payload подписи автора: канонический JSON v2 без meta.id и
+// meta.createdAt, которые назначает сервис при публикации, и без самой подписи автора.
+// meta.version входит: автор подписывает конкретный релиз
+func AuthorCanonical(m Manifest) ([]byte, error) {
+	m.CanonicalVersion = CanonicalV2
+	canon, err := canonicalFields(m)
+	if err != nil {
+		return nil, err
+	}
+	delete(canon["meta"].(map[string]any), "id")
+	return canonicaljson.Marshal(canon)
+}
+
+// canonicalFields — общая часть Canonical и AuthorCanonical без meta.createdAt и authorSignature
+func canonicalFields(m Manifest) (map[string]any, error) {
+	version := m.CanonicalVersion
+	if version == 0 {
+		version = CanonicalV1
+	}
+	if version != CanonicalV1 && version != CanonicalV2 {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedCanonical, version)
+	}
+
+	var script struct {
+		Code string `json:"code"`
+	}
+	if len(m.Script) > 0 {
+		if err := json.Unmarshal(m.Script, &script); err != nil {
+			return nil, fmt.Errorf("script must be an object with 'code': %w", err)
+		}
+	}
+
+	actions := m.Actions
+	if len(actions) == 0 {
+		actions = json.RawMessage("null")
+	}
+
+	meta := map[string]any{
+		"id":      m.Meta.ID,
+		"version": m.Meta.Version,
+		"author": map[string]string{
+			"name":  m.Meta.Author.Name,
+			"email": m.Meta.Author.Email,
+		},
+		"category": m.Meta.Category,
+		"icon":     m.Meta.Icon,
+		"tags":     m.Meta.Tags,
+	}
+	canon := map[string]any{
+		"actions":     actions,
+		"meta":        meta,
+		"permissions": m.Permissions,
+		"script":      map[string]string{"code": script.Code},
+		"ui":          m.UI,
+	}
+
+	if version == CanonicalV2 {
+		digests := m.LocalizationDigests
+		if digests == nil {
+			digests = map[string]map[string]string{}
+		}
+		canon["canonicalVersion"] = CanonicalV2
+		canon["localization"] = digests
+	}
+
+	return canon, nil
+}
+
+// ParsePublicKey декодирует ключ в формате GET /api/public-key (base64 Ed25519)
+func ParsePublicKey(b64 string) (ed25519.PublicKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(b64)
+	if err != nil {
+		return nil, fmt.Errorf("decode public key: %w", err)
+	}
+	if len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("public key must be %d bytes, got %d", ed25519.PublicKeySize, len(raw))
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+// VerifyPayload проверяет base64-подпись Ed25519 над готовым payload
+func VerifyPayload(pub ed25519.PublicKey, payload []byte, signatureB64 string) error {
+	if signatureB64 == "" {
+		return ErrNoSignature
+	}
+	sig, err := base64.StdEncoding.DecodeString(signatureB64)
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return ErrMalformedSignature
+	}
+	if !ed25519.Verify(pub, payload, sig) {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
+
+// Verify проверяет подпись манифеста, а для v2 — ещё и строки локализации;
+// nil означает, что манифест подлинный
+func Verify(pub ed25519.PublicKey, m Manifest) error {
+	payload, err := Canonical(m)
+	if err != nil {
+		return err
+	}
+	if err := VerifyPayload(pub, payload, m.Signature); err != nil {
+		return err
+	}
+	return VerifyLocalization(m)
+}
+
+// VerifyLocalization сверяет строки ответа с подписанным деревом хешей: каждый ключ
+// ищется по цепочке отката локали (pt-BR → pt → en), как его выбирает сервер.
+// В v1 локализация не подписана, и проверять нечего
+func VerifyLocalization(m Manifest) error {
+	if m.CanonicalVersion < CanonicalV2 {
+		return nil
+	}
+	chain := fallbackChain(m.Locale)
+	for key, value := range m.Localization {
+		found := false
+		for _, locale := range chain {
+			if d, ok := m.LocalizationDigests[locale][key]; ok {
+				if d != digest(value) {
+					return fmt.Errorf("%w: %s/%s", ErrLocalizationMismatch, locale, key)
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%w: %s is not signed", ErrLocalizationMismatch, key)
+		}
+	}
+	return nil
+}
+
+// fallbackChain повторяет правило отката сервера: pt-BR → pt → en
+func fallbackChain(locale string) []string {
+	var chain []string
+	for l := locale; l != ""; {
+		chain = append(chain, l)
+		i := strings.LastIndexByte(l, '-')
+		if i < 0 {
+			break
+		}
+		l = l[:i]
+	}
+	if len(chain) == 0 || chain[len(chain)-1] != "en" {
+		chain = append(chain, "en")
+	}
+	return chain
+}
+
+// KeySet — доверенные ключи по kid
+type KeySet map[string]ed25519.PublicKey
+
+// ParseKeySet разбирает ответ GET /api/keys (JWKS); ключи, отличные от Ed25519, пропускаются
+func ParseKeySet(data []byte) (KeySet, error) {
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Crv string `json:"crv"`
+			X   string `json:"x"`
+			Kid string `json:"kid"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, fmt.Errorf("decode key set: %w", err)
+	}
+
+	set := make(KeySet, len(jwks.Keys))
+	for _, k := range jwks.Keys {
+		if k.Kty != "OKP" || k.Crv != "Ed25519" {
+			continue
+		}
+		raw, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(raw) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("key %s: invalid Ed25519 public key", k.Kid)
+		}
+		set[k.Kid] = ed25519.PublicKey(raw)
+	}
+	return set, nil
+}
+
+// VerifyWithKeySet проверяет подпись ключом с kid манифеста; если kid не указан
+// (подпись старше ротации), пробует все ключи набора
+func VerifyWithKeySet(set KeySet, m Manifest) error {
+	payload, err := Canonical(m)
+	if err != nil {
+		return err
+	}
+
+	if m.SignatureKid != "" {
+		pub, ok := set[m.SignatureKid]
+		if !ok {
+			return ErrUnknownKey
+		}
+		if err := VerifyPayload(pub, payload, m.Signature); err != nil {
+			return err
+		}
+		return VerifyLocalization(m)
+	}
+
+	err = ErrUnknownKey
+	for _, pub := range set {
+		if err = VerifyPayload(pub, payload, m.Signature); !errors.Is(err, ErrSignatureMismatch) {
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return VerifyLocalization(m)
+}
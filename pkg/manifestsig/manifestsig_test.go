@@ -0,0 +1,321 @@
+package manifestsig
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+// testKey — детерминированный ключ, чтобы подписи в тестах были воспроизводимы
+func testKey(seed byte) ed25519.PrivateKey {
+	s := make([]byte, ed25519.SeedSize)
+	for i := range s {
+		s[i] = seed
+	}
+	return ed25519.NewKeyFromSeed(s)
+}
+
+func testManifest(canonicalVersion int) Manifest {
+	m := Manifest{
+		CanonicalVersion: canonicalVersion,
+		Meta: Meta{
+			ID:        "6f1c2a4e-8d3b-4f7a-9c1e-2b5d7a9e0f13",
+			Version:   "1.2.0",
+			Author:    Author{Name: "Ada", Email: "ada@example.com"},
+			Category:  "tools",
+			Icon:      "star",
+			Tags:      []string{"weather", "daily"},
+			CreatedAt: time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC),
+		},
+		UI:          json.RawMessage(`{"type":"list"}`),
+		Script:      json.RawMessage(`{"code":"main()"}`),
+		Actions:     json.RawMessage(`[]`),
+		Permissions: []string{"network", "location"},
+	}
+	if canonicalVersion == CanonicalV2 {
+		m.LocalizationDigests = DigestLocalization(map[string]map[string]string{
+			"en": {"title": "Weather", "subtitle": "Daily forecast"},
+			"pt": {"title": "Tempo"},
+		})
+		m.Locale = "pt-BR"
+		m.Localization = map[string]string{"title": "Tempo", "subtitle": "Daily forecast"}
+	}
+	return m
+}
+
+func signManifest(t *testing.T, priv ed25519.PrivateKey, m Manifest) Manifest {
+	t.Helper()
+	payload, err := Canonical(m)
+	if err != nil {
+		t.Fatalf("Canonical: %v", err)
+	}
+	m.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(priv, payload))
+	return m
+}
+
+func TestVerify(t *testing.T) {
+	priv := testKey(1)
+	pub := priv.Public().(ed25519.PublicKey)
+
+	tests := []struct {
+		name    string
+		version int
+		tamper  func(m *Manifest)
+		wantErr error
+	}{
+		{name: "v1", version: CanonicalV1},
+		{name: "v2", version: CanonicalV2},
+		{name: "v1 ignores localization", version: CanonicalV1, tamper: func(m *Manifest) {
+			m.Localization = map[string]string{"title": "anything"}
+		}},
+		{name: "version changed", version: CanonicalV2, tamper: func(m *Manifest) {
+			m.Meta.Version = "1.2.1"
+		}, wantErr: ErrSignatureMismatch},
+		{name: "tags reordered", version: CanonicalV1, tamper: func(m *Manifest) {
+			m.Meta.Tags = []string{"daily", "weather"}
+		}, wantErr: ErrSignatureMismatch},
+		{name: "permission dropped", version: CanonicalV2, tamper: func(m *Manifest) {
+			m.Permissions = m.Permissions[:1]
+		}, wantErr: ErrSignatureMismatch},
+		{name: "script changed", version: CanonicalV2, tamper: func(m *Manifest) {
+			m.Script = json.RawMessage(`{"code":"evil()"}`)
+		}, wantErr: ErrSignatureMismatch},
+		{name: "createdAt changed", version: CanonicalV2, tamper: func(m *Manifest) {
+			m.Meta.CreatedAt = m.Meta.CreatedAt.Add(time.Second)
+		}, wantErr: ErrSignatureMismatch},
+		{name: "downgraded to v1", version: CanonicalV2, tamper: func(m *Manifest) {
+			m.CanonicalVersion = CanonicalV1
+		}, wantErr: ErrSignatureMismatch},
+		{name: "localization string changed", version: CanonicalV2, tamper: func(m *Manifest) {
+			m.Localization["title"] = "Clima"
+		}, wantErr: ErrLocalizationMismatch},
+		{name: "localization key not signed", version: CanonicalV2, tamper: func(m *Manifest) {
+			m.Localization["footer"] = "extra"
+		}, wantErr: ErrLocalizationMismatch},
+		{name: "no signature", version: CanonicalV1, tamper: func(m *Manifest) {
+			m.Signature = ""
+		}, wantErr: ErrNoSignature},
+		{name: "signature not base64", version: CanonicalV1, tamper: func(m *Manifest) {
+			m.Signature = "not base64!"
+		}, wantErr: ErrMalformedSignature},
+		{name: "signature truncated", version: CanonicalV1, tamper: func(m *Manifest) {
+			m.Signature = m.Signature[:40]
+		}, wantErr: ErrMalformedSignature},
+		{name: "unknown canonical version", version: CanonicalV2, tamper: func(m *Manifest) {
+			m.CanonicalVersion = 3
+		}, wantErr: ErrUnsupportedCanonical},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := signManifest(t, priv, testManifest(tt.version))
+			if tt.tamper != nil {
+				tt.tamper(&m)
+			}
+			if err := Verify(pub, m); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	m := signManifest(t, priv, testManifest(CanonicalV2))
+	if err := Verify(testKey(2).Public().(ed25519.PublicKey), m); !errors.Is(err, ErrSignatureMismatch) {
+		t.Errorf("Verify with another key = %v, want %v", err, ErrSignatureMismatch)
+	}
+}
+
+func TestVerifyWithKeySet(t *testing.T) {
+	active, retired := testKey(1), testKey(2)
+	set := KeySet{
+		"active":  active.Public().(ed25519.PublicKey),
+		"retired": retired.Public().(ed25519.PublicKey),
+	}
+
+	tests := []struct {
+		name    string
+		priv    ed25519.PrivateKey
+		kid     string
+		wantErr error
+	}{
+		{name: "kid", priv: active, kid: "active"},
+		{name: "retired kid", priv: retired, kid: "retired"},
+		{name: "no kid tries all keys", priv: retired},
+		{name: "wrong kid", priv: active, kid: "retired", wantErr: ErrSignatureMismatch},
+		{name: "unknown kid", priv: active, kid: "gone", wantErr: ErrUnknownKey},
+		{name: "untrusted key without kid", priv: testKey(3), wantErr: ErrSignatureMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := signManifest(t, tt.priv, testManifest(CanonicalV2))
+			m.SignatureKid = tt.kid
+			if err := VerifyWithKeySet(set, m); !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyWithKeySet = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseKeySet(t *testing.T) {
+	pub := testKey(1).Public().(ed25519.PublicKey)
+	x := base64.RawURLEncoding.EncodeToString(pub)
+
+	tests := []struct {
+		name    string
+		jwks    string
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty", jwks: `{"keys":[]}`},
+		{name: "ed25519", jwks: `{"keys":[{"kty":"OKP","crv":"Ed25519","kid":"a","x":"` + x + `"}]}`, want: []string{"a"}},
+		{name: "rsa skipped", jwks: `{"keys":[{"kty":"RSA","kid":"r","n":"AQAB","e":"AQAB"},{"kty":"OKP","crv":"Ed25519","kid":"a","x":"` + x + `"}]}`, want: []string{"a"}},
+		{name: "x448 skipped", jwks: `{"keys":[{"kty":"OKP","crv":"X448","kid":"a","x":"` + x + `"}]}`},
+		{name: "short key", jwks: `{"keys":[{"kty":"OKP","crv":"Ed25519","kid":"a","x":"AAAA"}]}`, wantErr: true},
+		{name: "padded x", jwks: `{"keys":[{"kty":"OKP","crv":"Ed25519","kid":"a","x":"` + x + `="}]}`, wantErr: true},
+		{name: "not json", jwks: `keys`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, err := ParseKeySet([]byte(tt.jwks))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseKeySet error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(set) != len(tt.want) {
+				t.Fatalf("ParseKeySet = %d keys, want %d", len(set), len(tt.want))
+			}
+			for _, kid := range tt.want {
+				if !pub.Equal(set[kid]) {
+					t.Errorf("key %s = %x, want %x", kid, set[kid], pub)
+				}
+			}
+		})
+	}
+}
+
+// Эталонные payload для testManifest: любое изменение канонизации ломает подписи
+// уже опубликованных манифестов, поэтому байты зафиксированы, а не вычисляются
+const (
+	vectorV1 = `{"actions":[],"meta":{"author":{"email":"ada@example.com","name":"Ada"},"category":"tools","icon":"star","id":"6f1c2a4e-8d3b-4f7a-9c1e-2b5d7a9e0f13","tags":["weather","daily"],"version":"1.2.0"},"permissions":["network","location"],"script":{"code":"main()"},"ui":{"type":"list"}}`
+
+	vectorV2 = `{"actions":[],"canonicalVersion":2,"localization":{"en":{"subtitle":"b65ecc9f4f5be72ff552e3c2f62ea434749689b87769789876e29954d9415cc8","title":"a0bba638124664064545edb48049c05385d1542084b2b79bb6ec0cfea6d636c9"},"pt":{"title":"214b5b846cf3925f87ea67310e74493e27d226b85e43eaa96c0a75a7a57b9c63"}},"meta":{"author":{"email":"ada@example.com","name":"Ada"},"category":"tools","createdAt":"2025-03-14T15:09:26Z","icon":"star","id":"6f1c2a4e-8d3b-4f7a-9c1e-2b5d7a9e0f13","tags":["weather","daily"],"version":"1.2.0"},"permissions":["network","location"],"script":{"code":"main()"},"ui":{"type":"list"}}`
+
+	vectorV2Countersigned = `{"actions":[],"authorSignature":{"kid":"author-1","signature":"c2ln"},"canonicalVersion":2,"localization":{"en":{"subtitle":"b65ecc9f4f5be72ff552e3c2f62ea434749689b87769789876e29954d9415cc8","title":"a0bba638124664064545edb48049c05385d1542084b2b79bb6ec0cfea6d636c9"},"pt":{"title":"214b5b846cf3925f87ea67310e74493e27d226b85e43eaa96c0a75a7a57b9c63"}},"meta":{"author":{"email":"ada@example.com","name":"Ada"},"category":"tools","createdAt":"2025-03-14T15:09:26Z","icon":"star","id":"6f1c2a4e-8d3b-4f7a-9c1e-2b5d7a9e0f13","tags":["weather","daily"],"version":"1.2.0"},"permissions":["network","location"],"script":{"code":"main()"},"ui":{"type":"list"}}`
+
+	vectorAuthor = `{"actions":[],"canonicalVersion":2,"localization":{"en":{"subtitle":"b65ecc9f4f5be72ff552e3c2f62ea434749689b87769789876e29954d9415cc8","title":"a0bba638124664064545edb48049c05385d1542084b2b79bb6ec0cfea6d636c9"},"pt":{"title":"214b5b846cf3925f87ea67310e74493e27d226b85e43eaa96c0a75a7a57b9c63"}},"meta":{"author":{"email":"ada@example.com","name":"Ada"},"category":"tools","icon":"star","tags":["weather","daily"],"version":"1.2.0"},"permissions":["network","location"],"script":{"code":"main()"},"ui":{"type":"list"}}`
+
+	// публичный ключ testKey(1) и его подписи над vectorV1 и vectorV2 (проверены openssl pkeyutl)
+	vectorPublicKey   = "iojj3XQJ8ZX9UtstPLpdcspnCb8dlBIb83SIAbQPb1w="
+	vectorSignatureV1 = "vvfP0672Bo16uVH/ZHLhBSYSlmd5pPnpuBmWMmMdsmZ41cFuQQ6opa+kkDbqNZtajZ2fumV9rxlRuHsPwokOAg=="
+	vectorSignatureV2 = "IKEtphdzs49eo0LeBmX2clJjgznvbg9SdYaRqPxbOyZ1cK+ytBMMPVpla0vp8A36ga//DmgtLkJ9J52255W7Dg=="
+)
+
+func TestCanonicalVectors(t *testing.T) {
+	countersigned := testManifest(CanonicalV2)
+	countersigned.AuthorSignature = &AuthorSignature{Kid: "author-1", Signature: "c2ln"}
+	legacy := testManifest(CanonicalV1)
+	legacy.CanonicalVersion = 0
+
+	// поля, которые не подписываются, не должны менять payload
+	unsigned := testManifest(CanonicalV2)
+	unsigned.Signature = "sig"
+	unsigned.SignatureKid = "kid"
+	unsigned.SignatureJWS = "jws"
+	unsigned.Locale = "en"
+	unsigned.Localization = map[string]string{"title": "Weather"}
+
+	nonUTC := testManifest(CanonicalV2)
+	nonUTC.Meta.CreatedAt = nonUTC.Meta.CreatedAt.In(time.FixedZone("MSK", 3*60*60))
+
+	tests := []struct {
+		name string
+		m    Manifest
+		want string
+	}{
+		{name: "v1", m: testManifest(CanonicalV1), want: vectorV1},
+		{name: "missing canonicalVersion is v1", m: legacy, want: vectorV1},
+		{name: "v2", m: testManifest(CanonicalV2), want: vectorV2},
+		{name: "v2 countersigned", m: countersigned, want: vectorV2Countersigned},
+		{name: "v2 unsigned fields", m: unsigned, want: vectorV2},
+		{name: "v2 createdAt in UTC", m: nonUTC, want: vectorV2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Canonical(tt.m)
+			if err != nil {
+				t.Fatalf("Canonical: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Canonical =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+
+	got, err := AuthorCanonical(countersigned)
+	if err != nil {
+		t.Fatalf("AuthorCanonical: %v", err)
+	}
+	if string(got) != vectorAuthor {
+		t.Errorf("AuthorCanonical =\n%s\nwant\n%s", got, vectorAuthor)
+	}
+}
+
+func TestCanonicalRejects(t *testing.T) {
+	noCreatedAt := testManifest(CanonicalV2)
+	noCreatedAt.Meta.CreatedAt = time.Time{}
+	v1Countersigned := testManifest(CanonicalV1)
+	v1Countersigned.AuthorSignature = &AuthorSignature{Kid: "author-1", Signature: "c2ln"}
+	badScript := testManifest(CanonicalV1)
+	badScript.Script = json.RawMessage(`"main()"`)
+	future := testManifest(CanonicalV2)
+	future.CanonicalVersion = 3
+
+	tests := []struct {
+		name string
+		m    Manifest
+	}{
+		{name: "v2 without createdAt", m: noCreatedAt},
+		{name: "author signature in v1", m: v1Countersigned},
+		{name: "script is not an object", m: badScript},
+		{name: "unknown version", m: future},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := Canonical(tt.m); err == nil {
+				t.Errorf("Canonical = %s, want error", got)
+			}
+		})
+	}
+}
+
+func TestSignatureVectors(t *testing.T) {
+	pub, err := ParsePublicKey(vectorPublicKey)
+	if err != nil {
+		t.Fatalf("ParsePublicKey: %v", err)
+	}
+	if !pub.Equal(testKey(1).Public()) {
+		t.Fatal("vectorPublicKey is not testKey(1)")
+	}
+
+	tests := []struct {
+		name      string
+		version   int
+		payload   string
+		signature string
+	}{
+		{name: "v1", version: CanonicalV1, payload: vectorV1, signature: vectorSignatureV1},
+		{name: "v2", version: CanonicalV2, payload: vectorV2, signature: vectorSignatureV2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := VerifyPayload(pub, []byte(tt.payload), tt.signature); err != nil {
+				t.Errorf("VerifyPayload: %v", err)
+			}
+			m := testManifest(tt.version)
+			m.Signature = tt.signature
+			if err := Verify(pub, m); err != nil {
+				t.Errorf("Verify: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package manifestsig
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Список отзыва — подписанный ключом платформы перечень манифестов и версий, которые
+// клиент не должен запускать. sequence растёт с каждым отзывом, поэтому подмену свежего
+// списка старым клиент замечает так же, как откат каталога
+const TypeRevocations = "revocations"
+
+// ErrRevoked — манифест или его версия отозваны
+var ErrRevoked = errors.New("manifest revoked")
+
+// Revocation — запись списка; пустой Version отзывает все версии манифеста
+type Revocation struct {
+	ManifestID string    `json:"manifestId"`
+	Version    string    `json:"version,omitempty"`
+	Reason     string    `json:"reason"`
+	RevokedAt  time.Time `json:"revokedAt"`
+}
+
+// RevocationList — содержимое signed документа GET /api/revocations
+type RevocationList struct {
+	Type     string       `json:"_type"`
+	Sequence int64        `json:"sequence"`
+	Expires  time.Time    `json:"expires"`
+	Revoked  []Revocation `json:"revoked"`
+}
+
+// VerifyRevocations проверяет подпись и срок действия списка отзыва
+func VerifyRevocations(set KeySet, data []byte, now time.Time) (RevocationList, error) {
+	var env SignedMetadata[RevocationList]
+	if err := verifyMetadata(set, data, &env); err != nil {
+		return RevocationList{}, err
+	}
+	if env.Signed.Type != TypeRevocations {
+		return RevocationList{}, fmt.Errorf("%w: _type %q", ErrMalformedMetadata, env.Signed.Type)
+	}
+	return env.Signed, checkExpires(env.Signed.Expires, now)
+}
+
+// CheckRevocationRollback — новый список не старее уже принятого
+func CheckRevocationRollback(prev, next RevocationList) error {
+	if next.Sequence < prev.Sequence {
+		return fmt.Errorf("%w: revocation list %d is older than %d", ErrRollback, next.Sequence, prev.Sequence)
+	}
+	return nil
+}
+
+// Lookup ищет запись, отзывающую манифест id версии version: отзыв всего манифеста
+// важнее отзыва отдельной версии
+func (l RevocationList) Lookup(id, version string) (Revocation, bool) {
+	var found *Revocation
+	for i := range l.Revoked {
+		r := &l.Revoked[i]
+		if r.ManifestID != id {
+			continue
+		}
+		if r.Version == "" {
+			return *r, true
+		}
+		if r.Version == version && found == nil {
+			found = r
+		}
+	}
+	if found == nil {
+		return Revocation{}, false
+	}
+	return *found, true
+}
+
+// CheckRevoked возвращает ErrRevoked с причиной, если манифест нельзя запускать
+func (l RevocationList) CheckRevoked(m Manifest) error {
+	if r, ok := l.Lookup(m.Meta.ID, m.Meta.Version); ok {
+		return fmt.Errorf("%w: %s@%s: %s", ErrRevoked, m.Meta.ID, m.Meta.Version, r.Reason)
+	}
+	return nil
+}
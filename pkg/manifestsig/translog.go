@@ -0,0 +1,259 @@
+package manifestsig
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/bits"
+	"time"
+
+	"github.com/gibson042/canonicaljson-go"
+)
+
+// Журнал прозрачности — дерево Меркла по RFC 6962/9162 из всех подписей, выданных сервисом.
+// Лист — канонический JSON LogEntry; хеши листьев и узлов разделены префиксами 0x00 и 0x01
+
+var (
+	// ErrInvalidProof — доказательство включения или согласованности не сходится с корнем
+	ErrInvalidProof = errors.New("invalid Merkle proof")
+)
+
+// LogFormatJWS — Format записи о detached JWS: Signature содержит signatureJws манифеста
+const LogFormatJWS = "jws"
+
+// LogEntry — запись журнала об одной выданной подписи. Подпись payload и detached JWS
+// над ним — разные подписи ключа, поэтому у версии две записи: без Format и с LogFormatJWS
+type LogEntry struct {
+	ManifestID       string `json:"manifestId"`
+	Version          string `json:"version"`
+	CanonicalVersion int    `json:"canonicalVersion"`
+	// PayloadSHA256 — hex SHA-256 канонического payload, над которым сделана подпись
+	PayloadSHA256 string `json:"payloadSha256"`
+	Signature     string `json:"signature"`
+	SignatureKid  string `json:"signatureKid,omitempty"`
+	Format        string `json:"format,omitempty"`
+}
+
+// NewLogEntry описывает подпись манифеста в том виде, в каком он пришёл от API
+func NewLogEntry(m Manifest) (LogEntry, error) {
+	payload, err := Canonical(m)
+	if err != nil {
+		return LogEntry{}, err
+	}
+	version := m.CanonicalVersion
+	if version == 0 {
+		version = CanonicalV1
+	}
+	sum := sha256.Sum256(payload)
+	return LogEntry{
+		ManifestID:       m.Meta.ID,
+		Version:          m.Meta.Version,
+		CanonicalVersion: version,
+		PayloadSHA256:    hex.EncodeToString(sum[:]),
+		Signature:        m.Signature,
+		SignatureKid:     m.SignatureKid,
+	}, nil
+}
+
+// NewJWSLogEntry описывает detached JWS манифеста (ответ с signatureFormat=jws)
+func NewJWSLogEntry(m Manifest) (LogEntry, error) {
+	if m.SignatureJWS == "" {
+		return LogEntry{}, ErrNoSignature
+	}
+	e, err := NewLogEntry(m)
+	if err != nil {
+		return LogEntry{}, err
+	}
+	e.Signature, e.Format = m.SignatureJWS, LogFormatJWS
+	return e, nil
+}
+
+// LeafData — байты листа: канонический JSON записи
+func (e LogEntry) LeafData() ([]byte, error) {
+	return canonicaljson.Marshal(e)
+}
+
+// LeafHash — SHA-256(0x00 || data)
+func LeafHash(data []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{0x00})
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// NodeHash — SHA-256(0x01 || left || right)
+func NodeHash(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{0x01})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+// RootHash — MTH(D[n]) по хешам листьев; корень пустого дерева — SHA-256 пустой строки
+func RootHash(leaves [][]byte) []byte {
+	switch len(leaves) {
+	case 0:
+		sum := sha256.Sum256(nil)
+		return sum[:]
+	case 1:
+		return leaves[0]
+	}
+	k := split(len(leaves))
+	return NodeHash(RootHash(leaves[:k]), RootHash(leaves[k:]))
+}
+
+// InclusionProof — путь аудита PATH(index, D[n]) для листа index в дереве из leaves
+func InclusionProof(leaves [][]byte, index int) [][]byte {
+	if len(leaves) <= 1 {
+		return nil
+	}
+	k := split(len(leaves))
+	if index < k {
+		return append(InclusionProof(leaves[:k], index), RootHash(leaves[k:]))
+	}
+	return append(InclusionProof(leaves[k:], index-k), RootHash(leaves[:k]))
+}
+
+// ConsistencyProof — PROOF(first, D[n]): дерево размера first — префикс дерева из leaves
+func ConsistencyProof(leaves [][]byte, first int) [][]byte {
+	if first <= 0 || first >= len(leaves) {
+		return nil
+	}
+	return subproof(leaves, first, true)
+}
+
+func subproof(leaves [][]byte, m int, complete bool) [][]byte {
+	n := len(leaves)
+	if m == n {
+		if complete {
+			return nil
+		}
+		return [][]byte{RootHash(leaves)}
+	}
+	k := split(n)
+	if m <= k {
+		return append(subproof(leaves[:k], m, complete), RootHash(leaves[k:]))
+	}
+	return append(subproof(leaves[k:], m-k, false), RootHash(leaves[:k]))
+}
+
+// split — наибольшая степень двойки, строго меньшая n (n > 1)
+func split(n int) int {
+	return 1 << (bits.Len(uint(n-1)) - 1)
+}
+
+// VerifyInclusion проверяет, что лист с хешем leafHash стоит на позиции index
+// в дереве размера size с корнем root (RFC 9162, 2.1.3.2)
+func VerifyInclusion(leafHash []byte, index, size int64, proof [][]byte, root []byte) error {
+	if index < 0 || index >= size {
+		return fmt.Errorf("%w: index %d outside tree of size %d", ErrInvalidProof, index, size)
+	}
+	fn, sn := index, size-1
+	r := leafHash
+	for _, p := range proof {
+		if sn == 0 {
+			return fmt.Errorf("%w: proof too long", ErrInvalidProof)
+		}
+		if fn&1 == 1 || fn == sn {
+			r = NodeHash(p, r)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			r = NodeHash(r, p)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+	if sn != 0 || !bytes.Equal(r, root) {
+		return ErrInvalidProof
+	}
+	return nil
+}
+
+// VerifyConsistency проверяет, что дерево размера second с корнем secondRoot
+// продолжает дерево размера first с корнем firstRoot (RFC 9162, 2.1.4.2)
+func VerifyConsistency(first, second int64, firstRoot, secondRoot []byte, proof [][]byte) error {
+	switch {
+	case first < 0 || first > second:
+		return fmt.Errorf("%w: sizes %d and %d", ErrInvalidProof, first, second)
+	case first == second:
+		if len(proof) != 0 || !bytes.Equal(firstRoot, secondRoot) {
+			return ErrInvalidProof
+		}
+		return nil
+	case first == 0:
+		// пустое дерево — префикс любого
+		if len(proof) != 0 {
+			return ErrInvalidProof
+		}
+		return nil
+	}
+
+	// для полного поддерева его корень — начало доказательства
+	if first&(first-1) == 0 {
+		proof = append([][]byte{firstRoot}, proof...)
+	}
+	if len(proof) == 0 {
+		return ErrInvalidProof
+	}
+
+	fn, sn := first-1, second-1
+	for fn&1 == 1 {
+		fn >>= 1
+		sn >>= 1
+	}
+	fr, sr := proof[0], proof[0]
+	for _, c := range proof[1:] {
+		if sn == 0 {
+			return fmt.Errorf("%w: proof too long", ErrInvalidProof)
+		}
+		if fn&1 == 1 || fn == sn {
+			fr = NodeHash(c, fr)
+			sr = NodeHash(c, sr)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			sr = NodeHash(sr, c)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+	if sn != 0 || !bytes.Equal(fr, firstRoot) || !bytes.Equal(sr, secondRoot) {
+		return ErrInvalidProof
+	}
+	return nil
+}
+
+// TreeHead — состояние журнала: размер, корень и момент подписи
+type TreeHead struct {
+	TreeSize  int64     `json:"treeSize"`
+	RootHash  []byte    `json:"rootHash"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// SignedTreeHead — TreeHead с подписью ключа платформы над каноническим JSON TreeHead
+type SignedTreeHead struct {
+	TreeHead
+	KeyID     string `json:"keyid"`
+	Signature string `json:"signature"`
+}
+
+// VerifyTreeHead проверяет подпись головы дерева ключом из набора GET /api/keys
+func VerifyTreeHead(set KeySet, sth SignedTreeHead) error {
+	pub, ok := set[sth.KeyID]
+	if !ok {
+		return ErrUnknownKey
+	}
+	payload, err := MetadataPayload(sth.TreeHead)
+	if err != nil {
+		return err
+	}
+	return VerifyPayload(pub, payload, sth.Signature)
+}
@@ -0,0 +1,349 @@
+package manifestsig
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// ctLeaves — листья эталонных векторов RFC 6962 (certificate-transparency)
+var ctLeaves = [][]byte{
+	{},
+	{0x00},
+	{0x10},
+	{0x20, 0x21},
+	{0x30, 0x31},
+	{0x40, 0x41, 0x42, 0x43},
+	{0x50, 0x51, 0x52, 0x53, 0x54, 0x55, 0x56, 0x57},
+	{0x60, 0x61, 0x62, 0x63, 0x64, 0x65, 0x66, 0x67, 0x68, 0x69, 0x6a, 0x6b, 0x6c, 0x6d, 0x6e, 0x6f},
+}
+
+// leafHashes — хеши n листьев: первые восемь из ctLeaves, дальше синтетические
+func leafHashes(n int) [][]byte {
+	out := make([][]byte, n)
+	for i := range out {
+		if i < len(ctLeaves) {
+			out[i] = LeafHash(ctLeaves[i])
+		} else {
+			out[i] = LeafHash([]byte(fmt.Sprintf("leaf-%d", i)))
+		}
+	}
+	return out
+}
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func hexes(t *testing.T, ss ...string) [][]byte {
+	t.Helper()
+	out := make([][]byte, len(ss))
+	for i, s := range ss {
+		out[i] = mustHex(t, s)
+	}
+	return out
+}
+
+func TestRootHashVectors(t *testing.T) {
+	want := []string{
+		"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		"6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d",
+		"fac54203e7cc696cf0dfcb42c92a1d9dbaf70ad9e621f4bd8d98662f00e3c125",
+		"aeb6bcfe274b70a14fb067a5e5578264db0fa9b51af5e0ba159158f329e06e77",
+		"d37ee418976dd95753c1c73862b9398fa2a2cf9b4ff0fdfe8b30cd95209614b7",
+		"4e3bbb1f7b478dcfe71fb631631519a3bca12c9aefca1612bfce4c13a86264d4",
+		"76e67dadbcdf1e10e1b74ddc608abd2f98dfb16fbce75277b5232a127f2087ef",
+		"ddb89be403809e325750d3d263cd78929c2942b7942a34b77e122c9594a74c8c",
+		"5dc9da79a70659a9ad559cb701ded9a2ab9d823aad2f4960cfe370eff4604328",
+	}
+	for size, w := range want {
+		if got := hex.EncodeToString(RootHash(leafHashes(size))); got != w {
+			t.Errorf("RootHash(size %d) = %s, want %s", size, got, w)
+		}
+	}
+}
+
+func TestProofVectors(t *testing.T) {
+	inclusion := []struct {
+		index, size int
+		proof       [][]byte
+	}{
+		{index: 0, size: 8, proof: hexes(t,
+			"96a296d224f285c67bee93c30f8a309157f0daa35dc5b87e410b78630a09cfc7",
+			"5f083f0a1a33ca076a95279832580db3e0ef4584bdff1f54c8a360f50de3031e",
+			"6b47aaf29ee3c2af9af889bc1fb9254dabd31177f16232dd6aab035ca39bf6e4")},
+		{index: 5, size: 8, proof: hexes(t,
+			"bc1a0643b12e4d2d7c77918f44e0f4f79a838b6cf9ec5b5c283e1f4d88599e6b",
+			"ca854ea128ed050b41b35ffc1b87b8eb2bde461e9e3b5596ece6b9d5975a0ae0",
+			"d37ee418976dd95753c1c73862b9398fa2a2cf9b4ff0fdfe8b30cd95209614b7")},
+		{index: 2, size: 3, proof: hexes(t,
+			"fac54203e7cc696cf0dfcb42c92a1d9dbaf70ad9e621f4bd8d98662f00e3c125")},
+		{index: 1, size: 5, proof: hexes(t,
+			"6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d",
+			"5f083f0a1a33ca076a95279832580db3e0ef4584bdff1f54c8a360f50de3031e",
+			"bc1a0643b12e4d2d7c77918f44e0f4f79a838b6cf9ec5b5c283e1f4d88599e6b")},
+	}
+	for _, tt := range inclusion {
+		leaves := leafHashes(tt.size)
+		if got := InclusionProof(leaves, tt.index); !equalProofs(got, tt.proof) {
+			t.Errorf("InclusionProof(%d, %d) = %x, want %x", tt.index, tt.size, got, tt.proof)
+		}
+		if err := VerifyInclusion(leaves[tt.index], int64(tt.index), int64(tt.size), tt.proof, RootHash(leaves)); err != nil {
+			t.Errorf("VerifyInclusion(%d, %d): %v", tt.index, tt.size, err)
+		}
+	}
+
+	consistency := []struct {
+		first, second int
+		proof         [][]byte
+	}{
+		{first: 1, second: 8, proof: hexes(t,
+			"96a296d224f285c67bee93c30f8a309157f0daa35dc5b87e410b78630a09cfc7",
+			"5f083f0a1a33ca076a95279832580db3e0ef4584bdff1f54c8a360f50de3031e",
+			"6b47aaf29ee3c2af9af889bc1fb9254dabd31177f16232dd6aab035ca39bf6e4")},
+		{first: 6, second: 8, proof: hexes(t,
+			"0ebc5d3437fbe2db158b9f126a1d118e308181031d0a949f8dededebc558ef6a",
+			"ca854ea128ed050b41b35ffc1b87b8eb2bde461e9e3b5596ece6b9d5975a0ae0",
+			"d37ee418976dd95753c1c73862b9398fa2a2cf9b4ff0fdfe8b30cd95209614b7")},
+		{first: 2, second: 5, proof: hexes(t,
+			"5f083f0a1a33ca076a95279832580db3e0ef4584bdff1f54c8a360f50de3031e",
+			"bc1a0643b12e4d2d7c77918f44e0f4f79a838b6cf9ec5b5c283e1f4d88599e6b")},
+	}
+	for _, tt := range consistency {
+		leaves := leafHashes(tt.second)
+		if got := ConsistencyProof(leaves, tt.first); !equalProofs(got, tt.proof) {
+			t.Errorf("ConsistencyProof(%d, %d) = %x, want %x", tt.first, tt.second, got, tt.proof)
+		}
+		err := VerifyConsistency(int64(tt.first), int64(tt.second), RootHash(leaves[:tt.first]), RootHash(leaves), tt.proof)
+		if err != nil {
+			t.Errorf("VerifyConsistency(%d, %d): %v", tt.first, tt.second, err)
+		}
+	}
+}
+
+func equalProofs(a, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// tamperings — способы испортить доказательство; каждое обязано не пройти проверку
+var tamperings = []struct {
+	name string
+	fn   func(proof [][]byte) [][]byte
+}{
+	{"flip bit", func(p [][]byte) [][]byte {
+		if len(p) == 0 {
+			return nil
+		}
+		out := append([][]byte(nil), p...)
+		out[len(out)/2] = append([]byte(nil), out[len(out)/2]...)
+		out[len(out)/2][0] ^= 1
+		return out
+	}},
+	{"drop last", func(p [][]byte) [][]byte {
+		if len(p) == 0 {
+			return nil
+		}
+		return p[:len(p)-1]
+	}},
+	{"append extra", func(p [][]byte) [][]byte {
+		return append(append([][]byte(nil), p...), LeafHash([]byte("extra")))
+	}},
+	{"swap ends", func(p [][]byte) [][]byte {
+		if len(p) < 2 || bytes.Equal(p[0], p[len(p)-1]) {
+			return nil
+		}
+		out := append([][]byte(nil), p...)
+		out[0], out[len(out)-1] = out[len(out)-1], out[0]
+		return out
+	}},
+}
+
+func TestInclusionProofs(t *testing.T) {
+	for size := 1; size <= 40; size++ {
+		leaves := leafHashes(size)
+		root := RootHash(leaves)
+		grown := RootHash(leafHashes(size + 1))
+		for index := 0; index < size; index++ {
+			proof := InclusionProof(leaves, index)
+			if err := VerifyInclusion(leaves[index], int64(index), int64(size), proof, root); err != nil {
+				t.Fatalf("size %d index %d: %v", size, index, err)
+			}
+
+			for _, tm := range tamperings {
+				bad := tm.fn(proof)
+				if bad == nil {
+					continue
+				}
+				if err := VerifyInclusion(leaves[index], int64(index), int64(size), bad, root); !errors.Is(err, ErrInvalidProof) {
+					t.Errorf("size %d index %d %s: err = %v, want ErrInvalidProof", size, index, tm.name, err)
+				}
+			}
+
+			other := LeafHash([]byte("not in the log"))
+			if err := VerifyInclusion(other, int64(index), int64(size), proof, root); !errors.Is(err, ErrInvalidProof) {
+				t.Errorf("size %d index %d: foreign leaf accepted", size, index)
+			}
+			if size > 1 {
+				wrong := (index + 1) % size
+				if err := VerifyInclusion(leaves[index], int64(wrong), int64(size), proof, root); !errors.Is(err, ErrInvalidProof) {
+					t.Errorf("size %d index %d: accepted at index %d", size, index, wrong)
+				}
+			}
+			if err := VerifyInclusion(leaves[index], int64(index), int64(size+1), proof, grown); !errors.Is(err, ErrInvalidProof) {
+				t.Errorf("size %d index %d: stale proof accepted for size %d", size, index, size+1)
+			}
+		}
+
+		for _, index := range []int64{-1, int64(size)} {
+			if err := VerifyInclusion(leaves[0], index, int64(size), nil, root); !errors.Is(err, ErrInvalidProof) {
+				t.Errorf("size %d: index %d accepted", size, index)
+			}
+		}
+	}
+}
+
+func TestConsistencyProofs(t *testing.T) {
+	for second := 1; second <= 40; second++ {
+		leaves := leafHashes(second)
+		secondRoot := RootHash(leaves)
+		for first := 0; first <= second; first++ {
+			firstRoot := RootHash(leaves[:first])
+			proof := ConsistencyProof(leaves, first)
+			if err := VerifyConsistency(int64(first), int64(second), firstRoot, secondRoot, proof); err != nil {
+				t.Fatalf("%d -> %d: %v", first, second, err)
+			}
+			if first == 0 || first == second {
+				continue
+			}
+
+			for _, tm := range tamperings {
+				bad := tm.fn(proof)
+				if bad == nil {
+					continue
+				}
+				if err := VerifyConsistency(int64(first), int64(second), firstRoot, secondRoot, bad); !errors.Is(err, ErrInvalidProof) {
+					t.Errorf("%d -> %d %s: err = %v, want ErrInvalidProof", first, second, tm.name, err)
+				}
+			}
+
+			// история переписана: другой лист в старой части дерева
+			forked := append([][]byte(nil), leaves...)
+			forked[first-1] = LeafHash([]byte("forked"))
+			if err := VerifyConsistency(int64(first), int64(second), firstRoot, RootHash(forked), proof); !errors.Is(err, ErrInvalidProof) {
+				t.Errorf("%d -> %d: forked log accepted", first, second)
+			}
+			if err := VerifyConsistency(int64(first), int64(second), RootHash(leaves[:first-1]), secondRoot, proof); !errors.Is(err, ErrInvalidProof) {
+				t.Errorf("%d -> %d: wrong first root accepted", first, second)
+			}
+		}
+
+		if err := VerifyConsistency(int64(second), int64(second)-1, secondRoot, secondRoot, nil); !errors.Is(err, ErrInvalidProof) {
+			t.Errorf("shrinking %d -> %d accepted", second, second-1)
+		}
+	}
+}
+
+func TestLogEntry(t *testing.T) {
+	m := testManifest(CanonicalV2)
+	m.Signature = vectorSignatureV2
+	m.SignatureKid = "test-kid"
+	m.SignatureJWS = vectorJWS
+
+	plain, err := NewLogEntry(m)
+	if err != nil {
+		t.Fatalf("NewLogEntry: %v", err)
+	}
+	jws, err := NewJWSLogEntry(m)
+	if err != nil {
+		t.Fatalf("NewJWSLogEntry: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		entry LogEntry
+		want  string
+	}{
+		{
+			name:  "signature",
+			entry: plain,
+			want:  `{"canonicalVersion":2,"manifestId":"6f1c2a4e-8d3b-4f7a-9c1e-2b5d7a9e0f13","payloadSha256":"` + digest(vectorV2) + `","signature":"` + vectorSignatureV2 + `","signatureKid":"test-kid","version":"1.2.0"}`,
+		},
+		{
+			name:  "jws",
+			entry: jws,
+			want:  `{"canonicalVersion":2,"format":"jws","manifestId":"6f1c2a4e-8d3b-4f7a-9c1e-2b5d7a9e0f13","payloadSha256":"` + digest(vectorV2) + `","signature":"` + vectorJWS + `","signatureKid":"test-kid","version":"1.2.0"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.entry.LeafData()
+			if err != nil {
+				t.Fatalf("LeafData: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("LeafData =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+
+	m.SignatureJWS = ""
+	if _, err := NewJWSLogEntry(m); !errors.Is(err, ErrNoSignature) {
+		t.Errorf("NewJWSLogEntry without jws = %v, want ErrNoSignature", err)
+	}
+}
+
+func TestVerifyTreeHead(t *testing.T) {
+	priv := testKey(1)
+	set := KeySet{"test-kid": priv.Public().(ed25519.PublicKey)}
+	head := TreeHead{
+		TreeSize:  8,
+		RootHash:  RootHash(leafHashes(8)),
+		Timestamp: time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC),
+	}
+	payload, err := MetadataPayload(head)
+	if err != nil {
+		t.Fatalf("MetadataPayload: %v", err)
+	}
+	sth := SignedTreeHead{TreeHead: head, KeyID: "test-kid", Signature: base64.StdEncoding.EncodeToString(ed25519.Sign(priv, payload))}
+
+	tests := []struct {
+		name    string
+		tamper  func(s *SignedTreeHead)
+		wantErr error
+	}{
+		{name: "valid"},
+		{name: "size changed", tamper: func(s *SignedTreeHead) { s.TreeSize = 9 }, wantErr: ErrSignatureMismatch},
+		{name: "root changed", tamper: func(s *SignedTreeHead) { s.RootHash = RootHash(leafHashes(7)) }, wantErr: ErrSignatureMismatch},
+		{name: "timestamp changed", tamper: func(s *SignedTreeHead) { s.Timestamp = s.Timestamp.Add(time.Hour) }, wantErr: ErrSignatureMismatch},
+		{name: "unknown key", tamper: func(s *SignedTreeHead) { s.KeyID = "other" }, wantErr: ErrUnknownKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := sth
+			if tt.tamper != nil {
+				tt.tamper(&s)
+			}
+			if err := VerifyTreeHead(set, s); !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyTreeHead = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}